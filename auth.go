@@ -10,26 +10,18 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
 // Auth authenticates using the given credentials and returns a fresh token.
 // Most callers should not call this directly — use Authenticate /
 // AuthenticateContext, or let Query fetch a token lazily. Kept exported for
-// backwards compatibility.
+// backwards compatibility. Two-factor challenges are answered via the
+// WithTFACode callback.
 func (c *Client) Auth(creds *Credentials) (*Token, error) {
-	t := &Token{}
-	return t, c.Query(Request{
-		Uri:    "/api/2.0/authentication.json",
-		Method: http.MethodPost,
-		Body:   creds,
-		NoAuth: true,
-	}, &struct {
-		MetaResponse `json:",inline"`
-		Response     *Token `json:"response"`
-	}{
-		Response: t,
-	})
+	return c.authenticate(context.Background(), *creds)
 }
 
 // Authenticate validates credentials and primes the token. Library users may
@@ -37,11 +29,12 @@ func (c *Client) Auth(creds *Credentials) (*Token, error) {
 // is fetched lazily on the first request.
 //
 // Prefer AuthenticateContext in long-running jobs — it honours cancellation.
-func (c *Client) Authenticate() error { return c.ensureToken() }
+func (c *Client) Authenticate() error { return c.ensureToken(context.Background()) }
 
 // AuthenticateContext is the context-aware variant of Authenticate. If the
-// cached token is still valid it returns immediately; otherwise it performs
-// a POST to /api/2.0/authentication.json that is cancellable via ctx.
+// cached token is still valid it returns immediately; otherwise it adopts
+// Credentials.Token when set, or performs a POST to
+// /api/2.0/authentication.json that is cancellable via ctx.
 //
 // This is the recommended entry point for long-running syncs (cron,
 // watchers) because it guarantees that a stalled auth call will not block
@@ -50,39 +43,163 @@ func (c *Client) AuthenticateContext(ctx context.Context) error {
 	if c.tokenValid() {
 		return nil
 	}
-	body, err := json.Marshal(c.credentials)
-	if err != nil {
-		return fmt.Errorf("marshal credentials: %w", err)
+	// Single-flight: concurrent callers queue on authMu and re-check the
+	// token, so only the first one hits the portal.
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	if c.tokenValid() {
+		return nil
+	}
+	if tok := c.staticToken(); tok != nil {
+		c.setToken(tok)
+		return nil
+	}
+	if c.loadStoredToken() {
+		return nil
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL()+"/api/2.0/authentication.json", bytes.NewReader(body))
+	tok, err := c.authenticate(ctx, *c.credentials)
 	if err != nil {
 		return err
 	}
+	c.persistToken(tok)
+	return nil
+}
+
+// TFAChallenge describes the second factor the portal asked for after the
+// password was accepted.
+type TFAChallenge struct {
+	SMS        bool   // code was sent by SMS
+	PhoneNoise string // masked phone number the SMS went to
+	App        bool   // code comes from an authenticator app
+	AppKey     string // set when the authenticator is not yet paired: the setup key
+}
+
+// TFACodeFunc returns the one-time code for a two-factor challenge.
+type TFACodeFunc func(ctx context.Context, ch TFAChallenge) (string, error)
+
+// WithTFACode sets the callback that supplies two-factor codes. Without
+// it, authenticating a TFA-enabled account fails with ErrTFARequired.
+func WithTFACode(fn TFACodeFunc) Option {
+	return func(c *Client) { c.tfaCode = fn }
+}
+
+// authResponse is the "response" object of the authentication endpoints.
+// With TFA enabled the first call returns no token, only the flags.
+type authResponse struct {
+	Token      string `json:"token"`
+	Expires    Time   `json:"expires"`
+	SMS        bool   `json:"sms"`
+	PhoneNoise string `json:"phoneNoise"`
+	TFA        bool   `json:"tfa"`
+	TFAKey     string `json:"tfaKey"`
+}
+
+// authenticate runs the password flow for creds, answering a two-factor
+// challenge via the WithTFACode callback. A PasswordSource is resolved into
+// this call's copy of creds only, so the secret is never stored back into
+// the caller's or the client's Credentials; it is read again on re-auth.
+func (c *Client) authenticate(ctx context.Context, creds Credentials) (*Token, error) {
+	if creds.Password == "" && creds.PasswordSource != "" {
+		pw, err := ResolveSecret(ctx, creds.PasswordSource, creds)
+		if err != nil {
+			return nil, fmt.Errorf("auth: %w", err)
+		}
+		creds.Password = pw
+	}
+	r, err := c.postAuth(ctx, "/api/2.0/authentication.json", &creds)
+	if err != nil {
+		return nil, err
+	}
+	if r.Token != "" {
+		return &Token{Value: r.Token, Expires: r.Expires}, nil
+	}
+	if !r.SMS && !r.TFA {
+		return nil, fmt.Errorf("auth: empty token in response")
+	}
+	if c.tfaCode == nil {
+		return nil, fmt.Errorf("auth: %w", ErrTFARequired)
+	}
+	code, err := c.tfaCode(ctx, TFAChallenge{SMS: r.SMS, PhoneNoise: r.PhoneNoise, App: r.TFA, AppKey: r.TFAKey})
+	if err != nil {
+		return nil, fmt.Errorf("auth: tfa code: %w", err)
+	}
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, fmt.Errorf("auth: %w", ErrTFARequired)
+	}
+	r, err = c.postAuth(ctx, "/api/2.0/authentication/"+url.PathEscape(code)+".json", &creds)
+	if err != nil {
+		return nil, err
+	}
+	if r.Token == "" {
+		return nil, fmt.Errorf("auth: empty token after tfa code")
+	}
+	return &Token{Value: r.Token, Expires: r.Expires}, nil
+}
+
+// postAuth posts creds to one of the authentication endpoints.
+func (c *Client) postAuth(ctx context.Context, path string, creds *Credentials) (*authResponse, error) {
+	body, err := json.Marshal(creds)
+	if err != nil {
+		return nil, fmt.Errorf("marshal credentials: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL()+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
-		return fmt.Errorf("auth request: %w", err)
+		return nil, fmt.Errorf("auth request: %w", err)
 	}
 	defer resp.Body.Close()
 	raw, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("auth: %d %s", resp.StatusCode, truncate(string(raw), 400))
+		return nil, fmt.Errorf("auth: %w", newAPIError(http.MethodPost, req.URL.Path, resp.StatusCode, raw))
 	}
 	var env struct {
-		Response *Token `json:"response"`
+		Response *authResponse `json:"response"`
 	}
 	if err := json.Unmarshal(raw, &env); err != nil {
-		return fmt.Errorf("auth decode: %w", err)
+		return nil, fmt.Errorf("auth decode: %w", err)
 	}
-	if env.Response == nil || env.Response.Value == "" {
-		return fmt.Errorf("auth: empty token in response")
+	if env.Response == nil {
+		return nil, fmt.Errorf("auth: empty token in response")
 	}
-	c.token = env.Response
-	return nil
+	return env.Response, nil
+}
+
+// staticToken returns Credentials.Token as a Token, or nil when none is
+// configured or it was invalidated while a password is available to fall
+// back on. The value is also set as the asc_auth_key cookie, which the mail
+// attachment handler authenticates with.
+func (c *Client) staticToken() *Token {
+	v := normalizeStaticToken(c.credentials.Token)
+	c.mu.Lock()
+	rejected := c.staticRejected
+	c.mu.Unlock()
+	if v == "" || rejected {
+		return nil
+	}
+	if u, err := url.Parse(c.baseURL()); err == nil && c.client.Jar != nil {
+		c.client.Jar.SetCookies(u, []*http.Cookie{{Name: "asc_auth_key", Value: v, Path: "/"}})
+	}
+	// The portal does not tell us when an externally issued token expires;
+	// keep it until InvalidateToken.
+	return &Token{Value: v, Expires: Time(time.Now().AddDate(100, 0, 0))}
+}
+
+// normalizeStaticToken accepts a bare token or a copied "asc_auth_key=…"
+// cookie pair.
+func normalizeStaticToken(v string) string {
+	v = strings.TrimSpace(v)
+	v = strings.TrimPrefix(v, "asc_auth_key=")
+	v, _, _ = strings.Cut(v, ";")
+	return strings.TrimSpace(v)
 }
 
 // InvalidateToken clears the cached authentication token. The next request
@@ -90,32 +207,62 @@ func (c *Client) AuthenticateContext(ctx context.Context) error {
 //
 // Use this to recover from a mid-sync 401 when the server has revoked or
 // rotated the session while the Expires timestamp still looks fresh locally.
-func (c *Client) InvalidateToken() { c.token = nil }
+// A token persisted via WithTokenStore is removed as well. An invalidated
+// Credentials.Token is replaced by password authentication when a password
+// is configured; otherwise it is adopted again on the next request.
+func (c *Client) InvalidateToken() {
+	c.mu.Lock()
+	c.dropTokenLocked()
+	c.mu.Unlock()
+	if c.tokenStore != nil {
+		_ = c.tokenStore.Delete(TokenStoreKey(*c.credentials))
+	}
+}
+
+// dropTokenLocked clears the cached token; c.mu must be held. Dropping
+// Credentials.Token switches to the password flow when one is configured.
+func (c *Client) dropTokenLocked() {
+	if c.token != nil && c.token.Value == normalizeStaticToken(c.credentials.Token) && c.credentials.hasPassword() {
+		c.staticRejected = true
+	}
+	c.token = nil
+}
+
+// setToken replaces the cached token under the state lock.
+func (c *Client) setToken(t *Token) {
+	c.mu.Lock()
+	c.token = t
+	c.mu.Unlock()
+}
+
+// tokenValue returns the cached token string, or "" when none is cached.
+func (c *Client) tokenValue() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token == nil {
+		return ""
+	}
+	return c.token.Value
+}
 
 // tokenValid reports whether the cached token is present and not expired.
 func (c *Client) tokenValid() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.token != nil && !time.Time(c.token.Expires).Before(time.Now())
 }
 
-// ensureToken refreshes the authentication token when missing or expired.
-// Mirrors the logic inline in Query() but is safe to call from helpers that
-// bypass the typed Request abstraction.
-func (c *Client) ensureToken() error {
-	if c.tokenValid() {
-		return nil
-	}
-	tok, err := c.Auth(c.credentials)
-	if err != nil {
-		return err
-	}
-	c.token = tok
-	return nil
+// ensureToken refreshes the authentication token when missing or expired,
+// honouring ctx's cancellation and deadline. Safe for concurrent use:
+// re-authentication is single-flight via authMu.
+func (c *Client) ensureToken(ctx context.Context) error {
+	return c.AuthenticateContext(ctx)
 }
 
 // authHeader returns the value for the Authorization header, ensuring a token.
-func (c *Client) authHeader() (string, error) {
-	if err := c.ensureToken(); err != nil {
+func (c *Client) authHeader(ctx context.Context) (string, error) {
+	if err := c.ensureToken(ctx); err != nil {
 		return "", err
 	}
-	return c.token.Value, nil
+	return c.tokenValue(), nil
 }
@@ -0,0 +1,153 @@
+//go:build integration
+
+package onlyoffice
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// envTFACode answers a two-factor challenge from ONLYOFFICE_TFA_CODE, as
+// the CLIs do.
+func envTFACode(context.Context, TFAChallenge) (string, error) {
+	return os.Getenv("ONLYOFFICE_TFA_CODE"), nil
+}
+
+// TestIntegrationTwoFactor runs the two-factor flow against a portal
+// account that has it enabled. It skips for accounts without two-factor
+// auth and when ONLYOFFICE_TFA_CODE holds no current code.
+func TestIntegrationTwoFactor(t *testing.T) {
+	creds := skipWithoutCredentials(t)
+	ctx := context.Background()
+	err := NewClient(creds).AuthenticateContext(ctx)
+	if err == nil {
+		t.Skip("account has no two-factor auth — skipping")
+	}
+	if !errors.Is(err, ErrTFARequired) {
+		t.Fatalf("AuthenticateContext without a code callback: %v, want ErrTFARequired", err)
+	}
+	code := strings.TrimSpace(os.Getenv("ONLYOFFICE_TFA_CODE"))
+	if code == "" {
+		t.Skip("ONLYOFFICE_TFA_CODE not set — skipping two-factor test")
+	}
+
+	var got []TFAChallenge
+	c := NewClient(creds, WithTFACode(func(_ context.Context, ch TFAChallenge) (string, error) {
+		got = append(got, ch)
+		return " " + code + "\n", nil
+	}))
+	if err := c.AuthenticateContext(ctx); err != nil {
+		t.Fatalf("AuthenticateContext with a code: %v", err)
+	}
+	if len(got) != 1 || !got[0].SMS && !got[0].App {
+		t.Fatalf("challenges = %+v, want one SMS or app challenge", got)
+	}
+	if _, err := c.SelfUserID(ctx); err != nil {
+		t.Fatalf("SelfUserID with the two-factor token: %v", err)
+	}
+}
+
+// TestIntegrationPasswordSourceResolvedLazily reads the password from an
+// env: source set only after NewClient, and checks it is never stored.
+func TestIntegrationPasswordSourceResolvedLazily(t *testing.T) {
+	creds := skipWithoutCredentials(t)
+	password := creds.Password
+	creds.Password, creds.PasswordSource = "", "env:OO_TEST_PASS"
+	t.Setenv("OO_TEST_PASS", "")
+	c := NewClient(creds, WithTFACode(envTFACode))
+	t.Setenv("OO_TEST_PASS", password)
+	if err := c.AuthenticateContext(context.Background()); err != nil {
+		t.Fatalf("AuthenticateContext: %v", err)
+	}
+	if c.credentials.Password != "" {
+		t.Fatal("resolved password stored on the client")
+	}
+	if _, err := c.Auth(&creds); err != nil || creds.Password != "" {
+		t.Fatalf("Auth stored the secret in the caller's credentials (err %v)", err)
+	}
+}
+
+// TestIntegrationReauthenticatesRevokedStoredToken seeds the token store
+// with a token the portal rejects: the first request gets a 401, signs in
+// again once and refreshes the store.
+func TestIntegrationReauthenticatesRevokedStoredToken(t *testing.T) {
+	creds := skipWithoutCredentials(t)
+	s := &FileTokenStore{Path: filepath.Join(t.TempDir(), "tokens.json")}
+	_ = s.Save(TokenStoreKey(creds), &Token{Value: "revoked", Expires: Time(time.Now().Add(time.Hour))})
+
+	var mu sync.Mutex
+	var statuses []int
+	record := func(next http.RoundTripper) http.RoundTripper {
+		return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			if err == nil {
+				mu.Lock()
+				statuses = append(statuses, resp.StatusCode)
+				mu.Unlock()
+			}
+			return resp, err
+		})
+	}
+	c := NewClient(creds, WithTokenStore(s), WithTFACode(envTFACode), WithMiddleware(record))
+	if _, err := c.SelfUserID(context.Background()); err != nil {
+		t.Fatalf("SelfUserID with a revoked stored token: %v", err)
+	}
+	if len(statuses) == 0 || statuses[0] != http.StatusUnauthorized {
+		t.Fatalf("statuses = %v, want a 401 first", statuses)
+	}
+	if tok, _ := s.Load(TokenStoreKey(creds)); tok == nil || tok.Value == "revoked" {
+		t.Fatalf("store not refreshed: %+v", tok)
+	}
+}
+
+// TestIntegrationDryRun checks that a dry-run client signs in and reads
+// from the portal but sends none of its writes.
+func TestIntegrationDryRun(t *testing.T) {
+	var mu sync.Mutex
+	var sent []string
+	record := func(next http.RoundTripper) http.RoundTripper {
+		return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			sent = append(sent, req.Method+" "+req.URL.Path)
+			mu.Unlock()
+			return next.RoundTrip(req)
+		})
+	}
+	plan := NewDryRun()
+	c := NewClient(skipWithoutCredentials(t), WithDryRun(plan), WithTFACode(envTFACode), WithMiddleware(record))
+	ctx := context.Background()
+
+	co, err := c.CreateCompany(ctx, testCRMPrefix+"dry-run")
+	if err != nil {
+		t.Fatalf("CreateCompany: %v", err)
+	}
+	if co["id"] != float64(-1) {
+		t.Fatalf("synthetic response = %v", co)
+	}
+	if _, err := c.SelfUserID(ctx); err != nil {
+		t.Fatalf("SelfUserID: %v", err)
+	}
+
+	var reads int
+	for _, s := range sent {
+		switch {
+		case strings.HasPrefix(s, "GET "):
+			reads++
+		case !strings.Contains(s, "/api/2.0/authentication"):
+			t.Errorf("write reached the portal: %s", s)
+		}
+	}
+	if reads == 0 {
+		t.Errorf("sent %v, want the read to reach the portal", sent)
+	}
+	if p := plan.Plan(); len(p) != 1 || p[0].Path != "/api/2.0/crm/contact/company.json" {
+		t.Fatalf("plan %+v", p)
+	}
+}
@@ -0,0 +1,87 @@
+package onlyoffice
+
+// Auth tests without a portal: token normalisation, static-token state and
+// context plumbing. The password and two-factor flows against a live portal
+// are covered by the integration tests in auth_integration_test.go.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeStaticToken(t *testing.T) {
+	for in, want := range map[string]string{
+		"sso-token":                           "sso-token",
+		"  sso-token\n":                       "sso-token",
+		"asc_auth_key=sso-token":              "sso-token",
+		"asc_auth_key=sso-token; Path=/":      "sso-token",
+		" asc_auth_key= sso-token ;HttpOnly ": "sso-token",
+		"":                                    "",
+	} {
+		if got := normalizeStaticToken(in); got != want {
+			t.Errorf("normalizeStaticToken(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestLazyAuthUsesRequestContext(t *testing.T) {
+	c := NewClient(Credentials{Url: "http://portal.invalid", User: "u", Password: "p"},
+		WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			if err := r.Context().Err(); err != nil {
+				return nil, err
+			}
+			t.Fatalf("%s %s sent with a live context", r.Method, r.URL.Path)
+			return nil, nil
+		})))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.getJSON(ctx, "/api/2.0/people/@self.json"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestStaticTokenAndInvalidate(t *testing.T) {
+	c := noRequests(t)
+	c.credentials.Token = "asc_auth_key=sso-token; Path=/"
+	auth, err := c.authHeader(context.Background())
+	if err != nil || auth != "sso-token" {
+		t.Fatalf("authHeader=%q,%v", auth, err)
+	}
+	u, _ := url.Parse("http://portal.invalid/addons/mail")
+	if cs := c.client.Jar.Cookies(u); len(cs) != 1 || cs[0].Value != "sso-token" {
+		t.Fatalf("cookies=%v", cs)
+	}
+	// Without a password the static token is all we have: adopt it again.
+	c.InvalidateToken()
+	if auth, _ := c.authHeader(context.Background()); auth != "sso-token" {
+		t.Fatalf("re-adopt: auth=%q", auth)
+	}
+
+	// With a password an invalidated static token gives way to the
+	// password flow.
+	c = noRequests(t)
+	c.credentials.User, c.credentials.Password, c.credentials.Token = "u", "p", "sso-token"
+	if auth, _ := c.authHeader(context.Background()); auth != "sso-token" {
+		t.Fatalf("initial auth=%q", auth)
+	}
+	c.InvalidateToken()
+	if tok := c.staticToken(); tok != nil {
+		t.Fatalf("rejected static token adopted again: %+v", tok)
+	}
+}
+
+func TestPasswordSourceErrorsBeforeSending(t *testing.T) {
+	c := noRequests(t)
+	c.credentials.User, c.credentials.Token, c.credentials.PasswordSource = "u", "", "env:OO_TEST_UNSET"
+	err := c.AuthenticateContext(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "OO_TEST_UNSET") {
+		t.Fatalf("unset source: err=%v", err)
+	}
+	if c.credentials.Password != "" {
+		t.Fatalf("secret stored on the client: %q", c.credentials.Password)
+	}
+}
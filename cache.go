@@ -0,0 +1,291 @@
+package onlyoffice
+
+// Response caching for read-heavy lookups. FindCompany, FindPersonByEmail,
+// ListDealStages, ListTaskCategories, ListContactTags and GetUsers are
+// called over and over within one `oo` run or TUI session; with WithCache
+// their results are kept for a per-resource TTL. (*Client).do drops a
+// resource's entries after every create/update/delete request to its
+// endpoints, so the client never serves its own stale writes.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cached resources, used as TTL keys. Each maps to the endpoints whose
+// mutations invalidate it (see cacheInvalidations).
+const (
+	CacheDefault        = "*"
+	CacheContacts       = "contacts"        // FindCompany, FindPersonByEmail
+	CacheContactTags    = "contact-tags"    // ListContactTags
+	CacheDealStages     = "deal-stages"     // ListDealStages, ListDealStagesTyped
+	CacheTaskCategories = "task-categories" // ListTaskCategories
+	CacheUsers          = "users"           // GetUsers
+)
+
+// Cache stores encoded responses. Keys are opaque strings sharing a prefix
+// per portal account and resource; Get reports a miss for expired entries.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) ([]byte, bool, error)
+	Set(key string, value []byte, ttl time.Duration) error
+	DeletePrefix(prefix string) error
+}
+
+// DefaultCacheTTLs returns the TTLs used when WithCache gets none: short
+// for contacts, which colleagues edit all day, long for configuration such
+// as deal stages.
+func DefaultCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		CacheContacts:       2 * time.Minute,
+		CacheContactTags:    10 * time.Minute,
+		CacheDealStages:     time.Hour,
+		CacheTaskCategories: time.Hour,
+		CacheUsers:          15 * time.Minute,
+	}
+}
+
+// WithCache caches lookup results in store. ttls overrides the per-resource
+// TTLs of DefaultCacheTTLs; a CacheDefault ("*") entry applies to every
+// resource without its own, and a TTL <= 0 disables caching for a resource.
+// The same store may back several clients: entries are keyed by portal URL
+// and user (TokenStoreKey).
+func WithCache(store Cache, ttls map[string]time.Duration) Option {
+	return func(c *Client) {
+		if store == nil {
+			c.cache = nil
+			return
+		}
+		c.cache = &responseCache{store: store, ttls: ttls, gen: map[string]uint64{}}
+	}
+}
+
+// ParseCacheTTLs reads a compact spec such as "5m" (every resource) or
+// "users=1h,contacts=30s,*=10m", where each entry is resource=duration.
+// An empty spec returns (nil, nil) — the defaults.
+func ParseCacheTTLs(spec string) (map[string]time.Duration, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+	ttls := map[string]time.Duration{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		res, val, ok := strings.Cut(part, "=")
+		if !ok {
+			res, val = CacheDefault, part
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(val))
+		if err != nil {
+			return nil, fmt.Errorf("cache ttl %q: %w", part, err)
+		}
+		ttls[strings.TrimSpace(res)] = d
+	}
+	return ttls, nil
+}
+
+// DefaultCachePath returns $XDG_CACHE_HOME/oo/cache.db (or the platform
+// equivalent from os.UserCacheDir), next to the token store.
+func DefaultCachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "oo", "cache.db"), nil
+}
+
+// InvalidateCache drops the cached entries of the given resources, or of
+// all resources when none are named. Mutations sent through the client
+// invalidate automatically; this is for changes made elsewhere.
+func (c *Client) InvalidateCache(resources ...string) {
+	if c.cache == nil {
+		return
+	}
+	if len(resources) == 0 {
+		resources = []string{CacheContacts, CacheContactTags, CacheDealStages, CacheTaskCategories, CacheUsers}
+	}
+	for _, r := range resources {
+		c.invalidate(r)
+	}
+}
+
+// responseCache is the client's view of a Cache: TTL policy plus a
+// generation per resource, bumped on invalidation, so a lookup that raced
+// with a mutation does not store what it read before the write.
+type responseCache struct {
+	store Cache
+	ttls  map[string]time.Duration
+
+	mu  sync.Mutex
+	gen map[string]uint64
+}
+
+func (rc *responseCache) ttl(resource string) time.Duration {
+	if d, ok := rc.ttls[resource]; ok {
+		return d
+	}
+	if d, ok := rc.ttls[CacheDefault]; ok {
+		return d
+	}
+	return DefaultCacheTTLs()[resource]
+}
+
+func (rc *responseCache) generation(resource string) uint64 {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.gen[resource]
+}
+
+// cacheKeyPrefix scopes resource entries to the client's portal account.
+func (c *Client) cacheKeyPrefix(resource string) string {
+	return TokenStoreKey(*c.credentials) + "|" + resource + "|"
+}
+
+// cached returns the cached result for resource/key, or calls fetch and
+// caches what it returns. Store errors are not fatal — the lookup simply
+// goes to the portal.
+func cached[T any](ctx context.Context, c *Client, resource, key string, fetch func(context.Context) (T, error)) (T, error) {
+	rc := c.cache
+	if rc == nil || rc.ttl(resource) <= 0 {
+		return fetch(ctx)
+	}
+	k := c.cacheKeyPrefix(resource) + key
+	if b, ok, err := rc.store.Get(k); err == nil && ok {
+		var v T
+		if json.Unmarshal(b, &v) == nil {
+			return v, nil
+		}
+	} else if err != nil {
+		c.logCacheError("get", resource, err)
+	}
+	gen := rc.generation(resource)
+	v, err := fetch(ctx)
+	if err != nil {
+		return v, err
+	}
+	b, err := json.Marshal(v)
+	if err == nil && rc.generation(resource) == gen {
+		err = rc.store.Set(k, b, rc.ttl(resource))
+	}
+	if err != nil {
+		c.logCacheError("set", resource, err)
+	}
+	return v, nil
+}
+
+// cacheInvalidations maps endpoint prefixes to the resources a mutation
+// below them may change. Any contact change can alter a tag's item count.
+var cacheInvalidations = []struct {
+	prefix    string
+	resources []string
+}{
+	{"/api/2.0/crm/contact", []string{CacheContacts, CacheContactTags}},
+	{"/api/2.0/crm/opportunity/stage", []string{CacheDealStages}},
+	{"/api/2.0/crm/task/category", []string{CacheTaskCategories}},
+	{"/api/2.0/people", []string{CacheUsers}},
+}
+
+// invalidateFor drops the resources req may have changed. It runs after
+// every non-read request whatever the outcome: a write that timed out may
+// still have been applied.
+func (c *Client) invalidateFor(req *http.Request) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return
+	}
+	path := c.apiPath(req.URL.Path)
+	for _, inv := range cacheInvalidations {
+		if strings.HasPrefix(path, inv.prefix) {
+			for _, r := range inv.resources {
+				c.invalidate(r)
+			}
+		}
+	}
+}
+
+func (c *Client) invalidate(resource string) {
+	rc := c.cache
+	rc.mu.Lock()
+	rc.gen[resource]++
+	rc.mu.Unlock()
+	if err := rc.store.DeletePrefix(c.cacheKeyPrefix(resource)); err != nil {
+		c.logCacheError("invalidate", resource, err)
+	}
+}
+
+func (c *Client) logCacheError(op, resource string, err error) {
+	if c.logger != nil {
+		c.logger.Warn("onlyoffice: cache "+op+" failed", "resource", resource, "error", err)
+	}
+}
+
+// MemoryCache is an in-process Cache, enough for one CLI run or TUI
+// session. The zero value is ready to use.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	now     func() time.Time
+}
+
+type memoryEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache returns an empty in-process cache.
+func NewMemoryCache() *MemoryCache { return &MemoryCache{} }
+
+func (m *MemoryCache) clock() time.Time {
+	if m.now != nil {
+		return m.now()
+	}
+	return time.Now()
+}
+
+// Get implements Cache.
+func (m *MemoryCache) Get(key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !m.clock().Before(e.expires) {
+		delete(m.entries, key)
+		return nil, false, nil
+	}
+	return e.value, true, nil
+}
+
+// Set implements Cache.
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.entries == nil {
+		m.entries = map[string]memoryEntry{}
+	}
+	m.entries[key] = memoryEntry{value: value, expires: m.clock().Add(ttl)}
+	return nil
+}
+
+// DeletePrefix implements Cache.
+func (m *MemoryCache) DeletePrefix(prefix string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for k := range m.entries {
+		if strings.HasPrefix(k, prefix) {
+			delete(m.entries, k)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package onlyoffice
+
+// Cache tests without a portal: lookups go through cached with a counting
+// fetch, and invalidation is driven by requests that are never sent.
+// TestIntegrationCache checks the same against a live portal.
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// stageLookup returns a deal-stage lookup through c's cache and the number
+// of fetches it made.
+func stageLookup(c *Client) (func() []map[string]any, *int) {
+	fetches := 0
+	fetch := func(context.Context) ([]map[string]any, error) {
+		fetches++
+		return []map[string]any{{"id": 1, "title": "Lead"}}, nil
+	}
+	return func() []map[string]any {
+		v, _ := cached(context.Background(), c, CacheDealStages, "list", fetch)
+		return v
+	}, &fetches
+}
+
+func write(c *Client, method, rawURL string) {
+	req, _ := http.NewRequest(method, rawURL, nil)
+	c.invalidateFor(req)
+}
+
+func TestCacheServesRepeatLookupsAndInvalidates(t *testing.T) {
+	c := NewClient(Credentials{Url: "http://portal.invalid", Token: "tok"}, WithCache(NewMemoryCache(), nil))
+	lookup, fetches := stageLookup(c)
+	for range 3 {
+		if stages := lookup(); len(stages) != 1 || stages[0]["title"] != "Lead" {
+			t.Fatalf("stages=%v", stages)
+		}
+	}
+	if *fetches != 1 {
+		t.Fatalf("fetches=%d, want 1", *fetches)
+	}
+	// Callers may modify what they get without touching the cache.
+	lookup()[0]["title"] = "changed"
+	if again := lookup(); again[0]["title"] != "Lead" {
+		t.Fatalf("cached value aliased: %v", again)
+	}
+
+	// Reads and writes elsewhere in CRM leave deal stages alone; a stage
+	// write does not.
+	write(c, http.MethodGet, "http://portal.invalid/api/2.0/crm/opportunity/stage.json")
+	write(c, http.MethodDelete, "http://portal.invalid/api/2.0/crm/task/7.json")
+	lookup()
+	if *fetches != 1 {
+		t.Fatalf("unrelated request invalidated: fetches=%d", *fetches)
+	}
+	write(c, http.MethodDelete, "http://portal.invalid/api/2.0/crm/opportunity/stage/3.json")
+	lookup()
+	if *fetches != 2 {
+		t.Fatalf("after stage delete: fetches=%d, want 2", *fetches)
+	}
+
+	c.InvalidateCache(CacheDealStages)
+	lookup()
+	if *fetches != 3 {
+		t.Fatalf("after InvalidateCache: fetches=%d, want 3", *fetches)
+	}
+}
+
+func TestCacheInvalidatesUnderSubPath(t *testing.T) {
+	c := NewClient(Credentials{Url: "http://portal.invalid/office", Token: "tok"}, WithCache(NewMemoryCache(), nil))
+	lookup, fetches := stageLookup(c)
+	lookup()
+	write(c, http.MethodDelete, "http://portal.invalid/office/api/2.0/crm/opportunity/stage/3.json")
+	lookup()
+	if *fetches != 2 {
+		t.Fatalf("stage delete under /office did not invalidate: fetches=%d, want 2", *fetches)
+	}
+}
+
+func TestCacheDropsLookupRacingAWrite(t *testing.T) {
+	c := NewClient(Credentials{Url: "http://portal.invalid", Token: "tok"}, WithCache(NewMemoryCache(), nil))
+	fetches := 0
+	fetch := func(context.Context) ([]map[string]any, error) {
+		fetches++
+		if fetches == 1 {
+			c.InvalidateCache(CacheDealStages) // a write lands while the read is in flight
+		}
+		return nil, nil
+	}
+	for range 2 {
+		_, _ = cached(context.Background(), c, CacheDealStages, "list", fetch)
+	}
+	if fetches != 2 {
+		t.Fatalf("a read that raced an invalidation was cached: fetches=%d", fetches)
+	}
+}
+
+func TestCacheTTLs(t *testing.T) {
+	c := NewClient(Credentials{Url: "http://portal.invalid", Token: "tok"},
+		WithCache(NewMemoryCache(), map[string]time.Duration{CacheDealStages: 0}))
+	lookup, fetches := stageLookup(c)
+	lookup()
+	lookup()
+	if *fetches != 2 {
+		t.Fatalf("TTL 0 should disable caching: fetches=%d", *fetches)
+	}
+
+	m := NewMemoryCache()
+	now := time.Unix(1000, 0)
+	m.now = func() time.Time { return now }
+	_ = m.Set("k", []byte("v"), time.Minute)
+	if _, ok, _ := m.Get("k"); !ok {
+		t.Fatal("fresh entry missing")
+	}
+	now = now.Add(time.Minute)
+	if _, ok, _ := m.Get("k"); ok {
+		t.Fatal("expired entry served")
+	}
+}
+
+func TestParseCacheTTLs(t *testing.T) {
+	ttls, err := ParseCacheTTLs("users=1h, *=30s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc := &responseCache{ttls: ttls}
+	if rc.ttl(CacheUsers) != time.Hour || rc.ttl(CacheDealStages) != 30*time.Second {
+		t.Fatalf("ttls=%v", ttls)
+	}
+	if rc := (&responseCache{ttls: map[string]time.Duration{CacheUsers: time.Second}}); rc.ttl(CacheDealStages) != time.Hour {
+		t.Fatalf("unlisted resource should keep its default, got %v", rc.ttl(CacheDealStages))
+	}
+	if ttls, err := ParseCacheTTLs(""); ttls != nil || err != nil {
+		t.Fatalf("empty spec = %v, %v", ttls, err)
+	}
+	if _, err := ParseCacheTTLs("users=soon"); err == nil {
+		t.Fatal("want error for a bad duration")
+	}
+}
@@ -0,0 +1,322 @@
+// Package cassette records OnlyOffice API traffic into sanitized JSON files
+// and replays it offline. A test records once against a real portal; the
+// cassette then stands in for the portal in plain `go test ./...`, so the
+// responses under test stay real instead of hand-written.
+//
+// Wire it into a client as middleware:
+//
+//	cas, err := cassette.Load("testdata/cassettes/TestFoo.json")
+//	c := onlyoffice.NewClient(creds, onlyoffice.WithMiddleware(cas.Middleware()))
+//
+// Replay matches requests by method and path, in recorded order, preferring
+// an interaction with the same (sanitized) query so pages fetched in
+// parallel get their own responses. Requests other than GET must also carry
+// the recorded (sanitized) body, so a test that sends a different payload
+// fails instead of reading the old response; inputs that vary between runs
+// belong in Value. Authentication is exempt: its credentials are redacted.
+package cassette
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode selects whether a cassette talks to the portal.
+type Mode int
+
+const (
+	// ModeReplay serves every request from the cassette; nothing reaches the
+	// network.
+	ModeReplay Mode = iota
+	// ModeRecord forwards requests and appends the sanitized exchanges; Save
+	// writes them out.
+	ModeRecord
+)
+
+// ErrNoInteraction is returned in replay mode when the cassette holds no
+// (further) response for a request — the code under test diverged from the
+// recording.
+var ErrNoInteraction = errors.New("cassette: no recorded interaction")
+
+// ErrBodyMismatch is returned in replay mode when a recorded interaction
+// matches a request's method and path but not its body.
+var ErrBodyMismatch = errors.New("cassette: request body differs from the recording")
+
+// Interaction is one recorded request/response pair.
+type Interaction struct {
+	Method      string            `json:"method"`
+	Path        string            `json:"path"`
+	Query       string            `json:"query,omitempty"`
+	RequestBody string            `json:"request_body,omitempty"`
+	Status      int               `json:"status"`
+	Header      map[string]string `json:"header,omitempty"`
+	Body        string            `json:"body,omitempty"`
+	Base64      bool              `json:"base64,omitempty"` // Body is base64 (binary download)
+}
+
+// Cassette is a recorded session. Safe for concurrent use.
+type Cassette struct {
+	Values       map[string]string `json:"values,omitempty"`
+	Interactions []Interaction     `json:"interactions"`
+
+	path string
+	mode Mode
+	san  *Sanitizer
+
+	mu   sync.Mutex
+	used []bool // replay: interactions already served
+}
+
+// New returns an empty cassette in record mode that Save writes to path.
+func New(path string) *Cassette {
+	return &Cassette{path: path, mode: ModeRecord, san: NewSanitizer(), Values: map[string]string{}}
+}
+
+// Load reads a cassette for replay. A missing file yields an error matching
+// fs.ErrNotExist so tests can skip.
+func Load(path string) (*Cassette, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cassette{path: path, mode: ModeReplay, san: NewSanitizer()}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("cassette %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Mode reports whether the cassette records or replays.
+func (c *Cassette) Mode() Mode { return c.mode }
+
+// Path is the file the cassette was loaded from or will be saved to.
+func (c *Cassette) Path() string { return c.path }
+
+// Value returns the stored value for key, calling gen and storing its
+// result while recording. Use it for run-specific test inputs (unique
+// names, timestamps) so replay sees the same ones. Values pass through the
+// sanitizer before they are returned, so the portal sees the same
+// placeholder emails a replay will.
+func (c *Cassette) Value(key string, gen func() string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mode == ModeReplay {
+		return c.Values[key]
+	}
+	if v, ok := c.Values[key]; ok {
+		return v
+	}
+	v := c.san.Text(gen())
+	c.Values[key] = v
+	return v
+}
+
+// Middleware returns the cassette as a RoundTripper middleware (the shape of
+// onlyoffice.Middleware). In replay mode next is never called.
+func (c *Cassette) Middleware() func(next http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			if c.mode == ModeReplay {
+				return c.replay(r)
+			}
+			return c.record(r, next)
+		})
+	}
+}
+
+// Transport returns an http.RoundTripper for use outside the onlyoffice
+// client (next may be nil in replay mode; it defaults to
+// http.DefaultTransport when recording).
+func (c *Cassette) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return c.Middleware()(next)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// authPath is replayed repeatedly: recorded tokens are expired by the time a
+// cassette is replayed, so the client re-authenticates more often than it
+// did while recording.
+const authPath = "/api/2.0/authentication"
+
+func (c *Cassette) replay(r *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if r.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	auth := strings.HasPrefix(r.URL.Path, authPath)
+	checkBody := r.Method != http.MethodGet && !auth
+	c.mu.Lock()
+	query := c.san.Text(r.URL.RawQuery)
+	body := c.san.Text(bodyText(r, reqBody))
+	if c.used == nil {
+		c.used = make([]bool, len(c.Interactions))
+	}
+	hit, last, differs := -1, -1, -1
+	for i := range c.Interactions {
+		in := &c.Interactions[i]
+		if in.Method != r.Method || in.Path != r.URL.Path {
+			continue
+		}
+		last = i
+		if c.used[i] {
+			continue
+		}
+		if checkBody && in.RequestBody != body {
+			if differs < 0 {
+				differs = i
+			}
+			continue
+		}
+		if hit < 0 || (in.Query == query && c.Interactions[hit].Query != query) {
+			hit = i
+		}
+	}
+	if hit >= 0 {
+		c.used[hit] = true
+	} else if auth {
+		hit = last
+	}
+	c.mu.Unlock()
+	if hit < 0 && differs >= 0 {
+		return nil, fmt.Errorf("%w for %s %s in %s:\n sent     %s\n recorded %s",
+			ErrBodyMismatch, r.Method, r.URL.Path, c.path, body, c.Interactions[differs].RequestBody)
+	}
+	if hit < 0 {
+		return nil, fmt.Errorf("%w for %s %s in %s", ErrNoInteraction, r.Method, r.URL.Path, c.path)
+	}
+	return c.Interactions[hit].response(r)
+}
+
+func (in *Interaction) response(r *http.Request) (*http.Response, error) {
+	body := []byte(in.Body)
+	if in.Base64 {
+		var err error
+		if body, err = base64.StdEncoding.DecodeString(in.Body); err != nil {
+			return nil, fmt.Errorf("cassette: %s %s: %w", in.Method, in.Path, err)
+		}
+	}
+	h := http.Header{}
+	for k, v := range in.Header {
+		h.Set(k, v)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.Status, http.StatusText(in.Status)),
+		StatusCode:    in.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        h,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       r,
+	}, nil
+}
+
+// keptHeaders are the response headers worth recording; everything else
+// (cookies, server banners, request ids) is dropped.
+var keptHeaders = []string{"Content-Type", "Content-Disposition", "Retry-After"}
+
+func (c *Cassette) record(r *http.Request, next http.RoundTripper) (*http.Response, error) {
+	var reqBody []byte
+	if r.Body != nil {
+		var err error
+		if reqBody, err = io.ReadAll(r.Body); err != nil {
+			return nil, err
+		}
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	resp, err := next.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.san.AddHost(r.URL.Host)
+	in := Interaction{
+		Method:      r.Method,
+		Path:        r.URL.Path,
+		Query:       c.san.Text(r.URL.RawQuery),
+		RequestBody: c.san.Text(bodyText(r, reqBody)),
+		Status:      resp.StatusCode,
+	}
+	for _, k := range keptHeaders {
+		if v := resp.Header.Get(k); v != "" {
+			if in.Header == nil {
+				in.Header = map[string]string{}
+			}
+			in.Header[k] = v
+		}
+	}
+	if utf8.Valid(respBody) {
+		in.Body = c.san.Text(string(respBody))
+	} else {
+		in.Body = base64.StdEncoding.EncodeToString(respBody)
+		in.Base64 = true
+	}
+	c.Interactions = append(c.Interactions, in)
+	return resp, nil
+}
+
+// multipartBoundary stands in for the random boundary of multipart uploads,
+// so the same upload records and replays the same body.
+const multipartBoundary = "BOUNDARY"
+
+// bodyText returns a request body as recorded and compared: binary uploads
+// are summarized by size, multipart boundaries replaced.
+func bodyText(r *http.Request, b []byte) string {
+	if !utf8.Valid(b) {
+		return fmt.Sprintf("<%d bytes binary>", len(b))
+	}
+	s := string(b)
+	if _, params, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil && params["boundary"] != "" {
+		s = strings.ReplaceAll(s, params["boundary"], multipartBoundary)
+	}
+	return s
+}
+
+// Save writes a recorded cassette to its path (creating directories). It is
+// a no-op in replay mode.
+func (c *Cassette) Save() error {
+	if c.mode != ModeRecord {
+		return nil
+	}
+	c.mu.Lock()
+	b, err := json.MarshalIndent(c, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, append(b, '\n'), 0o644)
+}
@@ -0,0 +1,183 @@
+package cassette
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// portal stands in for the caller's transport, not for OnlyOffice: it echoes
+// canned bodies so the recorder's own handling can be checked.
+func portal(bodies map[string]string) http.RoundTripper {
+	return roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		key := r.Method + " " + r.URL.Path
+		if r.URL.RawQuery != "" {
+			key += "?" + r.URL.RawQuery
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}, "Set-Cookie": {"asc_auth_key=secret"}},
+			Body:       io.NopCloser(strings.NewReader(bodies[key])),
+			Request:    r,
+		}, nil
+	})
+}
+
+func get(t *testing.T, rt http.RoundTripper, method, rawURL, body string) string {
+	t.Helper()
+	var rd io.Reader
+	if body != "" {
+		rd = strings.NewReader(body)
+	}
+	req, err := http.NewRequest(method, rawURL, rd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, rawURL, err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+	return string(b)
+}
+
+func TestRecordSanitizesAndReplays(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "flow.json")
+	rec := New(path)
+	rt := rec.Transport(portal(map[string]string{
+		"POST /api/2.0/authentication":                 `{"response":{"token":"abc123","expires":"2030-01-01"}}`,
+		"GET /api/2.0/crm/contact/filter?startIndex=0": `{"response":[{"id":1,"email":"Jane.Doe@Acme.io","url":"https://crm.acme.io/x"}]}`,
+		"GET /api/2.0/crm/contact/filter?startIndex=1": `{"response":[{"id":2,"email":"jane.doe@acme.io"}]}`,
+	}))
+
+	get(t, rt, http.MethodPost, "https://crm.acme.io/api/2.0/authentication", `{"userName":"jane.doe@acme.io","password":"hunter2"}`)
+	live := get(t, rt, http.MethodGet, "https://crm.acme.io/api/2.0/crm/contact/filter?startIndex=0", "")
+	if !strings.Contains(live, "Jane.Doe@Acme.io") {
+		t.Fatalf("recording altered the live response: %s", live)
+	}
+	get(t, rt, http.MethodGet, "https://crm.acme.io/api/2.0/crm/contact/filter?startIndex=1", "")
+	name := rec.Value("name", func() string { return "owner jane.doe@acme.io" })
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, in := range raw.Interactions {
+		for _, s := range []string{in.Query, in.RequestBody, in.Body} {
+			for _, leak := range []string{"abc123", "hunter2", "acme.io", "Acme.io"} {
+				if strings.Contains(s, leak) {
+					t.Errorf("%s %s leaks %q: %s", in.Method, in.Path, leak, s)
+				}
+			}
+		}
+		if _, ok := in.Header["Set-Cookie"]; ok {
+			t.Errorf("%s %s kept Set-Cookie", in.Method, in.Path)
+		}
+	}
+	if got := raw.Interactions[1].Body; !strings.Contains(got, "user1@example.com") || !strings.Contains(got, PortalHost) {
+		t.Fatalf("body=%s", got)
+	}
+	if got := raw.Interactions[2].Body; !strings.Contains(got, "user1@example.com") {
+		t.Fatalf("same address got a different placeholder: %s", got)
+	}
+	if name != "owner user1@example.com" || raw.Value("name", nil) != name {
+		t.Fatalf("value=%q replayed=%q", name, raw.Value("name", nil))
+	}
+
+	// Replay serves pages by query even when they arrive out of order, and
+	// answers authentication as often as asked.
+	play := raw.Transport(nil)
+	if got := get(t, play, http.MethodGet, "https://"+PortalHost+"/api/2.0/crm/contact/filter?startIndex=1", ""); !strings.Contains(got, `"id":2`) {
+		t.Fatalf("page 2 replay=%s", got)
+	}
+	for i := 0; i < 3; i++ {
+		if got := get(t, play, http.MethodPost, "https://"+PortalHost+"/api/2.0/authentication", "{}"); !strings.Contains(got, Redacted) {
+			t.Fatalf("auth replay=%s", got)
+		}
+	}
+	if got := get(t, play, http.MethodGet, "https://"+PortalHost+"/api/2.0/crm/contact/filter?startIndex=0", ""); !strings.Contains(got, `"id":1`) {
+		t.Fatalf("page 1 replay=%s", got)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://"+PortalHost+"/api/2.0/crm/contact/filter", nil)
+	if _, err := play.RoundTrip(req); !errors.Is(err, ErrNoInteraction) {
+		t.Fatalf("exhausted replay err=%v", err)
+	}
+}
+
+func TestRecordBinaryBody(t *testing.T) {
+	rec := New(filepath.Join(t.TempDir(), "bin.json"))
+	bin := string([]byte{0x25, 0x50, 0x44, 0x46, 0xff, 0xfe, 0x00})
+	rt := rec.Transport(portal(map[string]string{"GET /file": bin}))
+	if got := get(t, rt, http.MethodGet, "https://x.test/file", ""); got != bin {
+		t.Fatalf("live=%q", got)
+	}
+	if in := rec.Interactions[0]; !in.Base64 {
+		t.Fatalf("binary body not base64-encoded: %+v", in)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+	play, err := Load(rec.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := get(t, play.Transport(nil), http.MethodGet, "https://x.test/file", ""); got != bin {
+		t.Fatalf("replay=%q", got)
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "nope.json")); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("err=%v", err)
+	}
+}
+
+func TestReplayComparesRequestBodies(t *testing.T) {
+	rec := New(filepath.Join(t.TempDir(), "bodies.json"))
+	rt := rec.Transport(portal(map[string]string{
+		"POST /api/2.0/crm/contact/company.json": `{"response":{"id":7}}`,
+		"POST /upload":                           `{"response":{"id":8}}`,
+	}))
+	get(t, rt, http.MethodPost, "https://crm.acme.io/api/2.0/crm/contact/company.json", "companyName=Acme&email=jane%40acme.io")
+	upload := func(rt http.RoundTripper) (*http.Response, error) {
+		var buf strings.Builder
+		mw := multipart.NewWriter(&buf)
+		fw, _ := mw.CreateFormFile("file", "a.txt")
+		_, _ = io.WriteString(fw, "hello")
+		_ = mw.Close()
+		req, _ := http.NewRequest(http.MethodPost, "https://crm.acme.io/upload", strings.NewReader(buf.String()))
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		return rt.RoundTrip(req)
+	}
+	if _, err := upload(rt); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	play, err := Load(rec.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	prt := play.Transport(nil)
+	req, _ := http.NewRequest(http.MethodPost, "https://"+PortalHost+"/api/2.0/crm/contact/company.json", strings.NewReader("companyName=Other"))
+	if _, err := prt.RoundTrip(req); !errors.Is(err, ErrBodyMismatch) {
+		t.Fatalf("different body: err=%v", err)
+	}
+	if got := get(t, prt, http.MethodPost, "https://"+PortalHost+"/api/2.0/crm/contact/company.json", "companyName=Acme&email=jane%40acme.io"); !strings.Contains(got, `"id":7`) {
+		t.Fatalf("same body replay=%s", got)
+	}
+	if _, err := upload(prt); err != nil {
+		t.Fatalf("multipart upload with a new boundary: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package cassette
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// PortalHost replaces the recorded portal's host name in cassettes.
+const PortalHost = "portal.example.invalid"
+
+// Redacted replaces secrets in cassettes.
+const Redacted = "REDACTED"
+
+var (
+	// JSON string members whose values are credentials.
+	secretJSON = regexp.MustCompile(`(?i)("(?:token|password|passwordHash|pwd|sms(?:Code)?|asc_auth_key|tfaKey|appKey)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// Form/query fields carrying credentials.
+	secretForm = regexp.MustCompile(`(?i)(^|[&?])(token|password|pwd|code|asc_auth_key)=[^&]*`)
+	// Bare e-mail addresses (also inside JSON strings and URL-encoded queries).
+	emailRe = regexp.MustCompile(`(?i)[a-z0-9._%+\-]+(?:@|%40)[a-z0-9.\-]+\.[a-z]{2,}`)
+)
+
+// Sanitizer scrubs recorded traffic: credentials become Redacted, the portal
+// host becomes PortalHost, and every distinct e-mail address is replaced by
+// a stable placeholder (user1@example.com, user2@…) so that the same address
+// maps to the same placeholder throughout one cassette.
+type Sanitizer struct {
+	hosts  map[string]*regexp.Regexp
+	emails map[string]string
+}
+
+// NewSanitizer returns an empty sanitizer.
+func NewSanitizer() *Sanitizer {
+	return &Sanitizer{hosts: map[string]*regexp.Regexp{}, emails: map[string]string{}}
+}
+
+// AddHost registers a host name (portal, docs server) to be masked.
+func (s *Sanitizer) AddHost(host string) {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if host == "" || host == PortalHost || s.hosts[host] != nil {
+		return
+	}
+	s.hosts[host] = regexp.MustCompile(`(?i)` + regexp.QuoteMeta(host))
+}
+
+// Text returns v with credentials, hosts and e-mail addresses scrubbed.
+func (s *Sanitizer) Text(v string) string {
+	if v == "" {
+		return v
+	}
+	v = secretJSON.ReplaceAllString(v, `${1}"`+Redacted+`"`)
+	v = secretForm.ReplaceAllString(v, `${1}${2}=`+Redacted)
+	for _, re := range s.hosts {
+		v = re.ReplaceAllLiteralString(v, PortalHost)
+	}
+	return emailRe.ReplaceAllStringFunc(v, s.email)
+}
+
+func (s *Sanitizer) email(addr string) string {
+	encoded := strings.Contains(addr, "%40")
+	key := strings.ToLower(strings.ReplaceAll(addr, "%40", "@"))
+	if strings.HasSuffix(key, "@example.com") {
+		return addr // already a placeholder
+	}
+	ph, ok := s.emails[key]
+	if !ok {
+		ph = fmt.Sprintf("user%d@example.com", len(s.emails)+1)
+		s.emails[key] = ph
+	}
+	if encoded {
+		return strings.ReplaceAll(ph, "@", "%40")
+	}
+	return ph
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	onlyoffice "github.com/eslider/go-onlyoffice"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // ApplyResult summarizes one apply pass.
@@ -20,8 +21,10 @@ type ApplyResult struct {
 
 // ApplyApproved creates/updates OO contacts for entries with approve=true.
 // Companies are applied before persons so Org links resolve in the same run.
-func ApplyApproved(ctx context.Context, client *onlyoffice.Client, doc *Document, dryRun bool) (*ApplyResult, error) {
-	res := &ApplyResult{DryRun: dryRun}
+func ApplyApproved(ctx context.Context, client *onlyoffice.Client, doc *Document, dryRun bool) (res *ApplyResult, err error) {
+	ctx, end := client.StartSpan(ctx, "catalog.ApplyApproved", attribute.Bool("onlyoffice.dry_run", dryRun))
+	defer func() { end(err) }()
+	res = &ApplyResult{DryRun: dryRun}
 	order := make([]int, 0, len(doc.Entries))
 	for i, e := range doc.Entries {
 		if e.Kind == "company" {
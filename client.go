@@ -10,47 +10,92 @@
 package onlyoffice
 
 import (
+	"log/slog"
 	"net/http"
 	"net/http/cookiejar"
 	"os"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Client of OnlyOffice API uses credentials to get a token and query the API
 // by every request.
 //
-// Construct with NewClient; optionally set fallbacks via SetDefaults. The
-// zero value is NOT usable — credentials are required. A single client is
-// safe for sequential use from one goroutine; for concurrent use, callers
-// should wrap with their own synchronization or create one client per
-// goroutine.
+// Construct with NewClient and functional options (WithTimeout,
+// WithTransport, WithRetryPolicy, …); the Set* methods remain for callers
+// configuring an existing client. The zero value is NOT usable — credentials
+// are required.
+//
+// A Client is safe for concurrent use by multiple goroutines once
+// configured: the cached token, self id and note category are guarded, and
+// token refresh is single-flight so a burst of parallel calls triggers one
+// authentication. The Set* configuration methods are not synchronized and
+// must be called before the client is shared.
 type Client struct {
 	client      *http.Client
 	credentials *Credentials
-	token       *Token
 
-	defaults  Defaults // optional fallbacks for calendar/project IDs
-	selfID    string   // cached /api/2.0/people/@self id
-	noteCatID int      // cached CRM history category id for "note"
+	defaults    Defaults    // optional fallbacks for calendar/project IDs
+	retry       RetryPolicy // transient-failure retries; zero value = none
+	parallelism int         // max in-flight calls for fan-out helpers; <=1 = sequential
+	limiter     *RateLimiter
+	tokenStore  TokenStore     // optional cross-process token cache
+	cache       *responseCache // optional lookup cache; see cache.go
+	journal     Journal        // optional audit log of mutations; see journal.go
+	dryRun      *DryRun        // set by WithDryRun; mutations are not sent
+	tfaCode     TFACodeFunc
+	userAgent   string
+	timeout     time.Duration // response-header deadline per attempt; see WithTimeout
+	logger      *slog.Logger
+	middleware  []Middleware // per-attempt RoundTripper chain; see middleware.go
+	tel         *telemetry   // nil unless a tracer/meter provider is configured
+
+	authMu sync.Mutex // serializes re-authentication (single-flight)
+
+	mu             sync.Mutex // guards the cached fields below
+	token          *Token
+	staticRejected bool   // Credentials.Token was invalidated; use the password
+	selfID         string // cached /api/2.0/people/@self id
+	noteCatID      int    // cached CRM history category id for "note"
 }
 
-// NewClient returns a new Client backed by http.DefaultClient.
-func NewClient(c Credentials) *Client {
+// NewClient returns a new Client with its own cookie jar (the mail download
+// handler authenticates via the session cookie). Options are applied in
+// order after the defaults.
+func NewClient(c Credentials, opts ...Option) *Client {
 	jar, _ := cookiejar.New(nil)
-	return &Client{
+	client := &Client{
 		client:      &http.Client{Jar: jar},
 		credentials: &c,
 	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client
 }
 
 // Credentials of OnlyOffice User. The Url field is NOT sent with the auth
 // payload — it only determines the host.
+//
+// Token is an optional pre-issued token (for example one obtained through
+// SSO, or the value of an asc_auth_key cookie copied from a browser). When
+// set it is used instead of the password flow.
+//
+// PasswordSource optionally names where to fetch the password from when
+// Password is empty (keyring, pass, a file or a command; see ResolveSecret).
+// It is resolved only when the client runs the password flow.
 type Credentials struct {
-	Url      string `json:"-"`
-	User     string `json:"userName"`
-	Password string `json:"password"`
+	Url            string `json:"-"`
+	User           string `json:"userName"`
+	Password       string `json:"password"`
+	Token          string `json:"-"`
+	PasswordSource string `json:"-"`
 }
 
+// hasPassword reports whether the password flow is possible.
+func (c *Credentials) hasPassword() bool { return c.Password != "" || c.PasswordSource != "" }
+
 // Defaults holds optional fallbacks used by package-level helpers when callers
 // pass an empty identifier (calendar or project). Set via (*Client).SetDefaults
 // or read from env via GetEnvironmentDefaults.
@@ -63,6 +108,9 @@ type Defaults struct {
 // such as AddEvent (when calendarID == "") or AddTask (when projectID == "").
 func (c *Client) SetDefaults(d Defaults) { c.defaults = d }
 
+// Defaults returns the configured fallback identifiers.
+func (c *Client) Defaults() Defaults { return c.defaults }
+
 // GetEnvironmentCredentials reads OnlyOffice credentials from environment.
 //
 // Primary variables (documented):
@@ -74,13 +122,20 @@ func (c *Client) SetDefaults(d Defaults) { c.defaults = d }
 //   - ONLYOFFICE_HOST        (alias for ONLYOFFICE_URL)
 //   - ONLYOFFICE_NAME        (alias for ONLYOFFICE_USER)
 //   - ONLYOFFICE_PASSWORD    (alias for ONLYOFFICE_PASS)
+//
+// ONLYOFFICE_TOKEN optionally supplies a pre-issued token (Credentials.Token).
+// ONLYOFFICE_PASS_SOURCE names a password source instead of a plain
+// password (Credentials.PasswordSource), e.g. "pass:work/onlyoffice" or
+// "keyring:onlyoffice"; see ResolveSecret.
 func GetEnvironmentCredentials() Credentials {
 	url := firstNonEmpty(os.Getenv("ONLYOFFICE_URL"), os.Getenv("ONLYOFFICE_HOST"))
 	url = strings.TrimRight(url, "/")
 	return Credentials{
-		Url:      url,
-		User:     firstNonEmpty(os.Getenv("ONLYOFFICE_USER"), os.Getenv("ONLYOFFICE_NAME")),
-		Password: firstNonEmpty(os.Getenv("ONLYOFFICE_PASS"), os.Getenv("ONLYOFFICE_PASSWORD")),
+		Url:            url,
+		User:           firstNonEmpty(os.Getenv("ONLYOFFICE_USER"), os.Getenv("ONLYOFFICE_NAME")),
+		Password:       firstNonEmpty(os.Getenv("ONLYOFFICE_PASS"), os.Getenv("ONLYOFFICE_PASSWORD")),
+		Token:          strings.TrimSpace(os.Getenv("ONLYOFFICE_TOKEN")),
+		PasswordSource: strings.TrimSpace(os.Getenv("ONLYOFFICE_PASS_SOURCE")),
 	}
 }
 
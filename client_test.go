@@ -57,6 +57,29 @@ func cleanupTestProjects(t *testing.T, c *Client) {
 	}
 }
 
+// createTestProject creates a "go-onlyoffice-test-<kind>-…" project that
+// cleanupTestProjects removes when the test ends.
+func createTestProject(t *testing.T, c *Client, kind string) int {
+	t.Helper()
+	t.Cleanup(func() { cleanupTestProjects(t, c) })
+	title := testProjectPrefix + kind + "-" + time.Now().UTC().Format("20060102-150405")
+	project, err := c.CreateProjectContext(context.Background(), NewProjectRequest{Title: title})
+	if err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	return *project.ID
+}
+
+// createTestTask adds a task due in a week to project projectID.
+func createTestTask(t *testing.T, c *Client, projectID int, title string) int {
+	t.Helper()
+	task, err := c.AddTask(context.Background(), strconv.Itoa(projectID), title, "", 0, time.Now().AddDate(0, 0, 7).Format("2006-01-02"))
+	if err != nil {
+		t.Fatalf("AddTask: %v", err)
+	}
+	return int(flexInt(task["id"]))
+}
+
 func TestIntegrationAuthenticateContext(t *testing.T) {
 	c := liveClient(t)
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -184,14 +207,72 @@ func TestIntegrationProjectAndTaskLifecycle(t *testing.T) {
 		t.Fatalf("UpdateProjectTask: %v", err)
 	}
 
-	// Subtask uses the form-encoded helper; exercises httpx.postForm path.
 	ctx := context.Background()
-	sub, err := c.AddSubtask(ctx, strconv.Itoa(*task.ID), "integration subtask")
+	sub, err := c.CreateSubtask(ctx, *task.ID, "integration subtask", "")
 	if err != nil {
-		t.Fatalf("AddSubtask: %v", err)
+		t.Fatalf("CreateSubtask: %v", err)
+	}
+	if sub.ID == 0 || int(sub.TaskID) != *task.ID {
+		t.Errorf("subtask = %+v", sub)
+	}
+
+	key := true
+	if ms, err := c.UpdateMilestone(ctx, *ms.ID, MilestoneUpdate{Title: "integration milestone (updated)", IsKey: &key}); err != nil {
+		t.Fatalf("UpdateMilestone: %v", err)
+	} else if ms.Title == nil || *ms.Title != "integration milestone (updated)" {
+		t.Errorf("milestone title not updated: %+v", ms.Title)
+	} else if ms.Deadline == nil || ms.Deadline.Format("2006-01-02") != time.Time(deadline).Format("2006-01-02") {
+		t.Errorf("UpdateMilestone without a deadline moved it to %v", ms.Deadline)
+	}
+	planned, err := c.ListMilestoneTasks(ctx, *ms.ID)
+	if err != nil {
+		t.Fatalf("ListMilestoneTasks: %v", err)
+	}
+	if len(planned) != 1 || *planned[0].ID != *task.ID {
+		t.Errorf("milestone tasks = %d, want task %d", len(planned), *task.ID)
+	}
+	if _, err := c.MoveTaskToMilestone(ctx, *task.ID, 0); err != nil {
+		t.Fatalf("MoveTaskToMilestone: %v", err)
+	}
+	if _, err := c.SetMilestoneStatus(ctx, *ms.ID, MilestoneClosed); err != nil {
+		t.Fatalf("SetMilestoneStatus: %v", err)
+	}
+}
+
+func TestIntegrationTimeTracking(t *testing.T) {
+	c := liveClient(t)
+	t.Cleanup(func() { cleanupTestProjects(t, c) })
+	ctx := context.Background()
+
+	project, err := c.CreateProjectContext(ctx, NewProjectRequest{Title: testProjectPrefix + "time-" + time.Now().UTC().Format("20060102-150405")})
+	if err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	task, err := c.AddTask(ctx, strconv.Itoa(*project.ID), "integration time task", "", 0, time.Now().AddDate(0, 0, 7).Format("2006-01-02"))
+	if err != nil {
+		t.Fatalf("AddTask: %v", err)
+	}
+	taskID := int(flexInt(task["id"]))
+
+	e, err := c.LogTime(ctx, taskID, NewTimeEntry{Hours: 0.5, Note: "integration"})
+	if err != nil {
+		t.Fatalf("LogTime: %v", err)
+	}
+	if int(e.ProjectID) != *project.ID {
+		t.Errorf("entry project = %d, want %d", e.ProjectID, *project.ID)
+	}
+	if _, err := c.LogTime(ctx, taskID, NewTimeEntry{Hours: 0}); err == nil {
+		t.Error("LogTime with zero hours must fail")
+	}
+	if _, err := c.SetTimeStatus(ctx, PaymentNotBilled, int(e.ID)); err != nil {
+		t.Errorf("SetTimeStatus: %v", err)
+	}
+	list, err := c.ListTime(ctx, TimeFilter{ProjectID: *project.ID})
+	if err != nil || len(list) != 1 {
+		t.Errorf("ListTime = %d entries, %v", len(list), err)
 	}
-	if _, ok := sub["id"]; !ok {
-		t.Errorf("subtask response missing id: %+v", sub)
+	if err := c.DeleteTime(ctx, int(e.ID)); err != nil {
+		t.Errorf("DeleteTime: %v", err)
 	}
 }
 
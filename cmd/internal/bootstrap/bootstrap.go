@@ -4,12 +4,17 @@
 package bootstrap
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"strings"
+	"time"
 
 	onlyoffice "github.com/eslider/go-onlyoffice"
+	"github.com/eslider/go-onlyoffice/sqlitecache"
+	"github.com/eslider/go-onlyoffice/sqlitejournal"
 	"github.com/joho/godotenv"
 )
 
@@ -19,21 +24,245 @@ func LoadEnv() {
 	applyEnvAliases()
 }
 
-// NewClient loads env, validates credentials, and authenticates against OnlyOffice.
+// NewClient loads env and the selected profile (ProfileName,
+// ONLYOFFICE_PROFILE or the config file default; see Config), validates
+// credentials, and authenticates against OnlyOffice.
+//
+// Optional tuning variables:
+//   - ONLYOFFICE_TIMEOUT     how long to wait for response headers, as a Go
+//     duration (default 2m); transfers themselves are not cut off
+//   - ONLYOFFICE_RATE_LIMIT  client-side throttling, see onlyoffice.ParseRateLimiter
+//     (e.g. "crm=5/10,*=20")
+//   - ONLYOFFICE_DEBUG       when non-empty, log every request to stderr
+//   - ONLYOFFICE_TOKEN_CACHE token cache file path, or "off" to disable
+//     (default: onlyoffice.DefaultTokenStorePath)
+//   - ONLYOFFICE_CACHE       lookup cache: "memory", "disk" for
+//     onlyoffice.DefaultCachePath, a SQLite file path, or "off" (default)
+//   - ONLYOFFICE_CACHE_TTL   per-resource TTLs, see onlyoffice.ParseCacheTTLs
+//     (e.g. "users=1h,*=5m")
+//   - ONLYOFFICE_JOURNAL     audit journal of mutations: "on" for
+//     onlyoffice.DefaultJournalPath, "sqlite", or a file path (*.db is
+//     SQLite, anything else JSON Lines); off by default
+//   - ONLYOFFICE_TFA_CODE    one-time code for two-factor accounts (otherwise
+//     prompted for on a terminal)
+//   - ONLYOFFICE_PROFILE     profile to use when --profile is not given
+//   - OO_CONFIG              config file (default: ConfigPath)
+//   - OTEL_EXPORTER_OTLP_*   OpenTelemetry export, see SetupTelemetry
 func NewClient(ctx context.Context) (*onlyoffice.Client, error) {
-	LoadEnv()
-	creds := onlyoffice.GetEnvironmentCredentials()
-	if creds.Url == "" || creds.User == "" || creds.Password == "" {
-		return nil, fmt.Errorf("need ONLYOFFICE_URL (or ONLYOFFICE_HOST/OO_URL), user (ONLYOFFICE_USER or ONLYOFFICE_NAME/OO_USER), password (ONLYOFFICE_PASS or ONLYOFFICE_PASSWORD/OO_PASS)")
+	c, err := NewUnauthenticatedClient()
+	if err != nil {
+		return nil, err
 	}
-	c := onlyoffice.NewClient(creds)
-	c.SetDefaults(onlyoffice.GetEnvironmentDefaults())
 	if err := c.AuthenticateContext(ctx); err != nil {
 		return nil, err
 	}
 	return c, nil
 }
 
+// DryRun, when set, captures the mutating requests of clients built by
+// NewClient instead of sending them (oo --dry-run).
+var DryRun *onlyoffice.DryRun
+
+// NewUnauthenticatedClient is NewClient without the eager authentication.
+// Used by `oo auth login`, which must bypass the token cache.
+func NewUnauthenticatedClient() (*onlyoffice.Client, error) {
+	creds, err := Credentials()
+	if err != nil {
+		return nil, err
+	}
+	opts, err := ClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	store, err := TokenStore()
+	if err != nil {
+		return nil, err
+	}
+	if store != nil {
+		opts = append(opts, onlyoffice.WithTokenStore(store))
+	}
+	if DryRun != nil {
+		opts = append(opts, onlyoffice.WithDryRun(DryRun))
+	}
+	opts = append(opts, onlyoffice.WithTFACode(TFACode))
+	return onlyoffice.NewClient(creds, opts...), nil
+}
+
+// TFACode answers a two-factor challenge from ONLYOFFICE_TFA_CODE, or by
+// prompting on stderr when stdin is a terminal.
+func TFACode(ctx context.Context, ch onlyoffice.TFAChallenge) (string, error) {
+	if v := strings.TrimSpace(os.Getenv("ONLYOFFICE_TFA_CODE")); v != "" {
+		return v, nil
+	}
+	if fi, err := os.Stdin.Stat(); err != nil || fi.Mode()&os.ModeCharDevice == 0 {
+		return "", fmt.Errorf("set ONLYOFFICE_TFA_CODE (stdin is not a terminal)")
+	}
+	switch {
+	case ch.SMS:
+		fmt.Fprintf(os.Stderr, "SMS code sent to %s: ", ch.PhoneNoise)
+	case ch.AppKey != "":
+		fmt.Fprintf(os.Stderr, "Pair your authenticator app with key %s, then enter the code: ", ch.AppKey)
+	default:
+		fmt.Fprint(os.Stderr, "Authenticator code: ")
+	}
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimSpace(line), ctx.Err()
+}
+
+// Credentials loads env and the selected profile (see ActiveProfile) and
+// returns the portal credentials, or an error naming what to set.
+// ONLYOFFICE_URL plus ONLYOFFICE_TOKEN (an SSO-issued token or
+// asc_auth_key cookie value) is enough on its own.
+func Credentials() (onlyoffice.Credentials, error) {
+	LoadEnv()
+	creds := onlyoffice.GetEnvironmentCredentials()
+	name, profile, explicit, err := ActiveProfile()
+	if err != nil {
+		return creds, err
+	}
+	if profile != nil {
+		creds = profile.apply(creds, explicit)
+	}
+	if creds.Url != "" && creds.Token != "" {
+		return creds, nil // pre-issued token: user/password optional
+	}
+	if creds.Url == "" || creds.User == "" || creds.Password == "" && creds.PasswordSource == "" {
+		if profile != nil {
+			return creds, fmt.Errorf("profile %q: need url, user and a password (password_source, password_command, password_env, password) or token (token_env, token)", name)
+		}
+		return creds, fmt.Errorf("need ONLYOFFICE_URL (or ONLYOFFICE_HOST/OO_URL), user (ONLYOFFICE_USER or ONLYOFFICE_NAME/OO_USER), password (ONLYOFFICE_PASS or ONLYOFFICE_PASSWORD/OO_PASS, or ONLYOFFICE_PASS_SOURCE), or a profile in the config file (--profile)")
+	}
+	return creds, nil
+}
+
+// TokenStore returns the on-disk token cache selected by
+// ONLYOFFICE_TOKEN_CACHE, or nil when it is set to "off" (or "0"/"false").
+func TokenStore() (*onlyoffice.FileTokenStore, error) {
+	v := strings.TrimSpace(os.Getenv("ONLYOFFICE_TOKEN_CACHE"))
+	switch strings.ToLower(v) {
+	case "off", "0", "false", "no":
+		return nil, nil
+	}
+	store, err := onlyoffice.NewFileTokenStore(v)
+	if err != nil {
+		return nil, fmt.Errorf("token cache: %w", err)
+	}
+	return store, nil
+}
+
+// Cache returns the lookup cache selected by ONLYOFFICE_CACHE: nil when it
+// is unset or "off", in memory for "memory" (or "on"), on disk (SQLite) for
+// "disk" or a file path.
+func Cache() (onlyoffice.Cache, error) {
+	v := strings.TrimSpace(os.Getenv("ONLYOFFICE_CACHE"))
+	switch strings.ToLower(v) {
+	case "", "off", "0", "false", "no":
+		return nil, nil
+	case "memory", "on", "1", "true", "yes":
+		return onlyoffice.NewMemoryCache(), nil
+	case "disk", "sqlite":
+		v = ""
+	}
+	store, err := sqlitecache.Open(v)
+	if err != nil {
+		return nil, fmt.Errorf("cache: %w", err)
+	}
+	return store, nil
+}
+
+// Journal returns the audit journal selected by ONLYOFFICE_JOURNAL, or nil
+// when it is unset or "off".
+func Journal() (onlyoffice.Journal, error) {
+	v := strings.TrimSpace(os.Getenv("ONLYOFFICE_JOURNAL"))
+	var (
+		j   onlyoffice.Journal
+		err error
+	)
+	switch lv := strings.ToLower(v); {
+	case lv == "" || lv == "off" || lv == "0" || lv == "false" || lv == "no":
+		return nil, nil
+	case lv == "on" || lv == "1" || lv == "true" || lv == "yes" || lv == "jsonl":
+		j, err = onlyoffice.NewFileJournal("")
+	case lv == "sqlite":
+		j, err = sqlitejournal.Open("")
+	case strings.HasSuffix(lv, ".db") || strings.HasSuffix(lv, ".sqlite"):
+		j, err = sqlitejournal.Open(v)
+	default:
+		j, err = onlyoffice.NewFileJournal(v)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("journal: %w", err)
+	}
+	return j, nil
+}
+
+// DefaultTimeout bounds how long a request waits for the portal to answer
+// so a hung portal cannot freeze the CLI or TUI. Large downloads and
+// uploads may take longer; see onlyoffice.WithTimeout.
+const DefaultTimeout = 2 * time.Minute
+
+// ClientOptions returns the options shared by oo and office: env and
+// profile defaults, the default retry policy, the request timeout, the
+// lookup cache, and the optional journal, rate limiter, debug logger and
+// telemetry providers.
+func ClientOptions() ([]onlyoffice.Option, error) {
+	timeout := DefaultTimeout
+	if v := strings.TrimSpace(os.Getenv("ONLYOFFICE_TIMEOUT")); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("ONLYOFFICE_TIMEOUT: %w", err)
+		}
+		timeout = d
+	}
+	defaults := onlyoffice.GetEnvironmentDefaults()
+	_, profile, explicit, err := ActiveProfile()
+	if err != nil {
+		return nil, err
+	}
+	if profile != nil {
+		defaults = profile.defaults(defaults, explicit)
+	}
+	opts := []onlyoffice.Option{
+		onlyoffice.WithDefaults(defaults),
+		onlyoffice.WithRetryPolicy(onlyoffice.DefaultRetryPolicy()),
+		onlyoffice.WithTimeout(timeout),
+		onlyoffice.WithUserAgent("go-onlyoffice-cli"),
+	}
+	cache, err := Cache()
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		ttls, err := onlyoffice.ParseCacheTTLs(os.Getenv("ONLYOFFICE_CACHE_TTL"))
+		if err != nil {
+			return nil, fmt.Errorf("ONLYOFFICE_CACHE_TTL: %w", err)
+		}
+		opts = append(opts, onlyoffice.WithCache(cache, ttls))
+	}
+	journal, err := Journal()
+	if err != nil {
+		return nil, err
+	}
+	if journal != nil {
+		opts = append(opts, onlyoffice.WithJournal(journal))
+	}
+	limiter, err := onlyoffice.ParseRateLimiter(os.Getenv("ONLYOFFICE_RATE_LIMIT"))
+	if err != nil {
+		return nil, fmt.Errorf("ONLYOFFICE_RATE_LIMIT: %w", err)
+	}
+	if limiter != nil {
+		opts = append(opts, onlyoffice.WithRateLimiter(limiter))
+	}
+	if strings.TrimSpace(os.Getenv("ONLYOFFICE_DEBUG")) != "" {
+		opts = append(opts, onlyoffice.WithLogger(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	}
+	opts = append(opts, telemetryOpts...)
+	return opts, nil
+}
+
 func applyEnvAliases() {
 	setEnvIfEmpty("ONLYOFFICE_URL", "OO_URL")
 	setEnvIfEmpty("ONLYOFFICE_USER", "OO_USER")
@@ -2,10 +2,13 @@ package bootstrap_test
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	onlyoffice "github.com/eslider/go-onlyoffice"
 	"github.com/eslider/go-onlyoffice/cmd/internal/bootstrap"
 )
 
@@ -52,10 +55,11 @@ func TestLoadEnvDoesNotOverrideCanonicalWithAlias(t *testing.T) {
 func TestNewClientReturnsErrorWithoutCredentials(t *testing.T) {
 	clearEnv(t,
 		"ONLYOFFICE_URL", "ONLYOFFICE_HOST", "ONLYOFFICE_USER", "ONLYOFFICE_NAME",
-		"ONLYOFFICE_PASS", "ONLYOFFICE_PASSWORD",
-		"OO_URL", "OO_USER", "OO_PASS",
+		"ONLYOFFICE_PASS", "ONLYOFFICE_PASSWORD", "ONLYOFFICE_PASS_SOURCE",
+		"OO_URL", "OO_USER", "OO_PASS", "ONLYOFFICE_PROFILE",
 	)
 	dir := t.TempDir()
+	t.Setenv("OO_CONFIG", filepath.Join(dir, "config.yaml"))
 	oldwd, err := os.Getwd()
 	if err != nil {
 		t.Fatal(err)
@@ -93,3 +97,236 @@ func clearEnv(t *testing.T, keys ...string) {
 		})
 	}
 }
+
+func TestClientOptionsRejectsBadTimeout(t *testing.T) {
+	t.Setenv("ONLYOFFICE_TIMEOUT", "soon")
+	if _, err := bootstrap.ClientOptions(); err == nil || !strings.Contains(err.Error(), "ONLYOFFICE_TIMEOUT") {
+		t.Fatalf("err=%v", err)
+	}
+}
+
+func TestClientOptionsRateLimitAndDebug(t *testing.T) {
+	t.Setenv("ONLYOFFICE_TIMEOUT", "")
+	t.Setenv("ONLYOFFICE_RATE_LIMIT", "crm=5/10")
+	t.Setenv("ONLYOFFICE_DEBUG", "1")
+	t.Setenv("ONLYOFFICE_CACHE", "off")
+	t.Setenv("ONLYOFFICE_JOURNAL", "off")
+	opts, err := bootstrap.ClientOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(opts) != 6 {
+		t.Fatalf("expected base options + limiter + logger, got %d", len(opts))
+	}
+}
+
+func TestTokenStoreFromEnv(t *testing.T) {
+	t.Setenv("ONLYOFFICE_TOKEN_CACHE", "off")
+	if s, err := bootstrap.TokenStore(); err != nil || s != nil {
+		t.Fatalf("off: store=%v err=%v", s, err)
+	}
+	t.Setenv("ONLYOFFICE_TOKEN_CACHE", "/tmp/oo-test/tokens.json")
+	s, err := bootstrap.TokenStore()
+	if err != nil || s == nil || s.Path != "/tmp/oo-test/tokens.json" {
+		t.Fatalf("path: store=%v err=%v", s, err)
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	clearEnv(t,
+		"ONLYOFFICE_URL", "ONLYOFFICE_HOST", "ONLYOFFICE_USER", "ONLYOFFICE_NAME",
+		"ONLYOFFICE_PASS", "ONLYOFFICE_PASSWORD", "ONLYOFFICE_PASS_SOURCE", "ONLYOFFICE_TOKEN",
+		"OO_URL", "OO_USER", "OO_PASS", "ONLYOFFICE_PROFILE",
+		"ONLYOFFICE_CALENDAR_ID", "ONLYOFFICE_PROJECT_ID", "ONLYOFFICE_CALENDAR_PROJECT_ID",
+	)
+	dir := t.TempDir()
+	oldwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldwd) })
+	cfg := filepath.Join(dir, "config.yaml")
+	t.Setenv("OO_CONFIG", cfg)
+	if err := os.WriteFile(cfg, []byte(`default: staging
+profiles:
+  staging:
+    url: https://staging.example.com/
+    user: me@example.com
+    password_env: STAGING_PASS
+    project_id: 12
+  production:
+    url: https://office.example.com
+    token_env: PROD_TOKEN
+    calendar_id: 3
+  helper:
+    url: https://helper.example.com
+    user: me@example.com
+    password_command: echo from-helper
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("STAGING_PASS", "s3cret")
+	t.Setenv("PROD_TOKEN", "tok")
+
+	creds, err := bootstrap.Credentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.Url != "https://staging.example.com" || creds.User != "me@example.com" || creds.Password != "s3cret" {
+		t.Errorf("default profile: %+v", creds)
+	}
+
+	// The default profile only fills gaps; an explicit one wins over env.
+	t.Setenv("ONLYOFFICE_URL", "https://env.example.com")
+	t.Setenv("ONLYOFFICE_PROJECT_ID", "99")
+	if creds, _ := bootstrap.Credentials(); creds.Url != "https://env.example.com" {
+		t.Errorf("default profile overrode env: %s", creds.Url)
+	}
+	if d := defaults(t); d.ProjectID != "99" {
+		t.Errorf("default profile overrode ONLYOFFICE_PROJECT_ID: %+v", d)
+	}
+
+	bootstrap.ProfileName = "production"
+	t.Cleanup(func() { bootstrap.ProfileName = "" })
+	creds, err = bootstrap.Credentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.Url != "https://office.example.com" || creds.Token != "tok" {
+		t.Errorf("--profile production: %+v", creds)
+	}
+	if d := defaults(t); d.CalendarID != "3" || d.ProjectID != "99" {
+		t.Errorf("--profile production defaults: %+v", d)
+	}
+
+	// A helper profile hands over the source; the client resolves it later.
+	t.Setenv("ONLYOFFICE_PASS", "env-pass")
+	bootstrap.ProfileName = "helper"
+	creds, err = bootstrap.Credentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.Password != "" || creds.PasswordSource != "command:echo from-helper" {
+		t.Errorf("--profile helper: %+v", creds)
+	}
+
+	// Env secrets belong to the env portal: a profile for another portal
+	// that brings none of its own must not inherit them.
+	t.Setenv("ONLYOFFICE_TOKEN", "env-tok")
+	t.Setenv("STAGING_PASS", "")
+	bootstrap.ProfileName = "staging"
+	creds, err = bootstrap.Credentials()
+	if creds.Password != "" || creds.Token != "" {
+		t.Errorf("--profile staging leaked env secrets to %s: %+v", creds.Url, creds)
+	}
+	if err == nil || !strings.Contains(err.Error(), `profile "staging"`) {
+		t.Errorf("--profile staging without a password: err = %v", err)
+	}
+	t.Setenv("ONLYOFFICE_URL", "https://staging.example.com")
+	if creds, _ = bootstrap.Credentials(); creds.Password != "env-pass" || creds.Token != "env-tok" {
+		t.Errorf("--profile staging for the env portal: %+v", creds)
+	}
+
+	bootstrap.ProfileName = "qa"
+	if _, err := bootstrap.Credentials(); err == nil || !strings.Contains(err.Error(), "helper, production, staging") {
+		t.Errorf("unknown profile: err = %v", err)
+	}
+}
+
+func TestDefaultProfileKeepsSecretsForItsPortal(t *testing.T) {
+	clearEnv(t,
+		"ONLYOFFICE_URL", "ONLYOFFICE_HOST", "ONLYOFFICE_USER", "ONLYOFFICE_NAME",
+		"ONLYOFFICE_PASS", "ONLYOFFICE_PASSWORD", "ONLYOFFICE_PASS_SOURCE", "ONLYOFFICE_TOKEN",
+		"OO_URL", "OO_USER", "OO_PASS", "ONLYOFFICE_PROFILE",
+	)
+	dir := t.TempDir()
+	oldwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldwd) })
+	cfg := filepath.Join(dir, "config.yaml")
+	t.Setenv("OO_CONFIG", cfg)
+	if err := os.WriteFile(cfg, []byte(`default: b
+profiles:
+  b:
+    url: https://b.example.com
+    user: me@example.com
+    password: b-secret
+    token: b-token
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("ONLYOFFICE_URL", "https://a.example.com/")
+	t.Setenv("ONLYOFFICE_USER", "me@example.com")
+	creds, _ := bootstrap.Credentials()
+	if creds.Url != "https://a.example.com" || creds.Password != "" || creds.PasswordSource != "" || creds.Token != "" {
+		t.Errorf("default profile for b.example.com filled secrets for %s: %+v", creds.Url, creds)
+	}
+
+	t.Setenv("ONLYOFFICE_URL", "https://b.example.com")
+	if creds, _ := bootstrap.Credentials(); creds.Password != "b-secret" || creds.Token != "b-token" {
+		t.Errorf("default profile for the env portal: %+v", creds)
+	}
+}
+
+func defaults(t *testing.T) onlyoffice.Defaults {
+	t.Helper()
+	opts, err := bootstrap.ClientOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return onlyoffice.NewClient(onlyoffice.Credentials{}, opts...).Defaults()
+}
+
+func TestCacheSelection(t *testing.T) {
+	for v, want := range map[string]string{
+		"":                                 "<nil>",
+		"off":                              "<nil>",
+		"memory":                           "*onlyoffice.MemoryCache",
+		filepath.Join(t.TempDir(), "c.db"): "*sqlitecache.Store",
+	} {
+		t.Setenv("ONLYOFFICE_CACHE", v)
+		c, err := bootstrap.Cache()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fmt.Sprintf("%T", c); got != want {
+			t.Errorf("ONLYOFFICE_CACHE=%q: %s, want %s", v, got, want)
+		}
+	}
+	t.Setenv("ONLYOFFICE_CACHE", "memory")
+	t.Setenv("ONLYOFFICE_CACHE_TTL", "users=later")
+	if _, err := bootstrap.ClientOptions(); err == nil || !strings.Contains(err.Error(), "ONLYOFFICE_CACHE_TTL") {
+		t.Errorf("bad TTL: err = %v", err)
+	}
+}
+
+func TestJournalSelection(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", dir)
+	for v, want := range map[string]string{
+		"":                            "<nil>",
+		"off":                         "<nil>",
+		"on":                          "*onlyoffice.FileJournal",
+		"sqlite":                      "*sqlitejournal.Journal",
+		filepath.Join(dir, "j.db"):    "*sqlitejournal.Journal",
+		filepath.Join(dir, "j.jsonl"): "*onlyoffice.FileJournal",
+	} {
+		t.Setenv("ONLYOFFICE_JOURNAL", v)
+		j, err := bootstrap.Journal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fmt.Sprintf("%T", j); got != want {
+			t.Errorf("ONLYOFFICE_JOURNAL=%q: %s, want %s", v, got, want)
+		}
+	}
+}
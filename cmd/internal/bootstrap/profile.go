@@ -0,0 +1,192 @@
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
+	"gopkg.in/yaml.v3"
+)
+
+// ProfileName is the --profile flag shared by oo and office. Empty selects
+// ONLYOFFICE_PROFILE, then the config file's default profile.
+var ProfileName string
+
+// Config is the CLI configuration file (see ConfigPath) holding named
+// portal profiles:
+//
+//	default: staging
+//	profiles:
+//	  staging:
+//	    url: https://staging.example.com
+//	    user: me@example.com
+//	    password_source: pass:work/onlyoffice-staging
+//	    project_id: 12
+//	  production:
+//	    url: https://office.example.com
+//	    token_env: PROD_OO_TOKEN
+//	    calendar_id: 3
+type Config struct {
+	Default  string              `yaml:"default"`
+	Profiles map[string]*Profile `yaml:"profiles"`
+}
+
+// Profile is one named portal account. Set one way to get the password:
+// password_source (keyring:, pass:, file:, command:, env:; see
+// onlyoffice.ResolveSecret), password_command (short for
+// password_source: command:…), password_env, or an inline password.
+// Secrets are best not inlined.
+type Profile struct {
+	URL             string `yaml:"url"`
+	User            string `yaml:"user"`
+	Password        string `yaml:"password,omitempty"`
+	PasswordEnv     string `yaml:"password_env,omitempty"`
+	PasswordSource  string `yaml:"password_source,omitempty"`
+	PasswordCommand string `yaml:"password_command,omitempty"`
+	Token           string `yaml:"token,omitempty"`
+	TokenEnv        string `yaml:"token_env,omitempty"`
+	CalendarID      string `yaml:"calendar_id,omitempty"`
+	ProjectID       string `yaml:"project_id,omitempty"`
+}
+
+// passwordSource is the profile's password source spec, if any.
+func (p *Profile) passwordSource() string {
+	switch {
+	case strings.TrimSpace(p.PasswordSource) != "":
+		return strings.TrimSpace(p.PasswordSource)
+	case strings.TrimSpace(p.PasswordCommand) != "":
+		return "command:" + strings.TrimSpace(p.PasswordCommand)
+	}
+	return ""
+}
+
+// ConfigPath is $OO_CONFIG, or oo/config.yaml under the user config
+// directory (~/.config/oo/config.yaml on Linux).
+func ConfigPath() (string, error) {
+	if v := strings.TrimSpace(os.Getenv("OO_CONFIG")); v != "" {
+		return v, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "oo", "config.yaml"), nil
+}
+
+// LoadConfig reads the configuration file at path. A missing file is an
+// empty configuration.
+func LoadConfig(path string) (*Config, error) {
+	cfg := &Config{}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Names lists the profile names, sorted.
+func (c *Config) Names() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ActiveProfile returns the selected profile and its name, or a nil profile
+// when none is selected. explicit reports whether the selection came from
+// --profile or ONLYOFFICE_PROFILE rather than the file's default.
+func ActiveProfile() (name string, p *Profile, explicit bool, err error) {
+	name, explicit = ProfileName, true
+	if name == "" {
+		name = strings.TrimSpace(os.Getenv("ONLYOFFICE_PROFILE"))
+	}
+	path, err := ConfigPath()
+	if err != nil {
+		return "", nil, false, err
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return "", nil, false, err
+	}
+	if name == "" {
+		name, explicit = cfg.Default, false
+	}
+	if name == "" {
+		return "", nil, false, nil
+	}
+	p = cfg.Profiles[name]
+	if p == nil {
+		if len(cfg.Profiles) == 0 {
+			return name, nil, explicit, fmt.Errorf("profile %q: no profiles in %s", name, path)
+		}
+		return name, nil, explicit, fmt.Errorf("profile %q not in %s (have: %s)", name, path, strings.Join(cfg.Names(), ", "))
+	}
+	return name, p, explicit, nil
+}
+
+// apply merges the profile into env-derived credentials. An explicitly
+// selected profile wins over the environment; the file's default profile
+// only fills what the environment leaves empty. Secrets never cross
+// portals: when an explicit profile points at another portal than the
+// environment, the environment's password and token are dropped rather
+// than sent there, and a default profile for another portal than the
+// environment's keeps its own.
+func (p *Profile) apply(creds onlyoffice.Credentials, explicit bool) onlyoffice.Credentials {
+	set := func(dst *string, v string) {
+		if v = strings.TrimSpace(v); v != "" && (explicit || *dst == "") {
+			*dst = v
+		}
+	}
+	portal := strings.TrimRight(strings.TrimSpace(p.URL), "/")
+	env := strings.TrimRight(creds.Url, "/")
+	if explicit && portal != "" && portal != env {
+		creds.Password, creds.PasswordSource, creds.Token = "", "", ""
+	}
+	set(&creds.Url, portal)
+	set(&creds.User, p.User)
+	if !explicit && portal != "" && env != "" && portal != env {
+		return creds
+	}
+	// The password and its source are one setting: whichever the profile
+	// gives replaces both, and neither is resolved here (see
+	// onlyoffice.Credentials.PasswordSource).
+	pw, src := strings.TrimSpace(p.Password), p.passwordSource()
+	if p.PasswordEnv != "" {
+		pw = strings.TrimSpace(os.Getenv(p.PasswordEnv))
+	}
+	if (pw != "" || src != "") &&
+		(explicit || creds.Password == "" && creds.PasswordSource == "") {
+		creds.Password, creds.PasswordSource = pw, src
+	}
+	set(&creds.Token, p.Token)
+	if p.TokenEnv != "" {
+		set(&creds.Token, os.Getenv(p.TokenEnv))
+	}
+	return creds
+}
+
+// defaults merges the profile's calendar and project ids into d, with the
+// same precedence as apply.
+func (p *Profile) defaults(d onlyoffice.Defaults, explicit bool) onlyoffice.Defaults {
+	if v := strings.TrimSpace(p.CalendarID); v != "" && (explicit || os.Getenv("ONLYOFFICE_CALENDAR_ID") == "") {
+		d.CalendarID = v
+	}
+	if v := strings.TrimSpace(p.ProjectID); v != "" &&
+		(explicit || os.Getenv("ONLYOFFICE_PROJECT_ID") == "" && os.Getenv("ONLYOFFICE_CALENDAR_PROJECT_ID") == "") {
+		d.ProjectID = v
+	}
+	return d
+}
@@ -0,0 +1,79 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// telemetryOpts holds the client options set up by SetupTelemetry; empty
+// when telemetry is off, so ClientOptions adds nothing.
+var telemetryOpts []onlyoffice.Option
+
+// SetupTelemetry exports request spans and latency metrics over OTLP/HTTP
+// when an endpoint is configured through the standard variables
+// (OTEL_EXPORTER_OTLP_ENDPOINT, or the _TRACES_/_METRICS_ variants; headers,
+// protocol and OTEL_SERVICE_NAME are honoured by the SDK). Without one — or
+// with OTEL_SDK_DISABLED=true — it does nothing. Call it before NewClient and
+// run the returned shutdown before exit so buffered spans are flushed.
+func SetupTelemetry(ctx context.Context, serviceName string) (shutdown func(context.Context) error, err error) {
+	LoadEnv()
+	noop := func(context.Context) error { return nil }
+	if strings.EqualFold(strings.TrimSpace(os.Getenv("OTEL_SDK_DISABLED")), "true") {
+		return noop, nil
+	}
+	traces := otlpEndpointSet("TRACES")
+	metrics := otlpEndpointSet("METRICS")
+	if !traces && !metrics {
+		return noop, nil
+	}
+	res, err := resource.New(ctx,
+		resource.WithAttributes(attribute.String("service.name", serviceName)),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return noop, err
+	}
+	var shutdowns []func(context.Context) error
+	if traces {
+		exp, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return noop, err
+		}
+		tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exp), sdktrace.WithResource(res))
+		telemetryOpts = append(telemetryOpts, onlyoffice.WithTracerProvider(tp))
+		shutdowns = append(shutdowns, tp.Shutdown)
+	}
+	if metrics {
+		exp, err := otlpmetrichttp.New(ctx)
+		if err != nil {
+			return noop, err
+		}
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exp)), sdkmetric.WithResource(res))
+		telemetryOpts = append(telemetryOpts, onlyoffice.WithMeterProvider(mp))
+		shutdowns = append(shutdowns, mp.Shutdown)
+	}
+	return func(ctx context.Context) error {
+		var errs []error
+		for _, fn := range shutdowns {
+			errs = append(errs, fn(ctx))
+		}
+		return errors.Join(errs...)
+	}, nil
+}
+
+// otlpEndpointSet reports whether an OTLP endpoint is configured for signal
+// ("TRACES" or "METRICS"), either specifically or via the shared variable.
+func otlpEndpointSet(signal string) bool {
+	return strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_"+signal+"_ENDPOINT")) != "" ||
+		strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")) != ""
+}
@@ -67,10 +67,10 @@ func (l *Loader) List(ctx context.Context, spec model.ListSpec) ([]model.Item, e
 	case model.SubjectProjectFiles:
 		pid := spec.ProjectID
 		if pid == "" {
-			pid = onlyoffice.GetEnvironmentDefaults().ProjectID
+			pid = l.Client.Defaults().ProjectID
 		}
 		if pid == "" {
-			return nil, fmt.Errorf("set ONLYOFFICE_PROJECT_ID or pick a project in the tree")
+			return nil, fmt.Errorf("set ONLYOFFICE_PROJECT_ID (or project_id in the profile) or pick a project in the tree")
 		}
 		return l.listProjectFiles(ctx, pid)
 	case model.SubjectTaskFiles:
@@ -132,7 +132,7 @@ func (l *Loader) executeDelete(ctx context.Context, item model.Item) (string, er
 		if err != nil {
 			return "", err
 		}
-		if _, err := l.Client.DeleteProject(id); err != nil {
+		if _, err := l.Client.DeleteProjectContext(ctx, id); err != nil {
 			return "", err
 		}
 		return fmt.Sprintf("Deleted project %s", item.Title), nil
@@ -236,7 +236,7 @@ func (l *Loader) listTaskFiles(ctx context.Context, taskID string) ([]model.Item
 }
 
 func (l *Loader) listProjects(ctx context.Context) ([]model.Item, error) {
-	projects, err := l.Client.GetProjects()
+	projects, err := l.Client.GetProjectsContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -45,11 +45,11 @@ func (l *Loader) saveProject(ctx context.Context, projectID string, fields model
 	if fields.ResponsibleID != "" {
 		req.ResponsibleID = fields.ResponsibleID
 	}
-	if _, err := l.Client.UpdateProject(req); err != nil {
+	if _, err := l.Client.UpdateProjectContext(ctx, req); err != nil {
 		return err
 	}
 	if fields.HasStatus {
-		if _, err := l.Client.UpdateProjectStatus(id, string(fields.Status)); err != nil {
+		if _, err := l.Client.UpdateProjectStatusContext(ctx, id, string(fields.Status)); err != nil {
 			return err
 		}
 	}
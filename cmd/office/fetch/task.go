@@ -7,6 +7,7 @@ import (
 
 	onlyoffice "github.com/eslider/go-onlyoffice"
 	"github.com/eslider/go-onlyoffice/cmd/office/model"
+	"github.com/eslider/go-onlyoffice/cmd/office/preview"
 )
 
 // UpdateTask saves editable task fields via the typed JSON API.
@@ -48,6 +49,23 @@ func (l *Loader) updateTask(ctx context.Context, taskID string, fields model.For
 			}
 		}
 	}
-	_, err = l.Client.UpdateProjectTask(req)
+	_, err = l.Client.UpdateProjectTaskContext(ctx, req)
 	return err
 }
+
+// TaskCommentsMarkdown returns the task's comment thread as markdown and
+// the number of comments.
+func (l *Loader) TaskCommentsMarkdown(ctx context.Context, taskID string) (string, int, error) {
+	if l == nil || l.Client == nil {
+		return "", 0, fmt.Errorf("fetch: client is nil")
+	}
+	id, err := strconv.Atoi(taskID)
+	if err != nil {
+		return "", 0, fmt.Errorf("task id %q: %w", taskID, err)
+	}
+	comments, err := l.Client.ListTaskComments(ctx, id)
+	if err != nil {
+		return "", 0, err
+	}
+	return preview.CommentsMarkdown(comments), len(comments), nil
+}
@@ -3,7 +3,9 @@ package fetch
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	onlyoffice "github.com/eslider/go-onlyoffice"
@@ -11,7 +13,7 @@ import (
 )
 
 func (l *Loader) listUsers(ctx context.Context) ([]model.Item, error) {
-	users, err := l.Client.GetUsers()
+	users, err := l.Client.GetUsersContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -122,10 +124,29 @@ func (l *Loader) updateUserProfile(ctx context.Context, userID string, body map[
 	return nil, err
 }
 
+// isSuspendedUserError reports whether a profile update was rejected because
+// the user is terminated. The portal has no error code for that state: it
+// answers with a plain server error (500) whose envelope message names it.
+// An *onlyoffice.APIError is therefore classified by status and sentinel
+// first: only a 500 counts, and not one whose exception type maps it to
+// ErrNotFound, ErrForbidden or ErrValidation, whatever its text. Only then
+// is the envelope message checked.
+// Errors that carry no *APIError fall back to matching the error text.
 func isSuspendedUserError(err error) bool {
 	if err == nil {
 		return false
 	}
-	msg := strings.ToLower(err.Error())
+	if apiErr, ok := onlyoffice.AsAPIError(err); ok {
+		if apiErr.StatusCode != http.StatusInternalServerError ||
+			errors.Is(apiErr, onlyoffice.ErrNotFound) || errors.Is(apiErr, onlyoffice.ErrForbidden) || errors.Is(apiErr, onlyoffice.ErrValidation) {
+			return false
+		}
+		return mentionsSuspension(apiErr.Message)
+	}
+	return mentionsSuspension(err.Error())
+}
+
+func mentionsSuspension(msg string) bool {
+	msg = strings.ToLower(msg)
 	return strings.Contains(msg, "suspended") || strings.Contains(msg, "terminated")
 }
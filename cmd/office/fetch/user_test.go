@@ -3,6 +3,8 @@ package fetch
 import (
 	"fmt"
 	"testing"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
 )
 
 func TestUserProfileUpdateBodyOmitsStatus(t *testing.T) {
@@ -32,3 +34,27 @@ func TestIsSuspendedUserError(t *testing.T) {
 		t.Fatal("expected suspended detection")
 	}
 }
+
+func TestIsSuspendedUserAPIError(t *testing.T) {
+	err := fmt.Errorf("update: %w", &onlyoffice.APIError{
+		Method:     "PUT",
+		Path:       "/api/2.0/people/x",
+		StatusCode: 500,
+		Message:    "The user is suspended",
+	})
+	if !isSuspendedUserError(err) {
+		t.Fatal("expected suspended detection through wrapped *APIError")
+	}
+	for _, e := range []*onlyoffice.APIError{
+		{StatusCode: 500, Message: "Access denied"},
+		// The status and exception type decide before the text does.
+		{StatusCode: 403, Message: "The user is suspended"},
+		{StatusCode: 404, Message: "Terminated user not found"},
+		{StatusCode: 500, Type: "System.Security.SecurityException", Message: "The user is suspended"},
+		{StatusCode: 500, Type: "System.ArgumentException", Message: "terminated"},
+	} {
+		if isSuspendedUserError(e) {
+			t.Errorf("%d %s %q flagged as suspended", e.StatusCode, e.Type, e.Message)
+		}
+	}
+}
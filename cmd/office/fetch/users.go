@@ -11,7 +11,7 @@ func (l *Loader) LoadUserChoices(ctx context.Context) ([]model.UserOption, error
 	if l == nil || l.Client == nil {
 		return nil, nil
 	}
-	users, err := l.Client.GetUsers()
+	users, err := l.Client.GetUsersContext(ctx)
 	if err != nil {
 		return nil, err
 	}
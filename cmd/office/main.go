@@ -6,10 +6,14 @@
 // Build & install:
 //
 //	go install github.com/eslider/go-onlyoffice/cmd/office@latest
+//
+// Pick a portal from the oo config file with -profile NAME.
 package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
 
@@ -19,16 +23,30 @@ import (
 )
 
 func main() {
-	client, err := bootstrap.NewClient(context.Background())
-	if err != nil {
+	if err := run(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
+}
+
+func run() (err error) {
+	flag.StringVar(&bootstrap.ProfileName, "profile", "", "portal profile from the config file (default $ONLYOFFICE_PROFILE, then the file's default)")
+	flag.Parse()
+
+	ctx := context.Background()
+	shutdown, err := bootstrap.SetupTelemetry(ctx, "office")
+	if err != nil {
+		return fmt.Errorf("telemetry: %w", err)
+	}
+	defer func() { err = errors.Join(err, shutdown(ctx)) }()
+
+	client, err := bootstrap.NewClient(ctx)
+	if err != nil {
+		return err
+	}
 
 	m := ui.NewModel(client)
 	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
-	if _, err := p.Run(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
-	}
+	_, err = p.Run()
+	return err
 }
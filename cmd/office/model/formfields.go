@@ -23,6 +23,14 @@ type FormFields struct {
 	UserACL        UserACLState
 	GroupsText     string
 	UserPassword   string
+	Subtasks       []SubtaskItem
+}
+
+// SubtaskItem is one line of a task's subtask checklist.
+type SubtaskItem struct {
+	Title       string
+	Responsible string
+	Done        bool
 }
 
 // KindHeading returns a short label for the detail pane header.
@@ -125,6 +133,7 @@ func FormFieldsFromRaw(kind Kind, raw map[string]any) FormFields {
 			ResponsibleID:  TaskResponsibleIDFromRaw(raw),
 			ProjectTitle:   TaskProjectTitle(raw),
 			TimingSummary:  TaskTimingSummary(raw),
+			Subtasks:       TaskSubtasksFromRaw(raw),
 		}
 	default:
 		title := strRaw(raw, "title")
@@ -158,6 +167,24 @@ func TaskResponsibleIDFromRaw(raw map[string]any) string {
 	return ""
 }
 
+// TaskSubtasksFromRaw returns the task's subtasks as checklist items.
+func TaskSubtasksFromRaw(raw map[string]any) []SubtaskItem {
+	list, _ := raw["subtasks"].([]any)
+	out := make([]SubtaskItem, 0, len(list))
+	for _, v := range list {
+		m, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		item := SubtaskItem{Title: strRaw(m, "title"), Done: intRawVal(m, "status") == 2}
+		if resp, ok := m["responsible"].(map[string]any); ok {
+			item.Responsible = strRaw(resp, "displayName")
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
 // TaskProjectTitle returns the owning project title when present.
 func TaskProjectTitle(raw map[string]any) string {
 	if raw == nil {
@@ -40,3 +40,16 @@ func TestFormFieldsFromRawTask(t *testing.T) {
 		t.Fatal("expected timing summary")
 	}
 }
+
+func TestTaskSubtasksFromRaw(t *testing.T) {
+	raw := map[string]any{"subtasks": []any{
+		map[string]any{"title": "Draft", "status": float64(2), "responsible": map[string]any{"displayName": "Alice"}},
+		map[string]any{"title": "Review", "status": float64(1)},
+		"junk",
+	}}
+	got := FormFieldsFromRaw(KindTask, raw).Subtasks
+	want := []SubtaskItem{{Title: "Draft", Responsible: "Alice", Done: true}, {Title: "Review"}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("subtasks = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,55 @@
+package preview
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
+)
+
+// CommentMarkdown converts a comment's HTML body to markdown, falling back
+// to the text with tags stripped.
+func CommentMarkdown(text string) string {
+	text = strings.TrimSpace(text)
+	if !looksLikeHTML(text) {
+		return text
+	}
+	if md, err := HTMLToMarkdown(text); err == nil {
+		if md = strings.TrimSpace(md); md != "" {
+			return md
+		}
+	}
+	return stripHTML(text)
+}
+
+// CommentsMarkdown formats a comment thread: replies follow their parent,
+// quoted one level deeper.
+func CommentsMarkdown(comments []onlyoffice.Comment) string {
+	if len(comments) == 0 {
+		return "_No comments yet._\n"
+	}
+	ordered, depth := onlyoffice.ThreadComments(comments)
+	var b strings.Builder
+	for i, c := range ordered {
+		quote := strings.Repeat("> ", depth[i])
+		author := c.AuthorName()
+		if author == "" {
+			author = "unknown"
+		}
+		head := "**" + author + "**"
+		if c.Created != nil {
+			head += " · " + time.Time(*c.Created).Format("2006-01-02 15:04")
+		}
+		body := CommentMarkdown(c.Text)
+		if c.Inactive {
+			body = "_(deleted)_"
+		}
+		fmt.Fprintf(&b, "%s%s\n%s\n", quote, head, quote)
+		for _, line := range strings.Split(body, "\n") {
+			fmt.Fprintf(&b, "%s%s\n", quote, line)
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimSpace(b.String()) + "\n"
+}
@@ -0,0 +1,34 @@
+package preview_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
+	"github.com/eslider/go-onlyoffice/cmd/office/preview"
+)
+
+func TestCommentsMarkdownThreads(t *testing.T) {
+	var comments []onlyoffice.Comment
+	err := json.Unmarshal([]byte(`[
+		{"id":"a","text":"<p>Looks <strong>good</strong></p>","created":"2026-10-14T09:30:00","createdBy":{"displayName":"Ann"}},
+		{"id":"b","text":"Ship it?","createdBy":{"displayName":"Bob"}},
+		{"id":"c","parentId":"a","text":"<p>Thanks</p>","createdBy":{"displayName":"Bob"}}
+	]`), &comments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := preview.CommentsMarkdown(comments)
+	for _, want := range []string{"**Ann** · 2026-10-14 09:30", "Looks **good**", "> **Bob**", "> Thanks", "Ship it?"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("missing %q in:\n%s", want, md)
+		}
+	}
+	if strings.Index(md, "Thanks") > strings.Index(md, "Ship it?") {
+		t.Errorf("reply should follow its parent:\n%s", md)
+	}
+	if md := preview.CommentsMarkdown(nil); !strings.Contains(md, "No comments") {
+		t.Errorf("empty thread = %q", md)
+	}
+}
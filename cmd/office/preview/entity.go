@@ -132,6 +132,19 @@ func TaskMarkdown(m map[string]any) string {
 	if desc := str(m, "description"); desc != "" {
 		fmt.Fprintf(&b, "## Description\n\n%s\n\n", desc)
 	}
+	if subs, ok := m["subtasks"].([]any); ok && len(subs) > 0 {
+		b.WriteString("## Subtasks\n\n")
+		for _, raw := range subs {
+			if row, ok := raw.(map[string]any); ok {
+				box := " "
+				if str(row, "status") == "2" {
+					box = "x"
+				}
+				fmt.Fprintf(&b, "- [%s] %s\n", box, str(row, "title"))
+			}
+		}
+		b.WriteString("\n")
+	}
 	return strings.TrimSpace(b.String()) + "\n"
 }
 
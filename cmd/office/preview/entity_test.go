@@ -56,3 +56,16 @@ func TestMailMarkdownPlainBodyFallback(t *testing.T) {
 		t.Fatalf("missing plain body:\n%s", md)
 	}
 }
+
+func TestTaskMarkdownSubtaskChecklist(t *testing.T) {
+	md := preview.TaskMarkdown(map[string]any{
+		"title": "Release",
+		"subtasks": []any{
+			map[string]any{"title": "Tag", "status": float64(2)},
+			map[string]any{"title": "Announce", "status": float64(1)},
+		},
+	})
+	if !strings.Contains(md, "- [x] Tag\n- [ ] Announce") {
+		t.Fatalf("checklist missing:\n%s", md)
+	}
+}
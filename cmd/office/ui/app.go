@@ -31,6 +31,13 @@ type detailLoadedMsg struct {
 	err      error
 }
 
+type commentsLoadedMsg struct {
+	itemID   string
+	markdown string
+	count    int
+	err      error
+}
+
 type detailSavedMsg struct {
 	item   model.Item
 	fields model.FormFields
@@ -354,21 +361,25 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err.Error()
 			m.detail.Clear()
 		} else {
-			pw := m.paneLayout()
-			w := pw.Detail - 4
-			if w < 20 {
-				w = 20
-			}
 			if msg.document {
-				m.detail.LoadDocument(msg.item, msg.markdown, w)
+				m.detail.LoadDocument(msg.item, msg.markdown, m.detailRenderWidth())
 			} else {
 				m.detail.LoadForm(msg.item, msg.fields)
 			}
 			m.detail.SetFocused(m.focus == model.FocusPreview)
 			m.err = ""
+			if m.detail.NeedsComments() {
+				m.loading = true
+				return m, m.loadCommentsCmd(msg.item)
+			}
 		}
 		return m, nil
 
+	case commentsLoadedMsg:
+		m.loading = false
+		m.detail.SetComments(msg.itemID, msg.markdown, msg.count, msg.err, m.detailRenderWidth())
+		return m, nil
+
 	case detailSavedMsg:
 		m.loading = false
 		if msg.err != nil {
@@ -458,6 +469,15 @@ func (m *Model) handleDetailKey(key string, msg tea.KeyMsg) (tea.Cmd, bool) {
 	case "ctrl+s":
 		m.loading = true
 		return m.saveDetailCmd(), true
+	case "alt+c":
+		if !m.detail.HasTabs() {
+			return nil, false
+		}
+		if m.detail.ToggleComments() {
+			m.loading = true
+			return m.loadCommentsCmd(m.detail.Item()), true
+		}
+		return nil, true
 	case "enter":
 		if m.detail.Zone() == detailZoneActions {
 			act, ok := m.detail.SelectedAction()
@@ -484,7 +504,7 @@ func (m *Model) routeDetailDocumentMouse(msg tea.MouseMsg) (tea.Cmd, bool) {
 	if !m.showDetail {
 		return nil, false
 	}
-	if !m.detail.IsDocumentContent() && !m.detail.isReadOnlyFormContent() {
+	if !m.detail.IsDocumentContent() && !m.detail.isReadOnlyFormContent() && !m.detail.showingComments() {
 		return nil, false
 	}
 	if msg.Y > m.paneHeight()+1 {
@@ -502,7 +522,7 @@ func (m *Model) routeDetailDocumentMouse(msg tea.MouseMsg) (tea.Cmd, bool) {
 }
 
 func (m Model) handleDetailMove(delta int) (Model, tea.Cmd) {
-	if m.detail.Zone() == detailZoneContent && (m.detail.IsDocumentContent() || m.detail.isReadOnlyFormContent()) {
+	if m.detail.Zone() == detailZoneContent && (m.detail.IsDocumentContent() || m.detail.isReadOnlyFormContent() || m.detail.showingComments()) {
 		key := "down"
 		if delta < 0 {
 			key = "up"
@@ -979,6 +999,19 @@ func (m *Model) loadDetailCmd(item model.Item) tea.Cmd {
 	}
 }
 
+func (m *Model) loadCommentsCmd(item model.Item) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		md, n, err := m.loader.TaskCommentsMarkdown(ctx, item.ID)
+		return commentsLoadedMsg{itemID: item.ID, markdown: md, count: n, err: err}
+	}
+}
+
+// detailRenderWidth is the markdown wrap width of the detail pane.
+func (m Model) detailRenderWidth() int {
+	return max(m.paneLayout().Detail-4, 20)
+}
+
 func (m *Model) closeTaskCmd() tea.Cmd {
 	item := m.detail.Item()
 	fields := m.detail.form.FormFields()
@@ -1045,5 +1078,5 @@ func paneStyle(focused bool) lipgloss.Style {
 }
 
 func helpText() string {
-	return "Alt+1/2/3: panes · / filter · Space select · 💾🗑 when selected · v: detail · Ctrl+S: save · q: quit"
+	return "Alt+1/2/3: panes · / filter · Space select · 💾🗑 when selected · v: detail · Ctrl+S: save · Alt+C: task comments · q: quit"
 }
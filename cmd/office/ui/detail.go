@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/viewport"
@@ -20,6 +21,14 @@ const (
 	detailForm
 )
 
+// detailTab selects what a task form shows: its fields or its comments.
+type detailTab int
+
+const (
+	detailTabFields detailTab = iota
+	detailTabComments
+)
+
 type detailZone int
 
 const (
@@ -44,6 +53,11 @@ type DetailPane struct {
 	formVP    viewport.Model
 	docText   string
 	styles    detailStyles
+
+	tab          detailTab
+	commentsVP   viewport.Model
+	commentsFor  string // item id the comments were loaded for
+	commentCount int
 }
 
 type detailStyles struct {
@@ -60,9 +74,12 @@ func newDetailPane() DetailPane {
 		docVP:  viewport.New(40, 10),
 		formVP: viewport.New(40, 10),
 		styles: newDetailStyles(),
+
+		commentsVP: viewport.New(40, 10),
 	}
 	d.docVP.MouseWheelEnabled = true
 	d.formVP.MouseWheelEnabled = true
+	d.commentsVP.MouseWheelEnabled = true
 	return d
 }
 
@@ -88,6 +105,68 @@ func (d *DetailPane) Clear() {
 	d.form.Clear()
 	d.docVP.SetContent("")
 	d.formVP.SetContent("")
+	d.resetComments()
+}
+
+func (d *DetailPane) resetComments() {
+	d.tab = detailTabFields
+	d.commentsFor = ""
+	d.commentCount = 0
+	d.commentsVP.SetContent("")
+}
+
+// HasTabs reports whether the pane shows the Details / Comments tab bar
+// (task forms only).
+func (d DetailPane) HasTabs() bool {
+	return d.mode == detailForm && d.item.Kind == model.KindTask
+}
+
+func (d DetailPane) showingComments() bool {
+	return d.HasTabs() && d.tab == detailTabComments
+}
+
+// ToggleComments switches between the Details and Comments tabs. It
+// returns true when the comments of the current item still need loading.
+func (d *DetailPane) ToggleComments() bool {
+	if !d.HasTabs() {
+		return false
+	}
+	if d.tab == detailTabComments {
+		d.tab = detailTabFields
+	} else {
+		d.tab = detailTabComments
+	}
+	d.tabStop = 0
+	d.applyTabStop(0)
+	d.layoutContent()
+	return d.NeedsComments()
+}
+
+// NeedsComments reports whether the Comments tab is open on an item whose
+// comments have not been loaded yet.
+func (d DetailPane) NeedsComments() bool {
+	return d.showingComments() && d.commentsFor != d.loadedID
+}
+
+// SetComments shows the comment thread loaded for item id; stale results
+// for another item are dropped.
+func (d *DetailPane) SetComments(id, markdown string, count int, err error, renderWidth int) {
+	if id != d.loadedID {
+		return
+	}
+	d.commentsFor = id
+	d.commentCount = count
+	if err != nil {
+		d.commentsFor = ""
+		d.commentsVP.SetContent(d.styles.empty.Render("Could not load comments: " + err.Error()))
+		return
+	}
+	text, rerr := preview.RenderMarkdown(markdown, renderWidth)
+	if rerr != nil {
+		text = markdown
+	}
+	d.commentsVP.SetContent(text)
+	d.commentsVP.GotoTop()
 }
 
 func (d *DetailPane) SetFocused(on bool) {
@@ -101,6 +180,9 @@ func (d *DetailPane) FocusFirstStop() {
 }
 
 func (d *DetailPane) maxTabStop() int {
+	if d.showingComments() {
+		return len(d.actions)
+	}
 	switch d.mode {
 	case detailForm:
 		if d.form.readOnly {
@@ -137,6 +219,16 @@ func (d *DetailPane) applyTabStop(stop int) {
 	}
 	d.tabStop = stop
 
+	if d.showingComments() {
+		d.form.SetFocused(false)
+		if stop == 0 {
+			d.zone = detailZoneContent
+			return
+		}
+		d.zone = detailZoneActions
+		d.actionIdx = stop - 1
+		return
+	}
 	switch d.mode {
 	case detailForm:
 		if d.form.readOnly {
@@ -200,12 +292,7 @@ func (d *DetailPane) SetSize(w, h int) {
 	}
 	d.width = w
 	d.height = h
-	contentH, _ := d.splitHeights()
-	d.form.SetSize(w, contentH)
-	d.docVP.Width = w
-	d.docVP.Height = contentH
-	d.formVP.Width = w
-	d.formVP.Height = contentH
+	d.layoutContent()
 }
 
 func (d *DetailPane) splitHeights() (contentH, actionH int) {
@@ -224,6 +311,14 @@ func (d *DetailPane) splitHeights() (contentH, actionH int) {
 }
 
 func (d *DetailPane) LoadForm(item model.Item, fields model.FormFields) {
+	if item.ID != d.loadedID || item.Kind != d.item.Kind {
+		// Moving between tasks keeps the Comments tab open.
+		tab := d.tab
+		d.resetComments()
+		if item.Kind == model.KindTask {
+			d.tab = tab
+		}
+	}
 	d.mode = detailForm
 	d.item = item
 	d.loadedID = item.ID
@@ -244,6 +339,7 @@ func (d *DetailPane) LoadDocument(item model.Item, markdown string, renderWidth
 	d.actionIdx = 0
 	d.tabStop = 0
 	d.form.Clear()
+	d.resetComments()
 	text, err := preview.RenderMarkdown(markdown, renderWidth)
 	if err != nil {
 		text = markdown
@@ -256,15 +352,26 @@ func (d *DetailPane) LoadDocument(item model.Item, markdown string, renderWidth
 }
 
 func (d *DetailPane) layoutContent() {
-	contentH, _ := d.splitHeights()
+	contentH := d.bodyHeight()
 	d.form.SetSize(d.width, contentH)
 	d.docVP.Width = d.width
 	d.docVP.Height = contentH
 	d.formVP.Width = d.width
 	d.formVP.Height = contentH
+	d.commentsVP.Width = d.width
+	d.commentsVP.Height = contentH
 	d.refreshFormViewport()
 }
 
+// bodyHeight is the content height below the tab bar, if any.
+func (d DetailPane) bodyHeight() int {
+	contentH, _ := d.splitHeights()
+	if d.HasTabs() && contentH > 4 {
+		contentH--
+	}
+	return contentH
+}
+
 func (d *DetailPane) refreshFormViewport() {
 	if d.mode != detailForm || !d.form.readOnly {
 		d.formVP.SetContent("")
@@ -288,6 +395,10 @@ func (d *DetailPane) FocusContent() {
 
 func (d *DetailPane) FocusActions() {
 	max := d.maxTabStop()
+	if d.showingComments() {
+		d.applyTabStop(min(1, max))
+		return
+	}
 	switch d.mode {
 	case detailForm:
 		if d.form.readOnly {
@@ -349,6 +460,9 @@ func (d DetailPane) documentContentHeight() int {
 
 // ScrollDocument scrolls the read-only document viewport (mail, file preview).
 func (d *DetailPane) ScrollDocument(key string) bool {
+	if d.showingComments() {
+		return d.zone == detailZoneContent && scrollViewport(&d.commentsVP, key)
+	}
 	if d.IsDocumentContent() {
 		return scrollViewport(&d.docVP, key)
 	}
@@ -362,6 +476,8 @@ func (d *DetailPane) ScrollDocument(key string) bool {
 func (d *DetailPane) ScrollDocumentMouse(msg tea.MouseMsg) tea.Cmd {
 	var vp *viewport.Model
 	switch {
+	case d.showingComments():
+		vp = &d.commentsVP
 	case d.IsDocumentContent():
 		vp = &d.docVP
 	case d.isReadOnlyFormContent():
@@ -395,6 +511,16 @@ func (d *DetailPane) Update(msg tea.Msg) tea.Cmd {
 		return nil
 	}
 	if d.zone == detailZoneContent {
+		if d.showingComments() {
+			if key, ok := msg.(tea.KeyMsg); ok {
+				if scrollViewport(&d.commentsVP, key.String()) {
+					return nil
+				}
+			}
+			var cmd tea.Cmd
+			d.commentsVP, cmd = d.commentsVP.Update(msg)
+			return cmd
+		}
 		switch d.mode {
 		case detailForm:
 			if d.form.readOnly {
@@ -426,11 +552,20 @@ func (d DetailPane) View() string {
 	if d.mode == detailEmpty {
 		return d.styles.empty.Render("Select a row to preview or edit.")
 	}
-	contentH, actionH := d.splitHeights()
+	_, actionH := d.splitHeights()
+	contentH := d.bodyHeight()
 	var top string
 	switch d.mode {
 	case detailForm:
-		if d.form.readOnly {
+		if d.showingComments() {
+			top = ApplyVerticalScrollbar(
+				d.commentsVP.View(),
+				d.commentsVP.Width,
+				d.commentsVP.Height,
+				d.commentsVP.TotalLineCount(),
+				d.commentsVP.YOffset,
+			)
+		} else if d.form.readOnly {
 			top = ApplyVerticalScrollbar(
 				d.formVP.View(),
 				d.formVP.Width,
@@ -452,11 +587,31 @@ func (d DetailPane) View() string {
 	default:
 		top = ""
 	}
+	if d.HasTabs() {
+		top = lipgloss.JoinVertical(lipgloss.Left, d.renderTabs(), top)
+	}
 	sep := d.styles.sep.Width(d.width).Render(strings.Repeat("─", max(1, d.width)))
 	bottom := lipgloss.NewStyle().Width(d.width).Height(actionH).Render(d.renderActions())
 	return lipgloss.JoinVertical(lipgloss.Left, top, sep, bottom)
 }
 
+func (d DetailPane) renderTabs() string {
+	comments := "Comments"
+	if d.commentsFor == d.loadedID && d.commentsFor != "" {
+		comments = fmt.Sprintf("Comments (%d)", d.commentCount)
+	}
+	parts := make([]string, 0, 3)
+	for i, label := range []string{"Details", comments} {
+		style := d.styles.action
+		if detailTab(i) == d.tab {
+			style = d.styles.actionOn
+		}
+		parts = append(parts, style.Render(label))
+	}
+	parts = append(parts, d.styles.empty.Render(" Alt+C"))
+	return lipgloss.NewStyle().Width(d.width).MaxHeight(1).Render(strings.Join(parts, ""))
+}
+
 func (d DetailPane) renderActions() string {
 	if len(d.actions) == 0 {
 		hint := "No actions"
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eslider/go-onlyoffice/cmd/office/model"
+)
+
+func taskFormFields() model.FormFields {
+	return model.FormFields{
+		PrimaryLabel: "Title", SecondaryLabel: "Description",
+		Primary: "Alpha", HasTaskStatus: true, TaskStatus: model.TaskLifecycleOpen,
+	}
+}
+
+func TestDetailCommentsTab(t *testing.T) {
+	d := newDetailPane()
+	d.SetSize(60, 30)
+	d.SetFocused(true)
+	d.LoadForm(model.Item{ID: "9", Kind: model.KindTask, Title: "T"}, taskFormFields())
+	if !d.HasTabs() || d.showingComments() {
+		t.Fatal("task form should show tabs, Details first")
+	}
+	if !d.ToggleComments() {
+		t.Fatal("first switch to Comments should ask for a load")
+	}
+	if d.Zone() != detailZoneContent || d.maxTabStop() != len(d.actions) {
+		t.Fatalf("comments tab: zone=%d max=%d", d.Zone(), d.maxTabStop())
+	}
+	d.TabForward()
+	if d.Zone() != detailZoneActions || d.actionIdx != 0 {
+		t.Fatalf("tab from comments should reach Save, zone=%d idx=%d", d.Zone(), d.actionIdx)
+	}
+
+	d.SetComments("other", "stale", 5, nil, 40)
+	if !d.NeedsComments() || d.commentCount != 0 {
+		t.Fatal("comments for another item must be ignored")
+	}
+	d.SetComments("9", "**Ann**\n\nLooks good", 1, nil, 40)
+	if d.NeedsComments() {
+		t.Fatal("comments loaded")
+	}
+	view := d.View()
+	if !strings.Contains(view, "Comments (1)") || !strings.Contains(view, "Looks good") {
+		t.Fatalf("view:\n%s", view)
+	}
+
+	d.LoadForm(model.Item{ID: "10", Kind: model.KindTask, Title: "U"}, taskFormFields())
+	if !d.showingComments() || !d.NeedsComments() {
+		t.Fatal("next task keeps the Comments tab and needs its own comments")
+	}
+	if d.ToggleComments() || d.showingComments() {
+		t.Fatal("toggle back to Details")
+	}
+
+	d.LoadForm(model.Item{ID: "1", Kind: model.KindProject}, model.FormFields{PrimaryLabel: "Title"})
+	if d.HasTabs() || d.ToggleComments() {
+		t.Fatal("projects have no Comments tab")
+	}
+}
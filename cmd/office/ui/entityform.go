@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -37,6 +38,7 @@ type EntityForm struct {
 	responsibleIdx  int
 	projectTitle    string
 	timingSummary   string
+	subtasks        []model.SubtaskItem
 	hasUserEdit     bool
 	userEnabled     bool
 	userACL         model.UserACLState
@@ -121,6 +123,7 @@ func (f *EntityForm) FormFields() model.FormFields {
 		UserChoices:    f.userChoices,
 		ProjectTitle:   f.projectTitle,
 		TimingSummary:  f.timingSummary,
+		Subtasks:       f.subtasks,
 		HasUserEdit:    f.hasUserEdit,
 		UserEnabled:    f.userEnabled,
 		UserACL:        f.userACL,
@@ -154,6 +157,7 @@ func (f *EntityForm) Load(kind model.Kind, itemID string, fields model.FormField
 	f.userChoices = fields.UserChoices
 	f.projectTitle = fields.ProjectTitle
 	f.timingSummary = fields.TimingSummary
+	f.subtasks = fields.Subtasks
 	f.responsibleID = fields.ResponsibleID
 	f.responsibleIdx = indexUserChoice(fields.UserChoices, fields.ResponsibleID)
 	f.hasUserEdit = fields.HasUserEdit
@@ -196,6 +200,7 @@ func (f *EntityForm) Clear() {
 	f.responsibleIdx = 0
 	f.projectTitle = ""
 	f.timingSummary = ""
+	f.subtasks = nil
 	f.hasUserEdit = false
 	f.userEnabled = false
 	f.userACL = model.UserACLState{}
@@ -257,6 +262,9 @@ func (f *EntityForm) layoutFields() {
 		metaLines++ // blank line before meta
 	}
 	extra := 6 + metaLines
+	if len(f.subtasks) > 0 {
+		extra += len(f.subtasks) + 2 // blank line and "Subtasks n/m"
+	}
 	if f.hasStatus || f.hasTaskStatus {
 		extra += 2
 	}
@@ -483,6 +491,9 @@ func (f EntityForm) View() string {
 	if metaBlock != "" {
 		parts = append(parts, metaBlock)
 	}
+	if subtasks := f.renderSubtasksBlock(); subtasks != "" {
+		parts = append(parts, subtasks)
+	}
 	return strings.Join(parts, "\n")
 }
 
@@ -548,6 +559,32 @@ func (f EntityForm) renderMetaBlock() string {
 	return strings.Join(lines, "\n")
 }
 
+// renderSubtasksBlock lists a task's subtasks as a read-only checklist.
+func (f EntityForm) renderSubtasksBlock() string {
+	if len(f.subtasks) == 0 {
+		return ""
+	}
+	done := 0
+	for _, s := range f.subtasks {
+		if s.Done {
+			done++
+		}
+	}
+	lines := []string{"", f.styles.label.Render(fmt.Sprintf("Subtasks %d/%d", done, len(f.subtasks)))}
+	for _, s := range f.subtasks {
+		box, style := "[ ] ", f.styles.meta
+		if s.Done {
+			box, style = "[x] ", f.styles.label.Strikethrough(true)
+		}
+		line := box + style.Render(s.Title)
+		if s.Responsible != "" {
+			line += f.styles.label.Render(" · " + s.Responsible)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
 func entityFormBlinkCmd() tea.Cmd {
 	return tea.Batch(textinput.Blink, textarea.Blink)
 }
@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eslider/go-onlyoffice/cmd/office/model"
+)
+
+func TestEntityFormSubtaskChecklist(t *testing.T) {
+	f := NewEntityFormForTest()
+	f.SetSize(60, 30)
+	fields := taskFormFields()
+	fields.Subtasks = []model.SubtaskItem{{Title: "Draft", Responsible: "Alice", Done: true}, {Title: "Review"}}
+	f.Load(model.KindTask, "9", fields)
+	view := f.View()
+	for _, want := range []string{"Subtasks 1/2", "[x] ", "Draft", "Alice", "[ ] ", "Review"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("view lacks %q:\n%s", want, view)
+		}
+	}
+	if len(f.FormFields().Subtasks) != 2 {
+		t.Error("FormFields should carry the subtasks back")
+	}
+
+	f.Load(model.KindTask, "10", taskFormFields())
+	if strings.Contains(f.View(), "Subtasks") {
+		t.Error("task without subtasks shows no checklist")
+	}
+}
@@ -0,0 +1,169 @@
+// Command oo-sandbox runs a local stand-in for an OnlyOffice Workspace
+// portal, so the office TUI and oo scripts can be demoed and smoke-tested
+// on a laptop.
+//
+//	oo-sandbox [-addr :8090] [-seed file-or-dir]...
+//	oo-sandbox snapshot [-o sandbox-seed.json]
+//
+// The server keeps all state in memory, seeded from cassettes: snapshot
+// records a read-only tour of a real portal (people, projects and tasks,
+// CRM, mail, files, calendar) with the usual ONLYOFFICE_* credentials and
+// writes it sanitized. Point the clients at the sandbox with any user name
+// and password:
+//
+//	ONLYOFFICE_URL=http://localhost:8090 ONLYOFFICE_USER=demo ONLYOFFICE_PASS=demo office
+//
+// Build & install:
+//
+//	go install github.com/eslider/go-onlyoffice/cmd/oo-sandbox@latest
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
+	"github.com/eslider/go-onlyoffice/cassette"
+	"github.com/eslider/go-onlyoffice/cmd/internal/bootstrap"
+	"github.com/eslider/go-onlyoffice/sandbox"
+)
+
+func main() {
+	var err error
+	if len(os.Args) > 1 && os.Args[1] == "snapshot" {
+		err = snapshot(os.Args[2:])
+	} else {
+		err = serve(os.Args[1:])
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func serve(args []string) error {
+	fl := flag.NewFlagSet("oo-sandbox", flag.ContinueOnError)
+	addr := fl.String("addr", "localhost:8090", "listen address")
+	var seeds []string
+	fl.Func("seed", "cassette file, or directory of *.json cassettes, to seed from (repeatable)", func(v string) error {
+		seeds = append(seeds, v)
+		return nil
+	})
+	if err := fl.Parse(args); err != nil {
+		return err
+	}
+
+	srv := sandbox.New()
+	for _, path := range seeds {
+		files, err := cassetteFiles(path)
+		if err != nil {
+			return err
+		}
+		for _, f := range files {
+			cas, err := cassette.Load(f)
+			if err != nil {
+				return err
+			}
+			log.Printf("seeded %d rows from %s", srv.Seed(cas), f)
+		}
+	}
+	log.Printf("OnlyOffice sandbox on http://%s (any user/password signs in)", *addr)
+	return http.ListenAndServe(*addr, srv)
+}
+
+// cassetteFiles expands a -seed argument: a file, or the *.json files of a
+// directory.
+func cassetteFiles(path string) ([]string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return []string{path}, nil
+	}
+	files, err := filepath.Glob(filepath.Join(path, "*.json"))
+	if err == nil && len(files) == 0 {
+		err = fmt.Errorf("%s: no *.json cassettes: %w", path, fs.ErrNotExist)
+	}
+	return files, err
+}
+
+// snapshot records the read-only calls the TUI makes against the portal
+// in the environment, for use as a -seed.
+func snapshot(args []string) (err error) {
+	fl := flag.NewFlagSet("oo-sandbox snapshot", flag.ContinueOnError)
+	out := fl.String("o", "sandbox-seed.json", "cassette file to write")
+	if err := fl.Parse(args); err != nil {
+		return err
+	}
+	ctx := context.Background()
+	creds, err := bootstrap.Credentials()
+	if err != nil {
+		return err
+	}
+	opts, err := bootstrap.ClientOptions()
+	if err != nil {
+		return err
+	}
+	cas := cassette.New(*out)
+	opts = append(opts, onlyoffice.WithMiddleware(cas.Middleware()), onlyoffice.WithTFACode(bootstrap.TFACode))
+	c := onlyoffice.NewClient(creds, opts...)
+	if err := c.AuthenticateContext(ctx); err != nil {
+		return err
+	}
+
+	// Each module is optional on a portal: a failing call is reported and
+	// the tour goes on.
+	calls := []struct {
+		name string
+		call func() error
+	}{
+		{"people", func() error { _, err := c.GetUsersContext(ctx); return err }},
+		{"self", func() error { _, err := c.GetUser(ctx, "@self"); return err }},
+		{"projects", func() error { _, err := c.GetProjectsContext(ctx); return err }},
+		{"tasks", func() error { _, err := c.ListAllTasks(ctx, ""); return err }},
+		{"contacts", func() error { _, err := c.ListAllContacts(ctx); return err }},
+		{"opportunities", func() error { _, err := c.ListAllOpportunities(ctx); return err }},
+		{"deal stages", func() error { _, err := c.ListDealStages(ctx); return err }},
+		{"cases", func() error { _, _, err := c.ListCases(ctx, 100, 0); return err }},
+		{"CRM tasks", func() error { _, _, err := c.ListCRMTasks(ctx, 100, 0); return err }},
+		{"task categories", func() error { _, err := c.ListTaskCategories(ctx); return err }},
+		{"invoices", func() error { _, _, err := c.ListInvoices(ctx, 100, 0); return err }},
+		{"invoice items", func() error { _, _, err := c.ListInvoiceItems(ctx, 100, 0); return err }},
+		{"mail accounts", func() error { _, err := c.ListMailAccounts(ctx); return err }},
+		{"mail folders", func() error { _, err := c.ListMailFolders(ctx); return err }},
+		{"inbox", func() error {
+			_, err := c.ListMailMessages(ctx, onlyoffice.MailMessagesFilter{Folder: onlyoffice.MailFolderInbox})
+			return err
+		}},
+		{"files", func() error { _, err := c.ListDavSections(ctx); return err }},
+		{"my documents", func() error { _, err := c.ListDavFolder(ctx, "@my"); return err }},
+		{"calendars", func() error { _, err := c.ListCalendars(ctx, "", ""); return err }},
+	}
+	var failed []string
+	for _, step := range calls {
+		if err := step.call(); err != nil {
+			log.Printf("snapshot: %s: %v", step.name, err)
+			failed = append(failed, step.name)
+		}
+	}
+	if err := cas.Save(); err != nil {
+		return err
+	}
+	log.Printf("wrote %d interactions to %s", len(cas.Interactions), *out)
+	if len(failed) == len(calls) {
+		return errors.New("snapshot: every call failed")
+	}
+	if len(failed) > 0 {
+		log.Printf("skipped: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
+	"github.com/eslider/go-onlyoffice/cmd/internal/bootstrap"
+	"github.com/spf13/cobra"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage the cached authentication token",
+	Long: "oo caches the portal token on disk (ONLYOFFICE_TOKEN_CACHE, default\n" +
+		"$XDG_CACHE_HOME/oo/tokens.json) so consecutive commands do not re-authenticate.",
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authLoginCmd())
+	authCmd.AddCommand(authLogoutCmd())
+	authCmd.AddCommand(authStatusCmd())
+}
+
+func authLoginCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "login",
+		Short: "Authenticate now and cache a fresh token",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := bootstrap.NewUnauthenticatedClient()
+			if err != nil {
+				return err
+			}
+			c.InvalidateToken() // drop any cached token so login always hits the portal
+			if err := c.AuthenticateContext(cmd.Context()); err != nil {
+				return err
+			}
+			if store, _ := bootstrap.TokenStore(); store == nil {
+				fmt.Println("authenticated (token cache disabled)")
+				return nil
+			}
+			return printTokenStatus()
+		},
+	}
+}
+
+func authLogoutCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Remove the cached token for the configured portal user",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			creds, store, err := tokenCache()
+			if err != nil {
+				return err
+			}
+			if err := store.Delete(onlyoffice.TokenStoreKey(creds)); err != nil {
+				return err
+			}
+			fmt.Printf("logged out %s from %s\n", creds.User, creds.Url)
+			return nil
+		},
+	}
+}
+
+func authStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show the cached token for the configured portal user (no network)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printTokenStatus()
+		},
+	}
+}
+
+// tokenCache returns the configured credentials and token store; it fails
+// when the cache is disabled via ONLYOFFICE_TOKEN_CACHE=off.
+func tokenCache() (onlyoffice.Credentials, *onlyoffice.FileTokenStore, error) {
+	creds, err := bootstrap.Credentials()
+	if err != nil {
+		return creds, nil, err
+	}
+	store, err := bootstrap.TokenStore()
+	if err != nil {
+		return creds, nil, err
+	}
+	if store == nil {
+		return creds, nil, fmt.Errorf("token cache disabled (ONLYOFFICE_TOKEN_CACHE=off)")
+	}
+	return creds, store, nil
+}
+
+func printTokenStatus() error {
+	creds, store, err := tokenCache()
+	if err != nil {
+		return err
+	}
+	tok, err := store.Load(onlyoffice.TokenStoreKey(creds))
+	if err != nil {
+		return err
+	}
+	out := map[string]any{
+		"portal": creds.Url,
+		"user":   creds.User,
+		"cache":  store.Path,
+		"cached": tok != nil,
+	}
+	if name, p, _, _ := bootstrap.ActiveProfile(); p != nil {
+		out["profile"] = name
+	}
+	if tok != nil {
+		exp := time.Time(tok.Expires)
+		out["expires"] = exp.Local().Format(time.RFC3339)
+		out["valid"] = exp.After(time.Now())
+	}
+	printObject(out)
+	return nil
+}
@@ -199,7 +199,7 @@ func catalogMatchCmd() *cobra.Command {
 
 func catalogApplyCmd() *cobra.Command {
 	var inPath, outPath string
-	var dryRun, doApply bool
+	var doApply bool
 	cmd := &cobra.Command{
 		Use:   "apply",
 		Short: "Create/update OO contacts for approve:true rows",
@@ -251,7 +251,6 @@ func catalogApplyCmd() *cobra.Command {
 	}
 	cmd.Flags().StringVarP(&inPath, "input", "i", "", "catalog YAML")
 	cmd.Flags().StringVarP(&outPath, "out", "O", "", "write updated catalog after apply (default: overwrite input)")
-	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "count actions without writing to OO")
 	cmd.Flags().BoolVar(&doApply, "apply", false, "perform OO creates/updates")
 	_ = cmd.MarkFlagRequired("input")
 	return cmd
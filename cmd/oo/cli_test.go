@@ -12,6 +12,7 @@ func TestRootRegistersSubjects(t *testing.T) {
 		"calendar", "projects", "tasks", "users", "whoami",
 		"contacts", "persons", "companies",
 		"opportunities", "cases", "crm-tasks", "crm", "mails", "invoices",
+		"auth", "journal", "undo", "time",
 	}
 	got := make(map[string]bool, len(rootCmd.Commands()))
 	for _, c := range rootCmd.Commands() {
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -17,6 +19,14 @@ import (
 // "table" (default, tabwriter-rendered), "json" (machine-readable).
 var outputFormat = "table"
 
+// dryRun is the value of the global --dry-run flag: mutating requests are
+// printed as a plan instead of being sent (see onlyoffice.DryRun).
+var dryRun bool
+
+// concurrency is the value of the global --concurrency flag: how many
+// requests list/dedupe fan-outs keep in flight.
+var concurrency = 4
+
 var rootCmd = &cobra.Command{
 	Use:           "oo",
 	Short:         "OnlyOffice Workspace CLI — subject-based command tree",
@@ -28,15 +38,55 @@ var rootCmd = &cobra.Command{
 
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "output format: table|json")
+	rootCmd.PersistentFlags().IntVar(&concurrency, "concurrency", 4, "max parallel requests for list/dedupe fan-out (1 = sequential)")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "print the create/update/delete requests a command would send instead of sending them")
+	rootCmd.PersistentFlags().StringVar(&bootstrap.ProfileName, "profile", "", "portal profile from the config file (default $ONLYOFFICE_PROFILE, then the file's default)")
 }
 
 // execute runs the root command. Exported only to main.go in the same package.
-func execute() error { return rootCmd.Execute() }
+// Telemetry is flushed before returning, so spans of a failed run are kept.
+func execute() (err error) {
+	ctx := context.Background()
+	shutdown, err := bootstrap.SetupTelemetry(ctx, "oo")
+	if err != nil {
+		return fmt.Errorf("telemetry: %w", err)
+	}
+	defer func() { err = errors.Join(err, shutdown(ctx)) }()
+	defer printDryRunPlan()
+	return rootCmd.ExecuteContext(ctx)
+}
 
 // newOO loads env (only .env in CWD) and returns an authenticated client.
 // godotenv is a CLI-only concern; the library itself never loads dotfiles.
 func newOO(cmd *cobra.Command) (*onlyoffice.Client, error) {
-	return bootstrap.NewClient(cmd.Context())
+	if dryRun && bootstrap.DryRun == nil {
+		bootstrap.DryRun = onlyoffice.NewDryRun()
+	}
+	c, err := bootstrap.NewClient(cmd.Context())
+	if err != nil {
+		return nil, err
+	}
+	c.SetConcurrency(concurrency)
+	return c, nil
+}
+
+// printDryRunPlan reports the requests --dry-run held back, on stderr so
+// the command's own output stays parseable.
+func printDryRunPlan() {
+	if bootstrap.DryRun == nil {
+		return
+	}
+	plan := bootstrap.DryRun.Plan()
+	if outputFormat == "json" {
+		enc := json.NewEncoder(os.Stderr)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(map[string]any{"dry_run": plan})
+		return
+	}
+	fmt.Fprintf(os.Stderr, "dry-run: %d request(s) not sent\n", len(plan))
+	for _, p := range plan {
+		fmt.Fprintf(os.Stderr, "  %s\n", p)
+	}
 }
 
 // printJSON dumps any value as indented JSON.
@@ -148,3 +148,10 @@ func clearEnv(t *testing.T, keys ...string) {
 		})
 	}
 }
+
+func TestTextToHTML(t *testing.T) {
+	got := textToHTML("a < b\nsee PR\n\nthanks")
+	if want := "<p>a &lt; b<br>see PR</p><p>thanks</p>"; got != want {
+		t.Fatalf("textToHTML=%q want %q", got, want)
+	}
+}
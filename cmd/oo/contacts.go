@@ -303,7 +303,6 @@ Omit --first/--last to keep current names (fetched first).`,
 
 func personsFixNamesCmd() *cobra.Command {
 	var companyID int
-	var dryRun bool
 	var orgHint string
 	cmd := &cobra.Command{
 		Use:   "fix-names",
@@ -392,7 +391,6 @@ With --company-id, only persons linked to that company are scanned.`,
 	}
 	cmd.Flags().IntVar(&companyID, "company-id", 0, "limit to persons of this company")
 	cmd.Flags().StringVar(&orgHint, "org", "", "org name hint for stripping suffixes")
-	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print planned renames only")
 	return cmd
 }
 
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
+	"github.com/eslider/go-onlyoffice/cmd/internal/bootstrap"
+	"github.com/spf13/cobra"
+)
+
+var journalCmd = &cobra.Command{
+	Use:   "journal",
+	Short: "Inspect the audit journal of create/update/delete requests",
+	Long: "With ONLYOFFICE_JOURNAL set (\"on\", \"sqlite\" or a file path) every mutation\n" +
+		"oo and office send is journaled with its payload, outcome and a snapshot of\n" +
+		"the affected objects taken just before the change.",
+}
+
+func init() {
+	rootCmd.AddCommand(journalCmd)
+	journalCmd.AddCommand(journalListCmd())
+	journalCmd.AddCommand(journalShowCmd())
+	rootCmd.AddCommand(undoCmd())
+}
+
+// openJournal returns the configured journal, or the default JSON Lines
+// file when journaling is off, so older entries stay readable.
+func openJournal() (onlyoffice.Journal, error) {
+	bootstrap.LoadEnv()
+	j, err := bootstrap.Journal()
+	if err != nil || j != nil {
+		return j, err
+	}
+	fmt.Fprintln(os.Stderr, "note: ONLYOFFICE_JOURNAL is off; reading the default journal file")
+	return onlyoffice.NewFileJournal("")
+}
+
+func journalListCmd() *cobra.Command {
+	var since time.Duration
+	var f onlyoffice.JournalFilter
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List journal entries, oldest first",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			j, err := openJournal()
+			if err != nil {
+				return err
+			}
+			if since > 0 {
+				f.Since = time.Now().Add(-since)
+			}
+			entries, err := j.List(f)
+			if err != nil {
+				return err
+			}
+			if outputFormat == "json" {
+				printJSON(entries)
+				return nil
+			}
+			rows := make([]map[string]any, 0, len(entries))
+			for _, e := range entries {
+				status := fmt.Sprint(e.Status)
+				if e.Error != "" && e.Status == 0 {
+					status = "error"
+				}
+				request := e.Method + " " + e.Path
+				if e.UndoOf != "" {
+					request = "of " + e.UndoOf + ": " + request
+				}
+				rows = append(rows, map[string]any{
+					"id":       e.ID,
+					"time":     e.Time.Local().Format("2006-01-02 15:04:05"),
+					"op":       e.Op,
+					"request":  request,
+					"status":   status,
+					"user":     e.User,
+					"snapshot": len(e.Snapshot),
+				})
+			}
+			printTable([]string{"id", "time", "op", "request", "status", "user", "snapshot"}, rows)
+			return nil
+		},
+	}
+	cmd.Flags().DurationVar(&since, "since", 0, "only entries newer than this (e.g. 24h)")
+	cmd.Flags().StringVar(&f.Op, "op", "", "only this operation: create|update|delete|merge|undo")
+	cmd.Flags().StringVar(&f.Path, "path", "", "only entries whose endpoint contains this text (e.g. crm/contact)")
+	cmd.Flags().IntVar(&f.Limit, "limit", 50, "show at most this many of the newest entries (0 = all)")
+	return cmd
+}
+
+func journalShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show ID",
+		Short: "Show one journal entry with its payload, response and snapshot",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			j, err := openJournal()
+			if err != nil {
+				return err
+			}
+			e, err := j.Get(args[0])
+			if err != nil {
+				return err
+			}
+			printJSON(e)
+			return nil
+		},
+	}
+}
+
+func undoCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "undo JOURNAL_ID",
+		Short: "Reverse a journaled CRM delete, merge, update or tag/member removal",
+		Long: "Recreates deleted contacts and deals from the journal snapshot (with contact info,\n" +
+			"addresses, tags, deal members and company persons), re-adds removed tags, members\n" +
+			"and contact info, and writes updated contact/deal fields back. Recreated objects get\n" +
+			"new ids. What cannot be restored (history, files, tasks moved by a merge) is listed.\n" +
+			"The undo is journaled as an \"undo\" entry, and an entry that already has one is\n" +
+			"refused. Combine with --dry-run to see the requests first.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			j, err := openJournal()
+			if err != nil {
+				return err
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			res, err := c.UndoFrom(cmd.Context(), j, args[0])
+			if res != nil {
+				printUndoResult(res)
+			}
+			if err == nil && len(res.Errors) > 0 {
+				err = fmt.Errorf("undo %s: %d step(s) failed", args[0], len(res.Errors))
+			}
+			return err
+		},
+	}
+}
+
+func printUndoResult(res *onlyoffice.UndoResult) {
+	if outputFormat == "json" {
+		printJSON(res)
+		return
+	}
+	for _, sec := range []struct {
+		title string
+		lines []string
+	}{{"restored", res.Restored}, {"not restored", res.Lost}, {"failed", res.Errors}} {
+		if len(sec.lines) == 0 {
+			continue
+		}
+		fmt.Println(sec.title + ":")
+		for _, l := range sec.lines {
+			fmt.Println("  " + l)
+		}
+	}
+}
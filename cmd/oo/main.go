@@ -3,8 +3,8 @@
 // Command tree is subject-based (mirrors the library split and the `tea` CLI):
 //
 //	oo calendar      list | events | add | delete
-//	oo projects      list | get | milestones | create | update | delete | files (list|upload|download|rename|delete)
-//	oo tasks         list | get | create | update | delete | subtask add | files (list|upload|detach)
+//	oo projects      list | get | milestones | create | update | delete | critical-path | milestone-update | milestone-close | milestone-tasks | milestone-move | files (list|upload|download|rename|delete)
+//	oo tasks         list | get | create | update | delete | subtask (add|list|close|reopen|update|delete) | link | unlink | files (list|upload|detach) | comment (list|add|update|delete)
 //	oo users         list | self            (alias: oo whoami)
 //	oo contacts      list | get | delete | info-add | merge | dedupe-info
 //	oo persons       list | create | delete | dedupe
@@ -15,10 +15,19 @@
 //	oo crm           cleanup
 //	oo mails         accounts | folders | list | get | download-attachment | draft | attach | draft-invoice | delete
 //	oo invoices      list | get | create | update | pdf | pdf-cleanup | status | delete | items …
+//	oo time          log | list | report | status | delete
+//	oo journal       list | show
+//	oo undo          JOURNAL_ID
 //
 // CRM association rules: docs/crm-associations.md
 //
 // Every list supports `--output/-o json|table` (table is the default).
+// `--dry-run` prints the create/update/delete requests a command would send
+// instead of sending them.
+//
+// `--profile NAME` selects a portal from ~/.config/oo/config.yaml (see
+// bootstrap.Config); without it ONLYOFFICE_PROFILE, then the file's default
+// profile, then plain ONLYOFFICE_* env apply.
 //
 // Build & install:
 //
@@ -26,13 +35,36 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
 )
 
 func main() {
 	if err := execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
+		if hint := errorHint(err); hint != "" {
+			fmt.Fprintln(os.Stderr, "hint:", hint)
+		}
 		os.Exit(1)
 	}
 }
+
+// errorHint suggests a next step for well-known portal failures.
+func errorHint(err error) string {
+	switch {
+	case errors.Is(err, onlyoffice.ErrTFARequired):
+		return "the account uses two-factor auth; set ONLYOFFICE_TFA_CODE or run `oo auth login` in a terminal"
+	case errors.Is(err, onlyoffice.ErrUnauthorized):
+		return "check ONLYOFFICE_USER / ONLYOFFICE_PASS (or OO_USER / OO_PASS in .env, or the --profile entry in the config file)"
+	case errors.Is(err, onlyoffice.ErrForbidden):
+		return "the portal user lacks permission for this module"
+	case errors.Is(err, onlyoffice.ErrRateLimited):
+		return "the portal is throttling requests; retry later"
+	case errors.Is(err, onlyoffice.ErrNotFound):
+		return "the referenced id does not exist (or is not visible to this user)"
+	}
+	return ""
+}
@@ -39,7 +39,7 @@ func prjListCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			list, err := c.GetProjects()
+			list, err := c.GetProjectsContext(cmd.Context())
 			if err != nil {
 				return err
 			}
@@ -102,19 +102,11 @@ func prjMilestonesCmd() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("project id must be integer: %w", err)
 			}
-			ms, err := c.GetProjectMilestones(&onlyoffice.Project{ID: &pid})
+			ms, err := c.GetProjectMilestonesContext(cmd.Context(), &onlyoffice.Project{ID: &pid})
 			if err != nil {
 				return err
 			}
-			rows := make([]map[string]any, 0, len(ms))
-			for _, m := range ms {
-				rows = append(rows, map[string]any{
-					"id":     derefInt64(m.ID),
-					"title":  derefString(m.Title),
-					"status": derefInt64(m.Status),
-				})
-			}
-			printTable([]string{"id", "title", "status"}, rows)
+			printTable(milestoneHeaders, milestoneRows(ms...))
 			return nil
 		},
 	}
@@ -143,7 +135,7 @@ func prjMilestoneCreateCmd() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("deadline: %w", err)
 			}
-			ms, err := c.CreateMilestone(onlyoffice.NewMilestoneRequest{
+			ms, err := c.CreateMilestoneContext(cmd.Context(), onlyoffice.NewMilestoneRequest{
 				ProjectID:   pid,
 				Title:       args[1],
 				Deadline:    onlyoffice.Time(day),
@@ -192,7 +184,7 @@ full title is composed as "CC | Company | Title".`,
 			if country != "" || company != "" {
 				title = onlyoffice.FormatProjectTitle(country, company, args[0])
 			}
-			p, err := c.CreateProject(onlyoffice.NewProjectRequest{
+			p, err := c.CreateProjectContext(cmd.Context(), onlyoffice.NewProjectRequest{
 				Title:         title,
 				Description:   desc,
 				ResponsibleID: resp,
@@ -248,7 +240,7 @@ func prjUpdateCmd() *cobra.Command {
 					desc = strings.TrimSpace(fmt.Sprint(cur["description"]))
 				}
 			}
-			p, err := c.UpdateProject(onlyoffice.ProjectUpdateRequest{
+			p, err := c.UpdateProjectContext(cmd.Context(), onlyoffice.ProjectUpdateRequest{
 				ID:            id,
 				Title:         title,
 				Description:   desc,
@@ -291,7 +283,7 @@ func prjDeleteCmd() *cobra.Command {
 				if err != nil {
 					return fmt.Errorf("project id %q must be integer: %w", raw, err)
 				}
-				p, err := c.DeleteProject(id)
+				p, err := c.DeleteProjectContext(cmd.Context(), id)
 				if err != nil {
 					return err
 				}
@@ -421,7 +413,6 @@ func prjContactsRemoveCmd() *cobra.Command {
 func prjContactsLinkGitCmd() *cobra.Command {
 	var gitRoot string
 	var minCommits int
-	var dryRun bool
 	var companyIDs []int
 	cmd := &cobra.Command{
 		Use:   "link-git PROJECT_ID",
@@ -457,14 +448,12 @@ Also links --company-id contacts (employer and/or client company).`,
 	cmd.Flags().StringVar(&gitRoot, "git-root", "", "local git repository path")
 	cmd.Flags().IntVar(&minCommits, "min-commits", 3, "ignore authors below this commit count")
 	cmd.Flags().IntSliceVar(&companyIDs, "company-id", nil, "CRM company contact ids to link")
-	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print planned links only")
 	return cmd
 }
 
 func prjContactsLinkAuthorsCmd() *cobra.Command {
 	var authorsFile string
 	var minCommits int
-	var dryRun bool
 	var companyIDs []int
 	cmd := &cobra.Command{
 		Use:   "link-authors PROJECT_ID",
@@ -501,7 +490,6 @@ Then: oo projects contacts link-authors 59 --from authors.txt --company-id COMPA
 	cmd.Flags().StringVar(&authorsFile, "from", "", "path to git shortlog -sne output")
 	cmd.Flags().IntVar(&minCommits, "min-commits", 3, "ignore authors below this commit count")
 	cmd.Flags().IntSliceVar(&companyIDs, "company-id", nil, "CRM company contact ids to link")
-	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print planned links only")
 	return cmd
 }
 
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	projectsCmd.AddCommand(prjMilestoneUpdateCmd())
+	projectsCmd.AddCommand(prjMilestoneCloseCmd())
+	projectsCmd.AddCommand(prjMilestoneTasksCmd())
+	projectsCmd.AddCommand(prjMilestoneMoveCmd())
+}
+
+func prjMilestoneUpdateCmd() *cobra.Command {
+	var title, deadline, desc, responsible string
+	var key, notify bool
+	cmd := &cobra.Command{
+		Use:   "milestone-update MILESTONE_ID",
+		Short: "Change a milestone's title, deadline, description, key flag or responsible",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("milestone id must be integer: %w", err)
+			}
+			u := onlyoffice.MilestoneUpdate{Title: title, ResponsibleID: responsible}
+			if deadline != "" {
+				if u.Deadline, err = time.Parse("2006-01-02", deadline); err != nil {
+					return fmt.Errorf("deadline: %w", err)
+				}
+			}
+			if cmd.Flags().Changed("description") {
+				u.Description = &desc
+			}
+			if cmd.Flags().Changed("key") {
+				u.IsKey = &key
+			}
+			if cmd.Flags().Changed("notify") {
+				u.IsNotify = &notify
+			}
+			if u == (onlyoffice.MilestoneUpdate{}) {
+				return fmt.Errorf("nothing to update: pass --title, --deadline, --description, --key, --notify or --responsible")
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			if u.ResponsibleID == "me" {
+				if u.ResponsibleID, err = c.SelfUserID(cmd.Context()); err != nil {
+					return err
+				}
+			}
+			ms, err := c.UpdateMilestone(cmd.Context(), id, u)
+			if err != nil {
+				return err
+			}
+			printMilestones(ms)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&title, "title", "", "new title")
+	cmd.Flags().StringVar(&deadline, "deadline", "", "new deadline YYYY-MM-DD")
+	cmd.Flags().StringVar(&desc, "description", "", "new description (\"\" clears it)")
+	cmd.Flags().BoolVar(&key, "key", false, "key milestone (--key=false unmarks)")
+	cmd.Flags().BoolVar(&notify, "notify", false, "remind the responsible before the deadline")
+	cmd.Flags().StringVar(&responsible, "responsible", "", "user id, or \"me\"")
+	return cmd
+}
+
+func prjMilestoneCloseCmd() *cobra.Command {
+	var reopen bool
+	cmd := &cobra.Command{
+		Use:   "milestone-close MILESTONE_ID [MILESTONE_ID...]",
+		Short: "Close milestones (all their tasks must be closed), or reopen them",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			status := onlyoffice.MilestoneClosed
+			if reopen {
+				status = onlyoffice.MilestoneOpen
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			var out []*onlyoffice.Milestone
+			for _, a := range args {
+				id, err := strconv.ParseInt(a, 10, 64)
+				if err != nil {
+					return fmt.Errorf("milestone id must be integer: %w", err)
+				}
+				ms, err := c.SetMilestoneStatus(cmd.Context(), id, status)
+				if err != nil {
+					return fmt.Errorf("milestone %d: %w", id, err)
+				}
+				out = append(out, ms)
+			}
+			printMilestones(out...)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&reopen, "reopen", false, "reopen instead of closing")
+	return cmd
+}
+
+func prjMilestoneTasksCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "milestone-tasks MILESTONE_ID",
+		Short: "List the tasks planned for a milestone",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("milestone id must be integer: %w", err)
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			tasks, err := c.ListMilestoneTasks(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+			if outputFormat == "json" {
+				printJSON(tasks)
+				return nil
+			}
+			printTable([]string{"id", "title", "status", "deadline", "responsible"}, milestoneTaskRows(tasks))
+			return nil
+		},
+	}
+}
+
+func prjMilestoneMoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "milestone-move MILESTONE_ID TASK_ID [TASK_ID...]",
+		Short: "Plan tasks for a milestone (MILESTONE_ID 0 takes them out of theirs)",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mid, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("milestone id must be integer: %w", err)
+			}
+			ids, err := atoiAll(args[1:])
+			if err != nil {
+				return fmt.Errorf("task id: %w", err)
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			var tasks []*onlyoffice.Task
+			for _, id := range ids {
+				t, err := c.MoveTaskToMilestone(cmd.Context(), id, mid)
+				if err != nil {
+					return fmt.Errorf("task %d: %w", id, err)
+				}
+				tasks = append(tasks, t)
+			}
+			if outputFormat == "json" {
+				printJSON(tasks)
+				return nil
+			}
+			printTable([]string{"id", "title", "status", "deadline", "responsible"}, milestoneTaskRows(tasks))
+			return nil
+		},
+	}
+}
+
+var milestoneHeaders = []string{"id", "title", "status", "deadline", "open", "closed", "key"}
+
+func milestoneRows(ms ...*onlyoffice.Milestone) []map[string]any {
+	rows := make([]map[string]any, 0, len(ms))
+	for _, m := range ms {
+		status, deadline, key := "open", "", ""
+		if onlyoffice.MilestoneStatus(derefInt64(m.Status)) == onlyoffice.MilestoneClosed {
+			status = "closed"
+		}
+		if m.Deadline != nil {
+			deadline = m.Deadline.Format("2006-01-02")
+		}
+		if derefBool(m.IsKey) {
+			key = "*"
+		}
+		rows = append(rows, map[string]any{
+			"id":       derefInt64(m.ID),
+			"title":    derefString(m.Title),
+			"status":   status,
+			"deadline": deadline,
+			"open":     derefInt64(m.ActiveTaskCount),
+			"closed":   derefInt64(m.ClosedTaskCount),
+			"key":      key,
+		})
+	}
+	return rows
+}
+
+func printMilestones(ms ...*onlyoffice.Milestone) {
+	if outputFormat == "json" {
+		printJSON(ms)
+		return
+	}
+	printTable(milestoneHeaders, milestoneRows(ms...))
+}
+
+func milestoneTaskRows(tasks []*onlyoffice.Task) []map[string]any {
+	rows := make([]map[string]any, 0, len(tasks))
+	for _, t := range tasks {
+		status, deadline, resp := "", "", ""
+		if t.Status != nil {
+			switch *t.Status {
+			case onlyoffice.ProjectTaskStatusOpen:
+				status = "open"
+			case onlyoffice.ProjectTaskStatusClosed:
+				status = "closed"
+			default:
+				status = strconv.Itoa(int(*t.Status))
+			}
+		}
+		if t.Deadline != nil {
+			deadline = t.Deadline.Format("2006-01-02")
+		}
+		if len(t.Responsibles) > 0 && t.Responsibles[0] != nil {
+			resp = derefString(t.Responsibles[0].DisplayName)
+		}
+		rows = append(rows, map[string]any{
+			"id":          derefInt(t.ID),
+			"title":       derefString(t.Title),
+			"status":      status,
+			"deadline":    deadline,
+			"responsible": resp,
+		})
+	}
+	return rows
+}
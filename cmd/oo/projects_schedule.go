@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	projectsCmd.AddCommand(prjCriticalPathCmd())
+}
+
+func prjCriticalPathCmd() *cobra.Command {
+	var all bool
+	cmd := &cobra.Command{
+		Use:   "critical-path PROJECT_ID",
+		Short: "Schedule a project's tasks along their links and show the critical path",
+		Long: `Computes earliest/latest dates and slack of every task from its start
+date, deadline and Gantt links. Tasks with zero slack form the critical path:
+any delay on them moves the project finish. Closed tasks are left out unless
+--all is given.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pid, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("project id must be integer: %w", err)
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			req := onlyoffice.NewProjectGetTasksRequest(pid)
+			req.Simple = false // the simple wrapper carries no links
+			tasks, err := c.GetTasksContext(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+			if !all {
+				open := tasks[:0]
+				for _, t := range tasks {
+					if t.Status == nil || *t.Status != onlyoffice.ProjectTaskStatusClosed {
+						open = append(open, t)
+					}
+				}
+				tasks = open
+			}
+			s, err := onlyoffice.ScheduleTasks(tasks)
+			if err != nil {
+				return err
+			}
+			if outputFormat == "json" {
+				printJSON(s)
+				return nil
+			}
+			printTable([]string{"id", "title", "start", "finish", "days", "slack", "critical", "late"}, scheduleRows(s))
+			path := make([]string, len(s.CriticalPath))
+			for i, id := range s.CriticalPath {
+				path[i] = strconv.Itoa(id)
+			}
+			fmt.Printf("\n%s .. %s (%d days)\ncritical: %s\n", s.Start.Format("2006-01-02"), s.Finish.Format("2006-01-02"),
+				int(s.Finish.Sub(s.Start).Hours()/24)+1, strings.Join(path, ", "))
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "include closed tasks")
+	return cmd
+}
+
+func scheduleRows(s *onlyoffice.Schedule) []map[string]any {
+	rows := make([]map[string]any, 0, len(s.Tasks))
+	for _, t := range s.Tasks {
+		crit, late := "", ""
+		if t.Critical {
+			crit = "*"
+		}
+		if t.Delay > 0 {
+			late = fmt.Sprintf("+%dd", t.Delay)
+		}
+		rows = append(rows, map[string]any{
+			"id":       t.TaskID,
+			"title":    t.Title,
+			"start":    t.EarlyStart.Format("2006-01-02"),
+			"finish":   t.EarlyFinish.Format("2006-01-02"),
+			"days":     t.Duration,
+			"slack":    t.Slack,
+			"critical": crit,
+			"late":     late,
+		})
+	}
+	return rows
+}
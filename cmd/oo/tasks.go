@@ -140,7 +140,7 @@ func taskCreateCmd() *cobra.Command {
 				} else {
 					req.StartDate = onlyoffice.Time(time.Now())
 				}
-				task, err := c.CreateProjectTask(req)
+				task, err := c.CreateProjectTaskContext(cmd.Context(), req)
 				if err != nil {
 					return err
 				}
@@ -216,32 +216,18 @@ func taskDeleteCmd() *cobra.Command {
 	}
 }
 
-// subtaskCmd exposes `oo tasks subtask {add}` — keeps things tidy without a
-// dedicated subtasks top-level command.
+// subtaskCmd exposes `oo tasks subtask {add,list,close,reopen,update,delete}`
+// — keeps things tidy without a dedicated subtasks top-level command.
 func subtaskCmd() *cobra.Command {
 	sub := &cobra.Command{
 		Use:   "subtask",
 		Short: "Subtasks of a parent task",
 	}
-	sub.AddCommand(&cobra.Command{
-		Use:   "add PARENT_TASK_ID TITLE [TITLE...]",
-		Short: "Add one or more subtasks",
-		Args:  cobra.MinimumNArgs(2),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			c, err := newOO(cmd)
-			if err != nil {
-				return err
-			}
-			parent := args[0]
-			for _, title := range args[1:] {
-				out, err := c.AddSubtask(cmd.Context(), parent, title)
-				if err != nil {
-					return err
-				}
-				printObject(out)
-			}
-			return nil
-		},
-	})
+	sub.AddCommand(subtaskAddCmd())
+	sub.AddCommand(subtaskListCmd())
+	sub.AddCommand(subtaskStatusCmd("close", "Tick subtasks off", onlyoffice.ProjectTaskStatusClosed))
+	sub.AddCommand(subtaskStatusCmd("reopen", "Reopen closed subtasks", onlyoffice.ProjectTaskStatusOpen))
+	sub.AddCommand(subtaskUpdateCmd())
+	sub.AddCommand(subtaskDeleteCmd())
 	return sub
 }
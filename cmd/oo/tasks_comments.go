@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
+	"github.com/eslider/go-onlyoffice/cmd/office/preview"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	tasksCmd.AddCommand(taskCommentCmd())
+}
+
+func taskCommentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "comment",
+		Aliases: []string{"comments"},
+		Short:   "Task comments: list, add (or reply), update, delete",
+	}
+	cmd.AddCommand(taskCommentListCmd())
+	cmd.AddCommand(taskCommentAddCmd())
+	cmd.AddCommand(taskCommentUpdateCmd())
+	cmd.AddCommand(taskCommentDeleteCmd())
+	return cmd
+}
+
+func taskCommentListCmd() *cobra.Command {
+	var thread bool
+	cmd := &cobra.Command{
+		Use:   "list TASK_ID",
+		Short: "List the comments on a task",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("task id: %w", err)
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			comments, err := c.ListTaskComments(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+			switch {
+			case outputFormat == "json":
+				printJSON(comments)
+			case thread:
+				fmt.Print(preview.CommentsMarkdown(comments))
+			default:
+				printTable([]string{"id", "parent", "author", "created", "text"}, commentRows(comments))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&thread, "thread", false, "print the thread as markdown, replies quoted under their parent")
+	return cmd
+}
+
+func commentRows(comments []onlyoffice.Comment) []map[string]any {
+	ordered, _ := onlyoffice.ThreadComments(comments)
+	rows := make([]map[string]any, 0, len(ordered))
+	for _, c := range ordered {
+		created := ""
+		if c.Created != nil {
+			created = time.Time(*c.Created).Format("2006-01-02 15:04")
+		}
+		text := html.UnescapeString(preview.CommentMarkdown(c.Text))
+		if c.Inactive {
+			text = "(deleted)"
+		}
+		rows = append(rows, map[string]any{
+			"id":      c.ID,
+			"parent":  c.ParentID,
+			"author":  c.AuthorName(),
+			"created": created,
+			"text":    text,
+		})
+	}
+	return rows
+}
+
+func taskCommentAddCmd() *cobra.Command {
+	var replyTo string
+	var raw bool
+	cmd := &cobra.Command{
+		Use:   "add TASK_ID TEXT|-",
+		Short: "Comment on a task (TEXT \"-\" reads stdin)",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("task id: %w", err)
+			}
+			content, err := commentContent(args[1], raw)
+			if err != nil {
+				return err
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			out, err := c.AddTaskComment(cmd.Context(), id, content, replyTo)
+			if err != nil {
+				return err
+			}
+			printCommentResult(out)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&replyTo, "reply-to", "", "comment id to answer")
+	cmd.Flags().BoolVar(&raw, "html", false, "TEXT is HTML; send it unchanged")
+	return cmd
+}
+
+func taskCommentUpdateCmd() *cobra.Command {
+	var raw bool
+	cmd := &cobra.Command{
+		Use:   "update COMMENT_ID TEXT|-",
+		Short: "Replace the text of a comment",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			content, err := commentContent(args[1], raw)
+			if err != nil {
+				return err
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			out, err := c.UpdateComment(cmd.Context(), args[0], content)
+			if err != nil {
+				return err
+			}
+			printCommentResult(out)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&raw, "html", false, "TEXT is HTML; send it unchanged")
+	return cmd
+}
+
+func taskCommentDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete COMMENT_ID [COMMENT_ID...]",
+		Aliases: []string{"rm"},
+		Short:   "Delete comments",
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			for _, id := range args {
+				if err := c.DeleteComment(cmd.Context(), id); err != nil {
+					return err
+				}
+				fmt.Println("deleted", id)
+			}
+			return nil
+		},
+	}
+}
+
+func printCommentResult(c *onlyoffice.Comment) {
+	if outputFormat == "json" {
+		printJSON(c)
+		return
+	}
+	printTable([]string{"id", "parent", "author", "created", "text"}, commentRows([]onlyoffice.Comment{*c}))
+}
+
+// commentContent reads the comment text ("-" = stdin) and, unless it is
+// already HTML, turns it into paragraphs the portal editor displays.
+func commentContent(arg string, isHTML bool) (string, error) {
+	text := arg
+	if arg == "-" {
+		b, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", err
+		}
+		text = string(b)
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", fmt.Errorf("comment text is empty")
+	}
+	if isHTML {
+		return text, nil
+	}
+	return textToHTML(text), nil
+}
+
+// textToHTML escapes plain text and keeps its paragraphs and line breaks.
+func textToHTML(text string) string {
+	var b strings.Builder
+	for _, para := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		if para = strings.TrimSpace(para); para == "" {
+			continue
+		}
+		lines := strings.Split(para, "\n")
+		for i := range lines {
+			lines[i] = html.EscapeString(lines[i])
+		}
+		b.WriteString("<p>" + strings.Join(lines, "<br>") + "</p>")
+	}
+	return b.String()
+}
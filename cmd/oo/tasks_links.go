@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	tasksCmd.AddCommand(taskLinkCmd())
+	tasksCmd.AddCommand(taskUnlinkCmd())
+}
+
+func taskLinkCmd() *cobra.Command {
+	var typ string
+	cmd := &cobra.Command{
+		Use:   "link PARENT_TASK_ID DEPENDENT_TASK_ID",
+		Short: "Make a task depend on another (Gantt link)",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids, err := atoiAll(args)
+			if err != nil {
+				return fmt.Errorf("task id: %w", err)
+			}
+			t, err := onlyoffice.ParseTaskLinkType(typ)
+			if err != nil {
+				return err
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			out, err := c.LinkTasks(cmd.Context(), ids[0], ids[1], t)
+			if err != nil {
+				return err
+			}
+			printTaskLinks(out)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&typ, "type", "t", "end-start", "end-start|start-start|end-end|start-end")
+	return cmd
+}
+
+func taskUnlinkCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unlink PARENT_TASK_ID DEPENDENT_TASK_ID",
+		Short: "Remove a dependency between two tasks",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids, err := atoiAll(args)
+			if err != nil {
+				return fmt.Errorf("task id: %w", err)
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			if err := c.UnlinkTasks(cmd.Context(), ids[0], ids[1]); err != nil {
+				return err
+			}
+			fmt.Printf("unlinked %d -> %d\n", ids[0], ids[1])
+			return nil
+		},
+	}
+}
+
+func printTaskLinks(t *onlyoffice.Task) {
+	if outputFormat == "json" {
+		printJSON(t)
+		return
+	}
+	rows := make([]map[string]any, 0, len(t.Links))
+	for _, l := range t.Links {
+		rows = append(rows, map[string]any{
+			"parent":    l.ParentTaskID.String(),
+			"dependent": l.DependentTaskID.String(),
+			"type":      l.Type.String(),
+		})
+	}
+	printTable([]string{"parent", "dependent", "type"}, rows)
+}
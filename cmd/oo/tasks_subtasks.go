@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
+	"github.com/spf13/cobra"
+)
+
+func subtaskAddCmd() *cobra.Command {
+	var responsible string
+	cmd := &cobra.Command{
+		Use:   "add PARENT_TASK_ID TITLE [TITLE...]",
+		Short: "Add one or more subtasks",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids, err := atoiAll(args[:1])
+			if err != nil {
+				return fmt.Errorf("task id: %w", err)
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			if responsible == "me" {
+				if responsible, err = c.SelfUserID(cmd.Context()); err != nil {
+					return err
+				}
+			}
+			var out []onlyoffice.Subtask
+			for _, title := range args[1:] {
+				s, err := c.CreateSubtask(cmd.Context(), ids[0], title, responsible)
+				if err != nil {
+					return err
+				}
+				out = append(out, *s)
+			}
+			printSubtasks(out...)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&responsible, "responsible", "", "user id to assign, or \"me\"")
+	return cmd
+}
+
+func subtaskListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list TASK_ID",
+		Aliases: []string{"ls"},
+		Short:   "List the subtasks of a task as a checklist",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids, err := atoiAll(args)
+			if err != nil {
+				return fmt.Errorf("task id: %w", err)
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			subs, err := c.ListSubtasks(cmd.Context(), ids[0])
+			if err != nil {
+				return err
+			}
+			if outputFormat == "json" {
+				printJSON(subs)
+				return nil
+			}
+			printTable(subtaskHeaders, subtaskRows(subs...))
+			return nil
+		},
+	}
+}
+
+// subtaskStatusCmd builds `close` (done) and `reopen`.
+func subtaskStatusCmd(use, short string, status onlyoffice.ProjectTaskStatus) *cobra.Command {
+	return &cobra.Command{
+		Use:   use + " TASK_ID SUBTASK_ID [SUBTASK_ID...]",
+		Short: short,
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids, err := atoiAll(args)
+			if err != nil {
+				return err
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			var out []onlyoffice.Subtask
+			for _, sid := range ids[1:] {
+				s, err := c.SetSubtaskStatus(cmd.Context(), ids[0], sid, status)
+				if err != nil {
+					return err
+				}
+				out = append(out, *s)
+			}
+			printSubtasks(out...)
+			return nil
+		},
+	}
+}
+
+func subtaskUpdateCmd() *cobra.Command {
+	var title, responsible string
+	cmd := &cobra.Command{
+		Use:   "update TASK_ID SUBTASK_ID",
+		Short: "Rename or reassign a subtask",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if title == "" && responsible == "" {
+				return fmt.Errorf("nothing to update: pass --title and/or --responsible")
+			}
+			ids, err := atoiAll(args)
+			if err != nil {
+				return err
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			if responsible == "me" {
+				if responsible, err = c.SelfUserID(cmd.Context()); err != nil {
+					return err
+				}
+			}
+			s, err := c.UpdateSubtask(cmd.Context(), ids[0], ids[1], title, responsible)
+			if err != nil {
+				return err
+			}
+			printSubtasks(*s)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&title, "title", "", "new title")
+	cmd.Flags().StringVar(&responsible, "responsible", "", "user id to assign, or \"me\"")
+	return cmd
+}
+
+func subtaskDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete TASK_ID SUBTASK_ID [SUBTASK_ID...]",
+		Aliases: []string{"rm"},
+		Short:   "Delete subtasks",
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids, err := atoiAll(args)
+			if err != nil {
+				return err
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			for _, sid := range ids[1:] {
+				if err := c.DeleteSubtask(cmd.Context(), ids[0], sid); err != nil {
+					return err
+				}
+				fmt.Println("deleted", sid)
+			}
+			return nil
+		},
+	}
+}
+
+var subtaskHeaders = []string{"id", "done", "title", "responsible", "updated"}
+
+func subtaskRows(subs ...onlyoffice.Subtask) []map[string]any {
+	rows := make([]map[string]any, 0, len(subs))
+	for _, s := range subs {
+		done, updated := "[ ]", ""
+		if s.Done() {
+			done = "[x]"
+		}
+		if s.Updated != nil {
+			updated = time.Time(*s.Updated).Format("2006-01-02 15:04")
+		}
+		rows = append(rows, map[string]any{
+			"id":          s.ID.String(),
+			"done":        done,
+			"title":       s.Title,
+			"responsible": s.ResponsibleName(),
+			"updated":     updated,
+		})
+	}
+	return rows
+}
+
+func printSubtasks(subs ...onlyoffice.Subtask) {
+	if outputFormat == "json" {
+		printJSON(subs)
+		return
+	}
+	printTable(subtaskHeaders, subtaskRows(subs...))
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
+	"github.com/spf13/cobra"
+)
+
+var timeCmd = &cobra.Command{
+	Use:   "time",
+	Short: "Time tracking on project tasks",
+}
+
+func init() {
+	rootCmd.AddCommand(timeCmd)
+	timeCmd.AddCommand(timeLogCmd())
+	timeCmd.AddCommand(timeListCmd())
+	timeCmd.AddCommand(timeReportCmd())
+	timeCmd.AddCommand(timeStatusCmd())
+	timeCmd.AddCommand(timeDeleteCmd())
+}
+
+func timeLogCmd() *cobra.Command {
+	var e onlyoffice.NewTimeEntry
+	var date string
+	cmd := &cobra.Command{
+		Use:   "log TASK_ID",
+		Short: "Log hours spent on a task",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			task, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("task id: %w", err)
+			}
+			if date != "" {
+				if e.Date, err = time.Parse("2006-01-02", date); err != nil {
+					return fmt.Errorf("date: %w", err)
+				}
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			out, err := c.LogTime(cmd.Context(), task, e)
+			if err != nil {
+				return err
+			}
+			if outputFormat == "json" {
+				printJSON(out)
+				return nil
+			}
+			printTable(timeHeaders, timeRows([]onlyoffice.TimeEntry{*out}))
+			return nil
+		},
+	}
+	cmd.Flags().Float64Var(&e.Hours, "hours", 0, "hours spent (e.g. 1.5)")
+	cmd.Flags().StringVar(&date, "date", "", "day of the work YYYY-MM-DD (default today)")
+	cmd.Flags().StringVar(&e.Note, "note", "", "what was done")
+	cmd.Flags().StringVar(&e.PersonID, "person", "", "user id who did the work (default: you)")
+	_ = cmd.MarkFlagRequired("hours")
+	return cmd
+}
+
+// timeFilterFlags registers the filters shared by list and report.
+func timeFilterFlags(cmd *cobra.Command, f *timeFilterArgs) {
+	cmd.Flags().IntVarP(&f.project, "project", "p", 0, "only this project id")
+	cmd.Flags().IntVar(&f.task, "task", 0, "only this task id")
+	cmd.Flags().StringVar(&f.person, "person", "", "only this user id (\"me\" = you)")
+	cmd.Flags().StringVar(&f.from, "from", "", "first day YYYY-MM-DD")
+	cmd.Flags().StringVar(&f.to, "to", "", "last day YYYY-MM-DD")
+	cmd.Flags().StringVar(&f.month, "month", "", "calendar month YYYY-MM (sets --from/--to)")
+	cmd.Flags().StringVar(&f.status, "status", "", "not-chargeable|billable|paid")
+}
+
+type timeFilterArgs struct {
+	project, task                   int
+	person, from, to, month, status string
+}
+
+// load fetches the matching entries. --task reads the task's own list;
+// everything else goes through the portal filter.
+func (a timeFilterArgs) load(cmd *cobra.Command, c *onlyoffice.Client) ([]onlyoffice.TimeEntry, error) {
+	var f onlyoffice.TimeFilter
+	f.ProjectID = a.project
+	var err error
+	if a.month != "" {
+		if f.From, err = time.Parse("2006-01", a.month); err != nil {
+			return nil, fmt.Errorf("month: %w", err)
+		}
+		f.To = f.From.AddDate(0, 1, -1)
+	}
+	if a.from != "" {
+		if f.From, err = time.Parse("2006-01-02", a.from); err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+	}
+	if a.to != "" {
+		if f.To, err = time.Parse("2006-01-02", a.to); err != nil {
+			return nil, fmt.Errorf("to: %w", err)
+		}
+	}
+	if !f.To.IsZero() {
+		f.To = f.To.Add(24*time.Hour - time.Second)
+	}
+	if a.status != "" {
+		s, err := onlyoffice.ParsePaymentStatus(a.status)
+		if err != nil {
+			return nil, err
+		}
+		f.Status = &s
+	}
+	f.PersonID = a.person
+	if a.person == "me" {
+		if f.PersonID, err = c.SelfUserID(cmd.Context()); err != nil {
+			return nil, err
+		}
+	}
+	if a.task == 0 {
+		return c.ListTime(cmd.Context(), f)
+	}
+	all, err := c.ListTaskTime(cmd.Context(), a.task)
+	if err != nil {
+		return nil, err
+	}
+	var out []onlyoffice.TimeEntry
+	for _, e := range all {
+		if f.Status != nil && e.PaymentStatus != *f.Status {
+			continue
+		}
+		if f.PersonID != "" && (e.Person == nil || e.Person.ID == nil || *e.Person.ID != f.PersonID) {
+			continue
+		}
+		if e.Date != nil && !inDays(time.Time(*e.Date), f.From, f.To) {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// inDays reports whether t falls on a calendar day from from through to,
+// taking t's day in its own zone: an entry dated 2026-10-01T00:00:00+02:00
+// belongs to October 1st whatever instant the UTC bounds fall on. Zero
+// bounds are open.
+func inDays(t, from, to time.Time) bool {
+	const day = "2006-01-02"
+	d := t.Format(day)
+	return (from.IsZero() || d >= from.Format(day)) && (to.IsZero() || d <= to.Format(day))
+}
+
+var timeHeaders = []string{"id", "date", "person", "project", "task", "hours", "status", "note"}
+
+func timeRows(entries []onlyoffice.TimeEntry) []map[string]any {
+	rows := make([]map[string]any, 0, len(entries))
+	for _, e := range entries {
+		date := ""
+		if e.Date != nil {
+			date = time.Time(*e.Date).Format("2006-01-02")
+		}
+		task := e.TaskID.String()
+		if e.TaskTitle != "" {
+			task += " " + e.TaskTitle
+		}
+		rows = append(rows, map[string]any{
+			"id":      e.ID.String(),
+			"date":    date,
+			"person":  e.PersonName(),
+			"project": e.ProjectID.String(),
+			"task":    task,
+			"hours":   float64(e.Hours),
+			"status":  e.PaymentStatus.String(),
+			"note":    e.Note,
+		})
+	}
+	return rows
+}
+
+func timeListCmd() *cobra.Command {
+	var f timeFilterArgs
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List logged time",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			entries, err := f.load(cmd, c)
+			if err != nil {
+				return err
+			}
+			if outputFormat == "json" {
+				printJSON(entries)
+				return nil
+			}
+			printTable(timeHeaders, timeRows(entries))
+			return nil
+		},
+	}
+	timeFilterFlags(cmd, &f)
+	return cmd
+}
+
+func timeReportCmd() *cobra.Command {
+	var f timeFilterArgs
+	var by string
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Sum logged hours per person, project, task, week, month or status",
+		Example: "  oo time report --month 2026-10 --by project,person\n" +
+			"  oo time report --project 12 --status billable --by week",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groups, err := onlyoffice.ParseTimeGroups(by)
+			if err != nil {
+				return err
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			entries, err := f.load(cmd, c)
+			if err != nil {
+				return err
+			}
+			report := onlyoffice.TimeReport(entries, groups...)
+			if outputFormat == "json" {
+				printJSON(report)
+				return nil
+			}
+			titles := map[string]string{}
+			for _, g := range groups {
+				if g != onlyoffice.TimeByProject {
+					continue
+				}
+				projects, err := c.GetProjectsContext(cmd.Context())
+				if err != nil {
+					return err
+				}
+				for _, p := range projects {
+					if p.ID != nil && p.Title != nil {
+						titles[strconv.Itoa(*p.ID)] = *p.Title
+					}
+				}
+			}
+			headers := make([]string, 0, len(groups)+2)
+			for _, g := range groups {
+				headers = append(headers, string(g))
+			}
+			headers = append(headers, "hours", "entries")
+			rows := make([]map[string]any, 0, len(report)+1)
+			var total float64
+			var n int
+			for _, r := range report {
+				row := map[string]any{"hours": roundHours(r.Hours), "entries": r.Entries}
+				for g, k := range r.Keys {
+					if t, ok := titles[k]; ok && g == onlyoffice.TimeByProject {
+						k += " " + t
+					}
+					row[string(g)] = k
+				}
+				rows = append(rows, row)
+				total += r.Hours
+				n += r.Entries
+			}
+			if len(groups) > 0 && len(rows) > 0 {
+				rows = append(rows, map[string]any{headers[0]: "TOTAL", "hours": roundHours(total), "entries": n})
+			}
+			printTable(headers, rows)
+			return nil
+		},
+	}
+	timeFilterFlags(cmd, &f)
+	cmd.Flags().StringVar(&by, "by", "person,project,week", "comma-separated grouping: person|project|task|week|month|status")
+	return cmd
+}
+
+func timeStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status STATUS TIME_ID [TIME_ID...]",
+		Short: "Mark time entries not-chargeable, billable or paid",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			status, err := onlyoffice.ParsePaymentStatus(args[0])
+			if err != nil {
+				return err
+			}
+			ids, err := atoiAll(args[1:])
+			if err != nil {
+				return err
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			out, err := c.SetTimeStatus(cmd.Context(), status, ids...)
+			if err != nil {
+				return err
+			}
+			if outputFormat == "json" {
+				printJSON(out)
+				return nil
+			}
+			printTable(timeHeaders, timeRows(out))
+			return nil
+		},
+	}
+}
+
+func timeDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete TIME_ID [TIME_ID...]",
+		Aliases: []string{"rm"},
+		Short:   "Delete time entries",
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids, err := atoiAll(args)
+			if err != nil {
+				return err
+			}
+			c, err := newOO(cmd)
+			if err != nil {
+				return err
+			}
+			if err := c.DeleteTime(cmd.Context(), ids...); err != nil {
+				return err
+			}
+			fmt.Printf("deleted %d time entry(s)\n", len(ids))
+			return nil
+		},
+	}
+}
+
+// roundHours trims float noise from summed hours (0.1+0.2).
+func roundHours(h float64) float64 { return math.Round(h*100) / 100 }
+
+func atoiAll(args []string) ([]int, error) {
+	out := make([]int, len(args))
+	for i, a := range args {
+		n, err := strconv.Atoi(a)
+		if err != nil {
+			return nil, fmt.Errorf("id %q: %w", a, err)
+		}
+		out[i] = n
+	}
+	return out, nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInDays(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 31, 23, 59, 59, 0, time.UTC)
+	berlin := time.FixedZone("CEST", 2*3600)
+	for _, tc := range []struct {
+		t    time.Time
+		want bool
+	}{
+		// Midnight in +02:00 is still September in UTC, but October 1st
+		// on the entry's own calendar.
+		{time.Date(2026, 10, 1, 0, 0, 0, 0, berlin), true},
+		{time.Date(2026, 9, 30, 23, 0, 0, 0, berlin), false},
+		{time.Date(2026, 10, 31, 0, 0, 0, 0, time.FixedZone("", -5*3600)), true},
+		{time.Date(2026, 11, 1, 0, 30, 0, 0, berlin), false},
+	} {
+		if got := inDays(tc.t, from, to); got != tc.want {
+			t.Errorf("inDays(%s) = %v, want %v", tc.t, got, tc.want)
+		}
+	}
+	if !inDays(time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}, time.Time{}) {
+		t.Error("zero bounds must be open")
+	}
+}
@@ -25,7 +25,7 @@ func usersListCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			users, err := c.GetUsers()
+			users, err := c.GetUsersContext(cmd.Context())
 			if err != nil {
 				return err
 			}
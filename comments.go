@@ -0,0 +1,225 @@
+package onlyoffice
+
+// Project comments and discussions: comment threads on tasks and on
+// project discussions (/api/2.0/project/message), plus discussion CRUD.
+// Comment and discussion bodies are HTML as the portal editor stores them.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Comment is one entry of a task or discussion comment thread. Replies
+// carry the id of the comment they answer in ParentID.
+type Comment struct {
+	ID        string `json:"id"` // GUID
+	ParentID  string `json:"parentId,omitempty"`
+	Text      string `json:"text"` // HTML
+	Created   *Time  `json:"created,omitempty"`
+	CreatedBy *User  `json:"createdBy,omitempty"`
+	Updated   *Time  `json:"updated,omitempty"`
+	Inactive  bool   `json:"inactive"` // deleted, kept for its replies
+	CanEdit   bool   `json:"canEdit"`
+}
+
+// AuthorName returns the display name of the comment's author.
+func (c Comment) AuthorName() string {
+	if c.CreatedBy == nil || c.CreatedBy.DisplayName == nil {
+		return ""
+	}
+	return *c.CreatedBy.DisplayName
+}
+
+// ThreadComments orders comments depth-first so replies follow their
+// parent, and returns the nesting depth of each. Comments whose parent is
+// missing are treated as top-level, and so is the first comment of a parent
+// cycle (a→b→a) in input order, with the rest of the cycle below it.
+func ThreadComments(comments []Comment) (ordered []Comment, depth []int) {
+	known := make(map[string]bool, len(comments))
+	for _, c := range comments {
+		known[c.ID] = true
+	}
+	children := map[string][]int{}
+	for i, c := range comments {
+		parent := c.ParentID
+		if !known[parent] || parent == c.ID {
+			parent = ""
+		}
+		children[parent] = append(children[parent], i)
+	}
+	seen := make([]bool, len(comments))
+	var walk func(i, d int)
+	walk = func(i, d int) {
+		if seen[i] {
+			return
+		}
+		seen[i] = true
+		ordered, depth = append(ordered, comments[i]), append(depth, d)
+		for _, j := range children[comments[i].ID] {
+			walk(j, d+1)
+		}
+	}
+	for _, i := range children[""] {
+		walk(i, 0)
+	}
+	// Whatever the walk did not reach hangs off a cycle.
+	for i := range comments {
+		walk(i, 0)
+	}
+	return ordered, depth
+}
+
+// ListTaskComments returns the comments on a task, oldest first.
+// GET /api/2.0/project/task/{taskid}/comment
+func (c *Client) ListTaskComments(ctx context.Context, taskID int) ([]Comment, error) {
+	return getTyped[[]Comment](ctx, c, fmt.Sprintf("/api/2.0/project/task/%d/comment.json", taskID))
+}
+
+// AddTaskComment posts a comment on a task; parentID answers an existing
+// comment ("" = top-level). content is HTML; plain text is sent as is.
+// POST /api/2.0/project/task/{taskid}/comment
+func (c *Client) AddTaskComment(ctx context.Context, taskID int, content, parentID string) (*Comment, error) {
+	return c.addComment(ctx, fmt.Sprintf("/api/2.0/project/task/%d/comment.json", taskID), content, parentID)
+}
+
+// UpdateComment replaces the text of a task or discussion comment.
+// PUT /api/2.0/project/comment/{commentid}
+func (c *Client) UpdateComment(ctx context.Context, commentID, content string) (*Comment, error) {
+	if strings.TrimSpace(content) == "" {
+		return nil, errors.New("comment: content is required")
+	}
+	raw, err := c.putJSON(ctx, "/api/2.0/project/comment/"+url.PathEscape(commentID)+".json", map[string]any{"content": content})
+	if err != nil {
+		return nil, err
+	}
+	// Older portals answer with the new text only.
+	var text string
+	if decodeResponse(raw, &text) == nil {
+		return &Comment{ID: commentID, Text: text}, nil
+	}
+	out := new(Comment)
+	return out, decodeResponse(raw, out)
+}
+
+// DeleteComment removes a task or discussion comment.
+// DELETE /api/2.0/project/comment/{commentid}
+func (c *Client) DeleteComment(ctx context.Context, commentID string) error {
+	_, err := c.deleteJSON(ctx, "/api/2.0/project/comment/"+url.PathEscape(commentID)+".json", nil)
+	return err
+}
+
+func (c *Client) addComment(ctx context.Context, path, content, parentID string) (*Comment, error) {
+	if strings.TrimSpace(content) == "" {
+		return nil, errors.New("comment: content is required")
+	}
+	body := map[string]any{"content": content}
+	if parentID != "" {
+		body["parentId"] = parentID
+	}
+	raw, err := c.postJSON(ctx, path, body)
+	if err != nil {
+		return nil, err
+	}
+	out := new(Comment)
+	return out, decodeResponse(raw, out)
+}
+
+// Discussion is a project discussion (the portal's "message").
+type Discussion struct {
+	ID            FlexInt       `json:"id"`
+	Title         string        `json:"title"`
+	Text          string        `json:"text,omitempty"` // HTML
+	ProjectOwner  *ProjectOwner `json:"projectOwner,omitempty"`
+	Created       *Time         `json:"created,omitempty"`
+	CreatedBy     *User         `json:"createdBy,omitempty"`
+	Updated       *Time         `json:"updated,omitempty"`
+	CommentsCount FlexInt       `json:"commentsCount"`
+	Status        FlexInt       `json:"status"` // 0 open, 1 archived
+	CanEdit       bool          `json:"canEdit"`
+}
+
+// NewDiscussion is the payload for CreateDiscussion and UpdateDiscussion.
+type NewDiscussion struct {
+	Title   string
+	Content string // HTML
+	// Participants are user ids subscribed to the discussion.
+	Participants []string
+	// Notify e-mails the participants.
+	Notify bool
+}
+
+func (d NewDiscussion) body(projectID int) (map[string]any, error) {
+	if strings.TrimSpace(d.Title) == "" {
+		return nil, errors.New("discussion: title is required")
+	}
+	body := map[string]any{"projectId": projectID, "title": d.Title, "content": d.Content, "notify": d.Notify}
+	if len(d.Participants) > 0 {
+		body["participants"] = strings.Join(d.Participants, ",")
+	}
+	return body, nil
+}
+
+// ListDiscussions returns the discussions of a project.
+// GET /api/2.0/project/{projectid}/message
+func (c *Client) ListDiscussions(ctx context.Context, projectID int) ([]Discussion, error) {
+	return getTyped[[]Discussion](ctx, c, fmt.Sprintf("/api/2.0/project/%d/message.json", projectID))
+}
+
+// GetDiscussion returns one discussion with its text.
+// GET /api/2.0/project/message/{messageid}
+func (c *Client) GetDiscussion(ctx context.Context, id int) (*Discussion, error) {
+	return getTyped[*Discussion](ctx, c, fmt.Sprintf("/api/2.0/project/message/%d.json", id))
+}
+
+// CreateDiscussion starts a discussion in a project.
+// POST /api/2.0/project/{projectid}/message
+func (c *Client) CreateDiscussion(ctx context.Context, projectID int, d NewDiscussion) (*Discussion, error) {
+	body, err := d.body(projectID)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := c.postJSON(ctx, fmt.Sprintf("/api/2.0/project/%d/message.json", projectID), body)
+	if err != nil {
+		return nil, err
+	}
+	out := new(Discussion)
+	return out, decodeResponse(raw, out)
+}
+
+// UpdateDiscussion replaces title, text and participants of a discussion.
+// PUT /api/2.0/project/message/{messageid}
+func (c *Client) UpdateDiscussion(ctx context.Context, id, projectID int, d NewDiscussion) (*Discussion, error) {
+	body, err := d.body(projectID)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := c.putJSON(ctx, fmt.Sprintf("/api/2.0/project/message/%d.json", id), body)
+	if err != nil {
+		return nil, err
+	}
+	out := new(Discussion)
+	return out, decodeResponse(raw, out)
+}
+
+// DeleteDiscussion removes a discussion and its comments.
+// DELETE /api/2.0/project/message/{messageid}
+func (c *Client) DeleteDiscussion(ctx context.Context, id int) error {
+	_, err := c.deleteJSON(ctx, fmt.Sprintf("/api/2.0/project/message/%d.json", id), nil)
+	return err
+}
+
+// ListDiscussionComments returns the comments on a discussion.
+// GET /api/2.0/project/message/{messageid}/comment
+func (c *Client) ListDiscussionComments(ctx context.Context, id int) ([]Comment, error) {
+	return getTyped[[]Comment](ctx, c, fmt.Sprintf("/api/2.0/project/message/%d/comment.json", id))
+}
+
+// AddDiscussionComment posts a comment on a discussion; parentID answers
+// an existing comment ("" = top-level).
+// POST /api/2.0/project/message/{messageid}/comment
+func (c *Client) AddDiscussionComment(ctx context.Context, id int, content, parentID string) (*Comment, error) {
+	return c.addComment(ctx, fmt.Sprintf("/api/2.0/project/message/%d/comment.json", id), content, parentID)
+}
@@ -0,0 +1,91 @@
+//go:build integration
+
+package onlyoffice
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestIntegrationTaskComments posts a comment and a reply on a throwaway
+// task, edits the reply, threads them and deletes both.
+func TestIntegrationTaskComments(t *testing.T) {
+	c := liveClient(t)
+	ctx := context.Background()
+	taskID := createTestTask(t, c, createTestProject(t, c, "comments"), "integration comment task")
+
+	first, err := c.AddTaskComment(ctx, taskID, "<p>Looks good</p>", "")
+	if err != nil {
+		t.Fatalf("AddTaskComment: %v", err)
+	}
+	if first.ID == "" || first.AuthorName() == "" {
+		t.Fatalf("comment = %+v", first)
+	}
+	reply, err := c.AddTaskComment(ctx, taskID, "<p>Thanks</p>", first.ID)
+	if err != nil {
+		t.Fatalf("AddTaskComment reply: %v", err)
+	}
+	edited, err := c.UpdateComment(ctx, reply.ID, "<p>Thanks!</p>")
+	if err != nil {
+		t.Fatalf("UpdateComment: %v", err)
+	}
+	if edited.ID != reply.ID || !strings.Contains(edited.Text, "Thanks!") {
+		t.Errorf("UpdateComment = %+v", edited)
+	}
+
+	list, err := c.ListTaskComments(ctx, taskID)
+	if err != nil {
+		t.Fatalf("ListTaskComments: %v", err)
+	}
+	ordered, depth := ThreadComments(list)
+	if len(ordered) != 2 || ordered[0].ID != first.ID || ordered[1].ID != reply.ID || depth[1] != 1 {
+		t.Fatalf("thread = %+v, depth %v", ordered, depth)
+	}
+
+	for _, id := range []string{reply.ID, first.ID} {
+		if err := c.DeleteComment(ctx, id); err != nil {
+			t.Fatalf("DeleteComment %s: %v", id, err)
+		}
+	}
+	if _, err := c.AddTaskComment(ctx, taskID, "  ", ""); err == nil {
+		t.Error("empty comment must fail")
+	}
+}
+
+// TestIntegrationDiscussions creates, comments on, renames and deletes a
+// discussion in a throwaway project.
+func TestIntegrationDiscussions(t *testing.T) {
+	c := liveClient(t)
+	ctx := context.Background()
+	projectID := createTestProject(t, c, "discussions")
+
+	d, err := c.CreateDiscussion(ctx, projectID, NewDiscussion{Title: "Kickoff", Content: "<p>Agenda</p>"})
+	if err != nil {
+		t.Fatalf("CreateDiscussion: %v", err)
+	}
+	if _, err := c.AddDiscussionComment(ctx, int(d.ID), "<p>+1</p>", ""); err != nil {
+		t.Fatalf("AddDiscussionComment: %v", err)
+	}
+	if _, err := c.UpdateDiscussion(ctx, int(d.ID), projectID, NewDiscussion{Title: "Kickoff (moved)", Content: "<p>Agenda</p>"}); err != nil {
+		t.Fatalf("UpdateDiscussion: %v", err)
+	}
+	got, err := c.GetDiscussion(ctx, int(d.ID))
+	if err != nil || got.Title != "Kickoff (moved)" {
+		t.Fatalf("GetDiscussion = %+v, %v", got, err)
+	}
+	list, err := c.ListDiscussions(ctx, projectID)
+	if err != nil || len(list) != 1 || list[0].CommentsCount != 1 {
+		t.Fatalf("ListDiscussions = %+v, %v", list, err)
+	}
+	if cs, err := c.ListDiscussionComments(ctx, int(d.ID)); err != nil || len(cs) != 1 {
+		t.Fatalf("ListDiscussionComments = %+v, %v", cs, err)
+	}
+	if err := c.DeleteDiscussion(ctx, int(d.ID)); err != nil {
+		t.Fatalf("DeleteDiscussion: %v", err)
+	}
+	if _, err := c.GetDiscussion(ctx, int(d.ID)); !errors.Is(err, ErrNotFound) {
+		t.Errorf("deleted discussion: err = %v, want ErrNotFound", err)
+	}
+}
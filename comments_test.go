@@ -0,0 +1,33 @@
+package onlyoffice
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestThreadComments(t *testing.T) {
+	in := []Comment{
+		{ID: "a"}, {ID: "b"}, {ID: "c", ParentID: "a"}, {ID: "d", ParentID: "c"}, {ID: "e", ParentID: "gone"},
+	}
+	if got, want := thread(in), "a >c >>d b e"; got != want {
+		t.Errorf("thread = %s; want %s", got, want)
+	}
+
+	// A parent cycle has no top-level entry point; its first comment in
+	// input order takes that place instead of the cycle vanishing.
+	in = []Comment{
+		{ID: "x"}, {ID: "b", ParentID: "a"}, {ID: "a", ParentID: "b"}, {ID: "r", ParentID: "a"},
+	}
+	if got, want := thread(in), "x b >a >>r"; got != want {
+		t.Errorf("cycle thread = %s; want %s", got, want)
+	}
+}
+
+func thread(in []Comment) string {
+	ordered, depth := ThreadComments(in)
+	var got []string
+	for i, c := range ordered {
+		got = append(got, strings.Repeat(">", depth[i])+c.ID)
+	}
+	return strings.Join(got, " ")
+}
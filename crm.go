@@ -2,7 +2,7 @@ package onlyoffice
 
 // Minimal CRM helpers: contacts, opportunities, cases, tasks, and history notes.
 // These expose untyped maps for flexibility — they are primarily consumed by
-// cmd/oo and CRM sync tooling.
+// cmd/oo and CRM sync tooling. Typed variants live in crm_types.go.
 
 import (
 	"context"
@@ -17,27 +17,50 @@ import (
 // ListContacts returns a page of CRM contacts and the total count.
 func (c *Client) ListContacts(ctx context.Context, count, startIndex int, search string) ([]map[string]any, int, error) {
 	q := url.Values{}
-	q.Set("count", strconv.Itoa(count))
-	q.Set("startIndex", strconv.Itoa(startIndex))
 	if search != "" {
 		q.Set("filterValue", search)
 	}
-	raw, err := c.getJSON(ctx, "/api/2.0/crm/contact/filter.json?"+q.Encode())
-	if err != nil {
-		return nil, 0, err
-	}
-	var env struct {
-		Response []map[string]any `json:"response"`
-		Total    int              `json:"total"`
+	return pageTotal(c.filterPage(ctx, "/api/2.0/crm/contact/filter.json", q, count, startIndex))
+}
+
+// ContactFilter narrows IterContacts. Zero value lists every contact.
+type ContactFilter struct {
+	Search   string // filterValue: free-text match on name/email
+	Tag      string // only contacts carrying this tag
+	PageSize int    // rows per request; 0 means DefaultPageSize
+}
+
+// IterContacts streams CRM contacts matching f page by page:
+//
+//	p := c.IterContacts(ctx, onlyoffice.ContactFilter{Tag: "customer"})
+//	for row, err := range p.All() { … }
+func (c *Client) IterContacts(ctx context.Context, f ContactFilter) *Pager[map[string]any] {
+	q := url.Values{}
+	if f.Search != "" {
+		q.Set("filterValue", f.Search)
 	}
-	if err := json.Unmarshal(raw, &env); err != nil {
-		return nil, 0, err
+	if f.Tag != "" {
+		q.Set("tags", f.Tag)
 	}
-	total := env.Total
-	if total == 0 && len(env.Response) > 0 {
-		total = len(env.Response)
+	return NewPager(ctx, f.PageSize, func(ctx context.Context, count, start int) ([]map[string]any, int, error) {
+		return c.filterPage(ctx, "/api/2.0/crm/contact/filter.json", q, count, start)
+	})
+}
+
+// filterPage fetches one count/startIndex page from a CRM filter endpoint.
+// extra carries endpoint-specific filters and is not modified. The total is
+// passed through as sent (0 when the envelope omits it) so Pager can tell
+// an unknown total from a one-page list.
+func (c *Client) filterPage(ctx context.Context, path string, extra url.Values, count, startIndex int) ([]map[string]any, int, error) {
+	return filterPageOf[map[string]any](ctx, c, path, extra, count, startIndex)
+}
+
+// pageTotal substitutes the page length for a missing envelope total.
+func pageTotal(rows []map[string]any, total int, err error) ([]map[string]any, int, error) {
+	if err == nil && total == 0 && len(rows) > 0 {
+		total = len(rows)
 	}
-	return env.Response, total, nil
+	return rows, total, err
 }
 
 // GetContact returns a single contact by id.
@@ -52,6 +75,12 @@ func (c *Client) FindCompany(ctx context.Context, name string) (map[string]any,
 	if needle == "" {
 		return nil, nil
 	}
+	return cached(ctx, c, CacheContacts, "company:"+needle, func(ctx context.Context) (map[string]any, error) {
+		return c.findCompany(ctx, name, needle)
+	})
+}
+
+func (c *Client) findCompany(ctx context.Context, name, needle string) (map[string]any, error) {
 	const page = 50
 	for start := 0; ; start += page {
 		items, total, err := c.ListContacts(ctx, page, start, name)
@@ -119,6 +148,12 @@ func (c *Client) FindPersonByEmail(ctx context.Context, email string) (map[strin
 	if needle == "" {
 		return nil, nil
 	}
+	return cached(ctx, c, CacheContacts, "email:"+needle, func(ctx context.Context) (map[string]any, error) {
+		return c.findPersonByEmail(ctx, needle)
+	})
+}
+
+func (c *Client) findPersonByEmail(ctx context.Context, needle string) (map[string]any, error) {
 	all, err := c.ListAllContacts(ctx)
 	if err != nil {
 		return nil, err
@@ -245,7 +280,9 @@ func (c *Client) DeleteContact(ctx context.Context, contactID string) (map[strin
 
 // ListContactTags returns all CRM contact tags (title + relativeItemsCount).
 func (c *Client) ListContactTags(ctx context.Context) ([]map[string]any, error) {
-	return c.ResponseArray(ctx, "/api/2.0/crm/contact/tag.json")
+	return cached(ctx, c, CacheContactTags, "list", func(ctx context.Context) ([]map[string]any, error) {
+		return c.ResponseArray(ctx, "/api/2.0/crm/contact/tag.json")
+	})
 }
 
 // CreateContactTag creates a contact tag by name. Idempotent: "already exists" is OK.
@@ -284,43 +321,15 @@ func (c *Client) ListContactsByTag(ctx context.Context, tagName string, count, s
 	if count <= 0 {
 		count = 50
 	}
-	q := url.Values{}
-	q.Set("count", strconv.Itoa(count))
-	q.Set("startIndex", strconv.Itoa(startIndex))
-	q.Set("tags", tagName)
-	raw, err := c.getJSON(ctx, "/api/2.0/crm/contact/filter.json?"+q.Encode())
-	if err != nil {
-		return nil, 0, err
-	}
-	var env struct {
-		Response []map[string]any `json:"response"`
-		Total    int              `json:"total"`
-	}
-	if err := json.Unmarshal(raw, &env); err != nil {
-		return nil, 0, err
-	}
-	total := env.Total
-	if total == 0 && len(env.Response) > 0 {
-		total = len(env.Response)
-	}
-	return env.Response, total, nil
+	return pageTotal(c.filterPage(ctx, "/api/2.0/crm/contact/filter.json", url.Values{"tags": {tagName}}, count, startIndex))
 }
 
-// ListAllContacts paginates through every CRM contact.
+// ListAllContacts paginates through every CRM contact. Pages after the
+// first are fetched in parallel up to the client's Concurrency.
 func (c *Client) ListAllContacts(ctx context.Context) ([]map[string]any, error) {
-	const page = 100
-	var all []map[string]any
-	for start := 0; ; start += page {
-		chunk, total, err := c.ListContacts(ctx, page, start, "")
-		if err != nil {
-			return nil, err
-		}
-		all = append(all, chunk...)
-		if start+page >= total || len(chunk) == 0 {
-			break
-		}
-	}
-	return all, nil
+	return listAllPages(ctx, c.Concurrency(), 100, func(ctx context.Context, count, start int) ([]map[string]any, int, error) {
+		return c.ListContacts(ctx, count, start, "")
+	})
 }
 
 // MergeContacts merges secondary into primary (secondary is removed).
@@ -390,21 +399,14 @@ func mapsFromAnySlice(rows []any) []map[string]any {
 
 // ListOpportunities returns a page of deals/opportunities and the total count.
 func (c *Client) ListOpportunities(ctx context.Context, count, startIndex int) ([]map[string]any, int, error) {
-	q := url.Values{}
-	q.Set("count", strconv.Itoa(count))
-	q.Set("startIndex", strconv.Itoa(startIndex))
-	raw, err := c.getJSON(ctx, "/api/2.0/crm/opportunity/filter.json?"+q.Encode())
-	if err != nil {
-		return nil, 0, err
-	}
-	var env struct {
-		Response []map[string]any `json:"response"`
-		Total    int              `json:"total"`
-	}
-	if err := json.Unmarshal(raw, &env); err != nil {
-		return nil, 0, err
-	}
-	return env.Response, env.Total, nil
+	return c.filterPage(ctx, "/api/2.0/crm/opportunity/filter.json", nil, count, startIndex)
+}
+
+// IterOpportunities streams every opportunity page by page.
+func (c *Client) IterOpportunities(ctx context.Context) *Pager[map[string]any] {
+	return NewPager(ctx, DefaultPageSize, func(ctx context.Context, count, start int) ([]map[string]any, int, error) {
+		return c.ListOpportunities(ctx, count, start)
+	})
 }
 
 // GetOpportunity returns a single opportunity (deal) by id.
@@ -448,7 +450,9 @@ func (c *Client) AddOpportunityMember(ctx context.Context, oppID, contactID stri
 
 // ListDealStages returns the configured opportunity stages.
 func (c *Client) ListDealStages(ctx context.Context) ([]map[string]any, error) {
-	return c.ResponseArray(ctx, "/api/2.0/crm/opportunity/stage.json")
+	return cached(ctx, c, CacheDealStages, "list", func(ctx context.Context) ([]map[string]any, error) {
+		return c.ResponseArray(ctx, "/api/2.0/crm/opportunity/stage.json")
+	})
 }
 
 // DeleteOpportunity removes a deal by id.
@@ -456,21 +460,10 @@ func (c *Client) DeleteOpportunity(ctx context.Context, id string) (map[string]a
 	return c.deleteObject(ctx, fmt.Sprintf("/api/2.0/crm/opportunity/%s.json", url.PathEscape(id)))
 }
 
-// ListAllOpportunities paginates through every opportunity.
+// ListAllOpportunities paginates through every opportunity. Pages after the
+// first are fetched in parallel up to the client's Concurrency.
 func (c *Client) ListAllOpportunities(ctx context.Context) ([]map[string]any, error) {
-	const page = 100
-	var all []map[string]any
-	for start := 0; ; start += page {
-		chunk, total, err := c.ListOpportunities(ctx, page, start)
-		if err != nil {
-			return nil, err
-		}
-		all = append(all, chunk...)
-		if start+page >= total || len(chunk) == 0 {
-			break
-		}
-	}
-	return all, nil
+	return listAllPages(ctx, c.Concurrency(), 100, c.ListOpportunities)
 }
 
 // OpportunityMembers extracts the members slice from a GetOpportunity response.
@@ -599,21 +592,14 @@ func floatField(m map[string]any, key string) float64 {
 
 // ListCases returns a page of CRM cases and the total count.
 func (c *Client) ListCases(ctx context.Context, count, startIndex int) ([]map[string]any, int, error) {
-	q := url.Values{}
-	q.Set("count", strconv.Itoa(count))
-	q.Set("startIndex", strconv.Itoa(startIndex))
-	raw, err := c.getJSON(ctx, "/api/2.0/crm/case/filter.json?"+q.Encode())
-	if err != nil {
-		return nil, 0, err
-	}
-	var env struct {
-		Response []map[string]any `json:"response"`
-		Total    int              `json:"total"`
-	}
-	if err := json.Unmarshal(raw, &env); err != nil {
-		return nil, 0, err
-	}
-	return env.Response, env.Total, nil
+	return c.filterPage(ctx, "/api/2.0/crm/case/filter.json", nil, count, startIndex)
+}
+
+// IterCases streams every CRM case page by page.
+func (c *Client) IterCases(ctx context.Context) *Pager[map[string]any] {
+	return NewPager(ctx, DefaultPageSize, func(ctx context.Context, count, start int) ([]map[string]any, int, error) {
+		return c.ListCases(ctx, count, start)
+	})
 }
 
 // CreateCase creates a new CRM case.
@@ -637,21 +623,14 @@ func (c *Client) DeleteCase(ctx context.Context, id string) (map[string]any, err
 
 // ListCRMTasks returns a page of CRM tasks (separate from Project tasks).
 func (c *Client) ListCRMTasks(ctx context.Context, count, startIndex int) ([]map[string]any, int, error) {
-	q := url.Values{}
-	q.Set("count", strconv.Itoa(count))
-	q.Set("startIndex", strconv.Itoa(startIndex))
-	raw, err := c.getJSON(ctx, "/api/2.0/crm/task/filter.json?"+q.Encode())
-	if err != nil {
-		return nil, 0, err
-	}
-	var env struct {
-		Response []map[string]any `json:"response"`
-		Total    int              `json:"total"`
-	}
-	if err := json.Unmarshal(raw, &env); err != nil {
-		return nil, 0, err
-	}
-	return env.Response, env.Total, nil
+	return c.filterPage(ctx, "/api/2.0/crm/task/filter.json", nil, count, startIndex)
+}
+
+// IterCRMTasks streams every CRM task page by page.
+func (c *Client) IterCRMTasks(ctx context.Context) *Pager[map[string]any] {
+	return NewPager(ctx, DefaultPageSize, func(ctx context.Context, count, start int) ([]map[string]any, int, error) {
+		return c.ListCRMTasks(ctx, count, start)
+	})
 }
 
 // CreateCRMTask creates a CRM task (reminder) attached to an entity.
@@ -719,7 +698,9 @@ func (c *Client) DeleteCRMTask(ctx context.Context, id string) (map[string]any,
 
 // ListTaskCategories returns CRM task categories.
 func (c *Client) ListTaskCategories(ctx context.Context) ([]map[string]any, error) {
-	return c.ResponseArray(ctx, "/api/2.0/crm/task/category.json")
+	return cached(ctx, c, CacheTaskCategories, "list", func(ctx context.Context) ([]map[string]any, error) {
+		return c.ResponseArray(ctx, "/api/2.0/crm/task/category.json")
+	})
 }
 
 // AddHistoryNote attaches a history note to a CRM entity. When categoryID is 0,
@@ -741,24 +722,30 @@ func (c *Client) AddHistoryNote(ctx context.Context, entityType string, entityID
 }
 
 func (c *Client) historyNoteCategoryID(ctx context.Context) (int, error) {
-	if c.noteCatID != 0 {
-		return c.noteCatID, nil
+	c.mu.Lock()
+	cached := c.noteCatID
+	c.mu.Unlock()
+	if cached != 0 {
+		return cached, nil
 	}
 	list, err := c.ResponseArray(ctx, "/api/2.0/crm/history/category.json")
 	if err != nil {
 		return 0, err
 	}
+	id := 0
 	for _, row := range list {
 		if strings.EqualFold(fmt.Sprint(row["title"]), "note") {
-			c.noteCatID = int(flexInt(row["id"]))
-			return c.noteCatID, nil
+			id = int(flexInt(row["id"]))
+			break
 		}
 	}
-	if len(list) > 0 {
-		c.noteCatID = int(flexInt(list[0]["id"]))
-		return c.noteCatID, nil
+	if id == 0 && len(list) > 0 {
+		id = int(flexInt(list[0]["id"]))
 	}
-	return 0, nil
+	c.mu.Lock()
+	c.noteCatID = id
+	c.mu.Unlock()
+	return id, nil
 }
 
 // flexInt coerces OnlyOffice numeric fields that JSON unmarshal may surface as
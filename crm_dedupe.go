@@ -50,6 +50,19 @@ func executeMergePlans(ctx context.Context, client crmDedupeClient, plans []Merg
 	}
 }
 
+// prefetch loads every id with get, up to limit in flight. Unlike
+// ParallelMap a failed row does not cancel the others: per-row errors are
+// returned positionally so dedupe passes can record them and move on.
+func prefetch(ctx context.Context, limit int, ids []string, get func(context.Context, string) (map[string]any, error)) ([]map[string]any, []error) {
+	out := make([]map[string]any, len(ids))
+	errs := make([]error, len(ids))
+	_ = ParallelForEach(ctx, limit, ids, func(ctx context.Context, i int, id string) error {
+		out[i], errs[i] = get(ctx, id)
+		return nil
+	})
+	return out, errs
+}
+
 // DedupeCompanies merges duplicate company contacts by normalized name.
 func DedupeCompanies(ctx context.Context, client crmDedupeClient) (DedupeResult, error) {
 	var res DedupeResult
@@ -100,14 +113,17 @@ func DedupeContactInfo(ctx context.Context, client crmDedupeClient) (DedupeResul
 	if err != nil {
 		return res, err
 	}
-	for _, row := range items {
-		cid := strconv.FormatInt(rowID(row), 10)
-		contact, err := client.GetContact(ctx, cid)
-		if err != nil {
-			res.addErr(err)
+	ids := make([]string, len(items))
+	for i, row := range items {
+		ids[i] = strconv.FormatInt(rowID(row), 10)
+	}
+	contacts, errs := prefetch(ctx, concurrencyOf(client), ids, client.GetContact)
+	for i, cid := range ids {
+		if errs[i] != nil {
+			res.addErr(errs[i])
 			continue
 		}
-		rows := ContactInfoRows(contact)
+		rows := ContactInfoRows(contacts[i])
 		for _, dataID := range GroupContactInfoRows(rows) {
 			_, err := client.DeleteContactInfo(ctx, cid, strconv.FormatInt(dataID, 10))
 			if err != nil {
@@ -171,14 +187,17 @@ func DedupeOpportunityMembers(ctx context.Context, client crmDedupeClient) (Dedu
 	if err != nil {
 		return res, err
 	}
-	for _, row := range items {
-		oppID := strconv.FormatInt(rowID(row), 10)
-		opp, err := client.GetOpportunity(ctx, oppID)
-		if err != nil {
-			res.addErr(err)
+	ids := make([]string, len(items))
+	for i, row := range items {
+		ids[i] = strconv.FormatInt(rowID(row), 10)
+	}
+	opps, errs := prefetch(ctx, concurrencyOf(client), ids, client.GetOpportunity)
+	for i, oppID := range ids {
+		if errs[i] != nil {
+			res.addErr(errs[i])
 			continue
 		}
-		members := OpportunityMembers(opp)
+		members := OpportunityMembers(opps[i])
 		if len(members) == 0 {
 			continue
 		}
@@ -227,8 +246,10 @@ func FixOpportunityTitles(ctx context.Context, client crmDedupeClient) (DedupeRe
 }
 
 // CleanupCRM runs all dedupe passes in dependency order.
-func CleanupCRM(ctx context.Context, client crmDedupeClient, ignoreCompanySuffix bool) (map[string]DedupeResult, error) {
-	out := make(map[string]DedupeResult)
+func CleanupCRM(ctx context.Context, client crmDedupeClient, ignoreCompanySuffix bool) (out map[string]DedupeResult, err error) {
+	ctx, end := startSpanOf(ctx, client, "CleanupCRM")
+	defer func() { end(err) }()
+	out = make(map[string]DedupeResult)
 	steps := []struct {
 		name string
 		fn   func(context.Context, crmDedupeClient) (DedupeResult, error)
@@ -4,9 +4,14 @@ package onlyoffice
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -127,3 +132,145 @@ func TestIntegrationFixOpportunityTitleDeal231Pattern(t *testing.T) {
 		t.Fatalf("title %q", got["title"])
 	}
 }
+
+func TestIntegrationUndoDeletedCompany(t *testing.T) {
+	j, err := NewFileJournal(filepath.Join(t.TempDir(), "journal.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewClient(skipWithoutCredentials(t), WithJournal(j))
+	ctx := context.Background()
+	name := testCRMPrefix + "undo-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	row, err := c.CreateCompany(ctx, name)
+	if err != nil {
+		t.Fatalf("CreateCompany: %v", err)
+	}
+	id := strconv.FormatInt(flexInt(row["id"]), 10)
+	if err := c.AddContactTag(ctx, id, "go-onlyoffice-test"); err != nil {
+		t.Fatalf("AddContactTag: %v", err)
+	}
+	if _, err := c.DeleteContact(ctx, id); err != nil {
+		t.Fatalf("DeleteContact: %v", err)
+	}
+	deletes, err := j.List(JournalFilter{Op: JournalDelete, Path: "/crm/contact/" + id + ".json"})
+	if err != nil || len(deletes) != 1 {
+		t.Fatalf("journaled deletes = %d, %v", len(deletes), err)
+	}
+
+	res, err := c.Undo(ctx, &deletes[0])
+	if err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	newID := res.NewIDs[id]
+	if newID == "" || len(res.Errors) > 0 {
+		t.Fatalf("Undo = %+v", res)
+	}
+	t.Cleanup(func() { _, _ = c.DeleteContact(ctx, newID) })
+	got, err := c.GetContact(ctx, newID)
+	if err != nil {
+		t.Fatalf("GetContact %s: %v", newID, err)
+	}
+	if stringField(got, "displayName") != name || !strings.Contains(fmt.Sprint(got["tags"]), "go-onlyoffice-test") {
+		t.Errorf("recreated company = %v", got)
+	}
+
+	undos, err := j.List(JournalFilter{Op: JournalUndo})
+	if err != nil || len(undos) != 1 || undos[0].UndoOf != deletes[0].ID {
+		t.Fatalf("journaled undos = %+v, %v", undos, err)
+	}
+	if _, err := c.UndoFrom(ctx, j, deletes[0].ID); !errors.Is(err, ErrAlreadyUndone) {
+		t.Fatalf("second undo: err = %v, want ErrAlreadyUndone", err)
+	}
+}
+
+// TestIntegrationJournalSnapshots deletes a throwaway company through a
+// journaling client and checks the entry carries the portal's pre-delete
+// view of it; a dry-run client journals nothing.
+func TestIntegrationJournalSnapshots(t *testing.T) {
+	creds := skipWithoutCredentials(t)
+	j, err := NewFileJournal(filepath.Join(t.TempDir(), "journal.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewClient(creds, WithJournal(j))
+	ctx := context.Background()
+	name := testCRMPrefix + "journal-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	row, err := c.CreateCompany(ctx, name)
+	if err != nil {
+		t.Fatalf("CreateCompany: %v", err)
+	}
+	id := strconv.FormatInt(flexInt(row["id"]), 10)
+	t.Cleanup(func() { _, _ = c.DeleteContact(ctx, id) })
+
+	dry := NewClient(creds, WithJournal(j), WithDryRun(NewDryRun()))
+	if _, err := dry.DeleteContact(ctx, id); err != nil {
+		t.Fatalf("dry-run DeleteContact: %v", err)
+	}
+	if _, err := c.DeleteContact(ctx, id); err != nil {
+		t.Fatalf("DeleteContact: %v", err)
+	}
+
+	entries, err := j.List(JournalFilter{})
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("entries = %+v, %v; want the create and the real delete", entries, err)
+	}
+	created, deleted := entries[0], entries[1]
+	if created.Op != JournalCreate || !created.OK() || !strings.Contains(string(created.Response), id) {
+		t.Errorf("create entry = %+v", created)
+	}
+	path := "/api/2.0/crm/contact/" + id + ".json"
+	if deleted.Op != JournalDelete || deleted.Path != path || deleted.User != creds.User || !deleted.OK() {
+		t.Errorf("delete entry = %+v", deleted)
+	}
+	var snap map[string]any
+	if err := json.Unmarshal(deleted.Snapshot[path], &snap); err != nil || snap["displayName"] != name {
+		t.Errorf("contact snapshot = %s, %v", deleted.Snapshot[path], err)
+	}
+	if got, err := j.Get(deleted.ID); err != nil || got.Path != path {
+		t.Errorf("Get = %+v, %v", got, err)
+	}
+}
+
+// TestIntegrationCache counts the GETs a caching client sends: repeat
+// lookups are served from the cache until InvalidateCache or a write to
+// the resource's endpoints.
+func TestIntegrationCache(t *testing.T) {
+	var gets atomic.Int64
+	count := func(next http.RoundTripper) http.RoundTripper {
+		return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodGet {
+				gets.Add(1)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+	c := NewClient(skipWithoutCredentials(t), WithCache(NewMemoryCache(), nil), WithMiddleware(count))
+	ctx := context.Background()
+	lookup := func(what string, list func(context.Context) ([]map[string]any, error), want int64) {
+		t.Helper()
+		gets.Store(0)
+		if _, err := list(ctx); err != nil {
+			t.Fatalf("%s: %v", what, err)
+		}
+		if n := gets.Load(); n != want {
+			t.Fatalf("%s: %d GETs, want %d", what, n, want)
+		}
+	}
+
+	lookup("first ListDealStages", c.ListDealStages, 1)
+	lookup("repeat ListDealStages", c.ListDealStages, 0)
+	c.InvalidateCache(CacheDealStages)
+	lookup("ListDealStages after InvalidateCache", c.ListDealStages, 1)
+
+	lookup("first ListContactTags", c.ListContactTags, 1)
+	lookup("repeat ListContactTags", c.ListContactTags, 0)
+	row, err := c.CreateCompany(ctx, testCRMPrefix+"cache-"+strconv.FormatInt(time.Now().UnixNano(), 10))
+	if err != nil {
+		t.Fatalf("CreateCompany: %v", err)
+	}
+	t.Cleanup(func() { _, _ = c.DeleteContact(ctx, strconv.FormatInt(flexInt(row["id"]), 10)) })
+	lookup("ListContactTags after a contact write", c.ListContactTags, 1)
+	lookup("ListDealStages after a contact write", c.ListDealStages, 0)
+}
@@ -0,0 +1,375 @@
+package onlyoffice
+
+// Typed CRM models. The map-returning methods in crm.go stay the primary API
+// for cmd/oo; the typed variants here decode the same endpoints into structs
+// for callers that would otherwise reach for stringField/flexInt. OnlyOffice
+// is inconsistent about scalar encoding (ids arrive as 42 or "42", enums as
+// 1 or "Email"), so ids and enum-ish fields use the tolerant Flex* types.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// FlexInt is an integer that decodes from a JSON number, a numeric string,
+// "" or null (both 0). It encodes as a plain number.
+type FlexInt int64
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *FlexInt) UnmarshalJSON(data []byte) error {
+	s := strings.TrimSpace(string(unquoteFlex(data)))
+	if s == "" || s == "null" {
+		*n = 0
+		return nil
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		*n = FlexInt(i)
+		return nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("onlyoffice: cannot decode %s as integer", data)
+	}
+	*n = FlexInt(f)
+	return nil
+}
+
+// String returns the decimal form, or "" for 0 (the API's "unset").
+func (n FlexInt) String() string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.FormatInt(int64(n), 10)
+}
+
+// FlexFloat is a float64 that decodes from a JSON number, a numeric string,
+// "" or null.
+type FlexFloat float64
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *FlexFloat) UnmarshalJSON(data []byte) error {
+	s := strings.TrimSpace(string(unquoteFlex(data)))
+	if s == "" || s == "null" {
+		*f = 0
+		return nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("onlyoffice: cannot decode %s as number", data)
+	}
+	*f = FlexFloat(v)
+	return nil
+}
+
+// FlexString is a string that also accepts JSON numbers and booleans (kept
+// in their literal form) and null ("").
+type FlexString string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *FlexString) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*s = ""
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var v string
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		*s = FlexString(v)
+		return nil
+	}
+	*s = FlexString(data)
+	return nil
+}
+
+// unquoteFlex strips one pair of surrounding double quotes.
+func unquoteFlex(data []byte) []byte {
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		return data[1 : len(data)-1]
+	}
+	return data
+}
+
+// ContactInfo is one commonData row of a CRM contact (email, phone, …).
+type ContactInfo struct {
+	ID        FlexInt    `json:"id"`
+	InfoType  FlexString `json:"infoType"` // numeric code or name; see Kind
+	Category  FlexString `json:"category"`
+	Data      string     `json:"data"`
+	IsPrimary bool       `json:"isPrimary"`
+}
+
+// Kind returns the normalized info type ("email", "phone", …).
+func (i ContactInfo) Kind() string {
+	return NormalizeContactInfoType(string(i.InfoType))
+}
+
+// Address is a postal address attached to a CRM contact.
+type Address struct {
+	ID           FlexInt    `json:"id,omitempty"`
+	Street       string     `json:"street,omitempty"`
+	City         string     `json:"city,omitempty"`
+	State        string     `json:"state,omitempty"`
+	Zip          string     `json:"zip,omitempty"`
+	Country      string     `json:"country,omitempty"`
+	Category     FlexString `json:"category,omitempty"`
+	CategoryName string     `json:"categoryName,omitempty"`
+	IsPrimary    bool       `json:"isPrimary,omitempty"`
+}
+
+// CRMContact holds the fields persons and companies share. List endpoints
+// that mix both kinds decode into it; IsCompany tells them apart.
+type CRMContact struct {
+	ID          FlexInt       `json:"id"`
+	DisplayName string        `json:"displayName"`
+	IsCompany   bool          `json:"isCompany"`
+	IsPrivate   bool          `json:"isPrivate"`
+	About       string        `json:"about,omitempty"`
+	Industry    string        `json:"industry,omitempty"`
+	Tags        []string      `json:"tags,omitempty"`
+	CommonData  []ContactInfo `json:"commonData,omitempty"`
+	Addresses   []Address     `json:"addresses,omitempty"`
+	AvatarURL   string        `json:"mediumFotoUrl,omitempty"`
+	Created     *Time         `json:"created,omitempty"`
+	CreateBy    *User         `json:"createBy,omitempty"`
+}
+
+// Emails returns the contact's email addresses, primary first.
+func (c CRMContact) Emails() []string {
+	var primary, rest []string
+	for _, row := range c.CommonData {
+		if row.Kind() != "email" || strings.TrimSpace(row.Data) == "" {
+			continue
+		}
+		if row.IsPrimary {
+			primary = append(primary, row.Data)
+		} else {
+			rest = append(rest, row.Data)
+		}
+	}
+	return append(primary, rest...)
+}
+
+// Person is a CRM person contact.
+type Person struct {
+	CRMContact
+	FirstName string   `json:"firstName"`
+	LastName  string   `json:"lastName"`
+	Title     string   `json:"title,omitempty"` // job title
+	Company   *Company `json:"company,omitempty"`
+}
+
+// Company is a CRM company contact.
+type Company struct {
+	CRMContact
+	CompanyName  string  `json:"companyName"`
+	PersonsCount FlexInt `json:"personsCount,omitempty"`
+}
+
+// Currency is the bid currency of an opportunity.
+type Currency struct {
+	Abbreviation string `json:"abbreviation"`
+	Title        string `json:"title,omitempty"`
+	Symbol       string `json:"symbol,omitempty"`
+}
+
+// DealStage is an opportunity pipeline stage.
+type DealStage struct {
+	ID                 FlexInt   `json:"id"`
+	Title              string    `json:"title"`
+	Description        string    `json:"description,omitempty"`
+	Color              string    `json:"color,omitempty"`
+	SuccessProbability FlexFloat `json:"successProbability,omitempty"`
+	StageType          FlexInt   `json:"stageType,omitempty"` // 0 open, 1 won, 2 lost
+	SortOrder          FlexInt   `json:"sortOrder,omitempty"`
+}
+
+// Opportunity is a CRM deal.
+type Opportunity struct {
+	ID                 FlexInt      `json:"id"`
+	Title              string       `json:"title"`
+	Description        string       `json:"description,omitempty"`
+	Stage              *DealStage   `json:"stage,omitempty"`
+	Responsible        *User        `json:"responsible,omitempty"`
+	BidCurrency        *Currency    `json:"bidCurrency,omitempty"`
+	BidValue           FlexFloat    `json:"bidValue"`
+	BidType            FlexInt      `json:"bidType"`
+	PerPeriodValue     FlexInt      `json:"perPeriodValue"`
+	SuccessProbability FlexFloat    `json:"successProbability"`
+	ExpectedCloseDate  *Time        `json:"expectedCloseDate,omitempty"`
+	ActualCloseDate    *Time        `json:"actualCloseDate,omitempty"`
+	IsPrivate          bool         `json:"isPrivate"`
+	Contact            *CRMContact  `json:"contact,omitempty"`
+	Members            []CRMContact `json:"members,omitempty"`
+	Created            *Time        `json:"created,omitempty"`
+	CreateBy           *User        `json:"createBy,omitempty"`
+}
+
+// Case is a CRM case.
+type Case struct {
+	ID        FlexInt      `json:"id"`
+	Title     string       `json:"title"`
+	IsClosed  bool         `json:"isClosed"`
+	IsPrivate bool         `json:"isPrivate"`
+	Members   []CRMContact `json:"members,omitempty"`
+	Created   *Time        `json:"created,omitempty"`
+	CreateBy  *User        `json:"createBy,omitempty"`
+}
+
+// EntityRef points at the deal/case a task or history event is attached to.
+type EntityRef struct {
+	EntityType  string  `json:"entityType"`
+	EntityID    FlexInt `json:"entityId"`
+	EntityTitle string  `json:"entityTitle,omitempty"`
+}
+
+// CRMCategory is a task or history-event category.
+type CRMCategory struct {
+	ID          FlexInt `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description,omitempty"`
+	ImagePath   string  `json:"imagePath,omitempty"`
+}
+
+// CRMTask is a CRM task (reminder), distinct from project Task.
+type CRMTask struct {
+	ID          FlexInt      `json:"id"`
+	Title       string       `json:"title"`
+	Description string       `json:"description,omitempty"`
+	Deadline    *Time        `json:"deadLine,omitempty"`
+	IsClosed    bool         `json:"isClosed"`
+	AlertValue  FlexInt      `json:"alertValue,omitempty"`
+	Responsible *User        `json:"responsible,omitempty"`
+	Category    *CRMCategory `json:"category,omitempty"`
+	Contact     *CRMContact  `json:"contact,omitempty"`
+	Entity      *EntityRef   `json:"entity,omitempty"`
+	Created     *Time        `json:"created,omitempty"`
+	CreateBy    *User        `json:"createBy,omitempty"`
+}
+
+// HistoryEvent is one entry of a contact/deal/case history (notes, calls, …).
+type HistoryEvent struct {
+	ID       FlexInt      `json:"id"`
+	Content  string       `json:"content"`
+	Category *CRMCategory `json:"category,omitempty"`
+	Contact  *CRMContact  `json:"contact,omitempty"`
+	Entity   *EntityRef   `json:"entity,omitempty"`
+	Created  *Time        `json:"created,omitempty"`
+	CreateBy *User        `json:"createBy,omitempty"`
+}
+
+// decodeResponse unmarshals the "response" field of raw into out. A null
+// response leaves out untouched.
+func decodeResponse(raw json.RawMessage, out any) error {
+	resp, err := responseField(raw, "response")
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(resp)) == 0 || string(resp) == "null" {
+		return nil
+	}
+	return json.Unmarshal(resp, out)
+}
+
+// getTyped issues a GET and decodes the "response" field into a T.
+func getTyped[T any](ctx context.Context, c *Client, path string) (T, error) {
+	var out T
+	raw, err := c.getJSON(ctx, path)
+	if err != nil {
+		return out, err
+	}
+	err = decodeResponse(raw, &out)
+	return out, err
+}
+
+// filterPageOf is filterPage decoding rows into T.
+func filterPageOf[T any](ctx context.Context, c *Client, path string, extra url.Values, count, startIndex int) ([]T, int, error) {
+	q := url.Values{}
+	for k, v := range extra {
+		q[k] = v
+	}
+	q.Set("count", strconv.Itoa(count))
+	q.Set("startIndex", strconv.Itoa(startIndex))
+	raw, err := c.getJSON(ctx, path+"?"+q.Encode())
+	if err != nil {
+		return nil, 0, err
+	}
+	var env struct {
+		Response []T `json:"response"`
+		Total    int `json:"total"`
+	}
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return nil, 0, err
+	}
+	return env.Response, env.Total, nil
+}
+
+// GetPerson returns a person contact by id.
+func (c *Client) GetPerson(ctx context.Context, contactID string) (*Person, error) {
+	return getTyped[*Person](ctx, c, fmt.Sprintf("/api/2.0/crm/contact/%s.json", url.PathEscape(contactID)))
+}
+
+// GetCompany returns a company contact by id.
+func (c *Client) GetCompany(ctx context.Context, contactID string) (*Company, error) {
+	return getTyped[*Company](ctx, c, fmt.Sprintf("/api/2.0/crm/contact/%s.json", url.PathEscape(contactID)))
+}
+
+// ListPersons returns a page of person contacts and the total count.
+func (c *Client) ListPersons(ctx context.Context, count, startIndex int, search string) ([]Person, int, error) {
+	return filterPageOf[Person](ctx, c, "/api/2.0/crm/contact/filter.json", contactListQuery("person", search), count, startIndex)
+}
+
+// ListCompanies returns a page of company contacts and the total count.
+func (c *Client) ListCompanies(ctx context.Context, count, startIndex int, search string) ([]Company, int, error) {
+	return filterPageOf[Company](ctx, c, "/api/2.0/crm/contact/filter.json", contactListQuery("company", search), count, startIndex)
+}
+
+func contactListQuery(view, search string) url.Values {
+	q := url.Values{"contactListView": {view}}
+	if search != "" {
+		q.Set("filterValue", search)
+	}
+	return q
+}
+
+// GetOpportunityTyped is GetOpportunity decoded into an Opportunity.
+func (c *Client) GetOpportunityTyped(ctx context.Context, id string) (*Opportunity, error) {
+	return getTyped[*Opportunity](ctx, c, fmt.Sprintf("/api/2.0/crm/opportunity/%s.json", url.PathEscape(id)))
+}
+
+// ListOpportunitiesTyped is ListOpportunities decoded into Opportunity values.
+func (c *Client) ListOpportunitiesTyped(ctx context.Context, count, startIndex int) ([]Opportunity, int, error) {
+	return filterPageOf[Opportunity](ctx, c, "/api/2.0/crm/opportunity/filter.json", nil, count, startIndex)
+}
+
+// ListDealStagesTyped is ListDealStages decoded into DealStage values.
+func (c *Client) ListDealStagesTyped(ctx context.Context) ([]DealStage, error) {
+	return cached(ctx, c, CacheDealStages, "typed", func(ctx context.Context) ([]DealStage, error) {
+		return getTyped[[]DealStage](ctx, c, "/api/2.0/crm/opportunity/stage.json")
+	})
+}
+
+// ListCasesTyped is ListCases decoded into Case values.
+func (c *Client) ListCasesTyped(ctx context.Context, count, startIndex int) ([]Case, int, error) {
+	return filterPageOf[Case](ctx, c, "/api/2.0/crm/case/filter.json", nil, count, startIndex)
+}
+
+// ListCRMTasksTyped is ListCRMTasks decoded into CRMTask values.
+func (c *Client) ListCRMTasksTyped(ctx context.Context, count, startIndex int) ([]CRMTask, int, error) {
+	return filterPageOf[CRMTask](ctx, c, "/api/2.0/crm/task/filter.json", nil, count, startIndex)
+}
+
+// ListHistory returns the history events of a CRM entity. entityType is
+// "contact", "opportunity" or "case".
+func (c *Client) ListHistory(ctx context.Context, entityType string, entityID int) ([]HistoryEvent, error) {
+	q := url.Values{}
+	q.Set("entityType", entityType)
+	q.Set("entityId", strconv.Itoa(entityID))
+	return getTyped[[]HistoryEvent](ctx, c, "/api/2.0/crm/history/filter.json?"+q.Encode())
+}
@@ -0,0 +1,112 @@
+package onlyoffice
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestFlexIntDecodesMixedEncodings(t *testing.T) {
+	var got struct {
+		A, B, C, D, E FlexInt
+	}
+	in := `{"A": 42, "B": "17", "C": "", "D": null, "E": 3.0}`
+	if err := json.Unmarshal([]byte(in), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.A != 42 || got.B != 17 || got.C != 0 || got.D != 0 || got.E != 3 {
+		t.Fatalf("got %+v", got)
+	}
+	if err := json.Unmarshal([]byte(`"abc"`), &got.A); err == nil {
+		t.Fatal("want error for non-numeric string")
+	}
+	if got.B.String() != "17" || FlexInt(0).String() != "" {
+		t.Fatalf("String: %q %q", got.B.String(), FlexInt(0).String())
+	}
+}
+
+func TestFlexStringAcceptsNumbers(t *testing.T) {
+	var rows []ContactInfo
+	in := `[{"id":"5","infoType":1,"data":"a@b.c","isPrimary":true},{"id":6,"infoType":"Phone","data":"+1"}]`
+	if err := json.Unmarshal([]byte(in), &rows); err != nil {
+		t.Fatal(err)
+	}
+	if rows[0].ID != 5 || rows[0].InfoType != "1" || rows[0].Kind() != "email" {
+		t.Fatalf("row0 %+v", rows[0])
+	}
+	if rows[1].ID != 6 || rows[1].Kind() != "phone" {
+		t.Fatalf("row1 %+v", rows[1])
+	}
+}
+
+func TestPersonDecodesCompanyAndEmails(t *testing.T) {
+	raw := json.RawMessage(`{"response":{
+		"id":"101","displayName":"Ada Lovelace","isCompany":false,
+		"firstName":"Ada","lastName":"Lovelace","title":"Engineer",
+		"commonData":[
+			{"id":1,"infoType":"Email","data":"old@example.com","isPrimary":false},
+			{"id":2,"infoType":1,"data":"ada@example.com","isPrimary":true},
+			{"id":3,"infoType":2,"data":"+44"}],
+		"addresses":[{"city":"London","category":"0","isPrimary":true}],
+		"company":{"id":7,"displayName":"Analytical Engines","isCompany":true,"companyName":"Analytical Engines","personsCount":"3"},
+		"created":"2026-01-02T10:00:00.0000000+01:00"}}`)
+	var p *Person
+	if err := decodeResponse(raw, &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.ID != 101 || p.FirstName != "Ada" || p.Title != "Engineer" {
+		t.Fatalf("person %+v", p)
+	}
+	if p.Company == nil || p.Company.ID != 7 || p.Company.PersonsCount != 3 || !p.Company.IsCompany {
+		t.Fatalf("company %+v", p.Company)
+	}
+	emails := p.Emails()
+	if len(emails) != 2 || emails[0] != "ada@example.com" {
+		t.Fatalf("emails %v", emails)
+	}
+	if len(p.Addresses) != 1 || p.Addresses[0].City != "London" {
+		t.Fatalf("addresses %+v", p.Addresses)
+	}
+	if p.Created == nil || time.Time(*p.Created).Year() != 2026 {
+		t.Fatalf("created %v", p.Created)
+	}
+}
+
+func TestOpportunityDecodesStringNumbers(t *testing.T) {
+	in := `{"id":12,"title":"Deal","bidValue":"1500.50","bidType":"0",
+		"stage":{"id":"3","title":"Won","successProbability":100,"stageType":1},
+		"bidCurrency":{"abbreviation":"EUR"},
+		"members":[{"id":"4","displayName":"X"}],
+		"expectedCloseDate":"2026-03-01T00:00:00"}`
+	var o Opportunity
+	if err := json.Unmarshal([]byte(in), &o); err != nil {
+		t.Fatal(err)
+	}
+	if o.BidValue != 1500.5 || o.Stage.ID != 3 || o.Stage.StageType != 1 || o.BidCurrency.Abbreviation != "EUR" {
+		t.Fatalf("opp %+v", o)
+	}
+	if len(o.Members) != 1 || o.Members[0].ID != 4 {
+		t.Fatalf("members %+v", o.Members)
+	}
+	if o.ExpectedCloseDate == nil || time.Time(*o.ExpectedCloseDate).Month() != time.March {
+		t.Fatalf("close date %v", o.ExpectedCloseDate)
+	}
+}
+
+func TestCRMTaskAndHistoryDecode(t *testing.T) {
+	var task CRMTask
+	if err := json.Unmarshal([]byte(`{"id":"9","title":"Call","deadLine":"2026-05-01T09:00:00.0000000+02:00",
+		"category":{"id":2,"title":"Call"},"entity":{"entityType":"opportunity","entityId":"12"}}`), &task); err != nil {
+		t.Fatal(err)
+	}
+	if task.ID != 9 || task.Category.ID != 2 || task.Entity.EntityID != 12 || task.Deadline == nil {
+		t.Fatalf("task %+v", task)
+	}
+	var events []HistoryEvent
+	if err := json.Unmarshal([]byte(`[{"id":1,"content":"note","created":"","category":{"id":"-1","title":"Note"}}]`), &events); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Category.ID != -1 || !time.Time(*events[0].Created).IsZero() {
+		t.Fatalf("events %+v", events)
+	}
+}
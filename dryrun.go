@@ -0,0 +1,183 @@
+package onlyoffice
+
+// Dry-run mode. A DryRun middleware answers every mutating request itself
+// and records it in a plan, so any flow — CleanupCRM, MergeContacts, a
+// catalog import — can be previewed against the live portal: reads go
+// through, writes do not. Created objects get synthetic negative ids,
+// which later steps of the same flow carry along into the plan.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// PlannedRequest is one mutating request captured by a DryRun.
+type PlannedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"` // relative to the portal, e.g. /api/2.0/…
+	Query  string `json:"query,omitempty"`
+	// Body is the decoded payload: JSON as sent, form fields as an object,
+	// anything else (file uploads) as a short description.
+	Body any `json:"body,omitempty"`
+	// ID is the synthetic id handed back in the response.
+	ID int `json:"id"`
+}
+
+// String renders the request as "METHOD path?query body".
+func (p PlannedRequest) String() string {
+	s := p.Method + " " + p.Path
+	if p.Query != "" {
+		s += "?" + p.Query
+	}
+	switch b := p.Body.(type) {
+	case nil:
+	case string:
+		s += " " + b
+	default:
+		if j, err := json.Marshal(b); err == nil {
+			s += " " + string(j)
+		}
+	}
+	return s
+}
+
+// DryRun captures mutating requests instead of sending them. Install it
+// with WithDryRun; read the captured requests with Plan. Safe for
+// concurrent use.
+type DryRun struct {
+	mu     sync.Mutex
+	plan   []PlannedRequest
+	lastID int
+}
+
+// NewDryRun returns an empty plan.
+func NewDryRun() *DryRun { return &DryRun{} }
+
+// WithDryRun makes the client record every non-read request in d and
+// answer it with a synthetic success instead of sending it.
+// Authentication still reaches the portal, so reads see real data.
+// Captured writes are answered without waiting on WithRateLimiter.
+func WithDryRun(d *DryRun) Option {
+	return func(c *Client) {
+		c.dryRun = d
+		c.Use(d.middleware(c.apiPath))
+	}
+}
+
+// Plan returns the requests captured so far, in the order they were made.
+func (d *DryRun) Plan() []PlannedRequest {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]PlannedRequest(nil), d.plan...)
+}
+
+// Middleware returns the capturing middleware for a portal served from the
+// host root. WithDryRun installs one that also knows the portal's sub-path.
+func (d *DryRun) Middleware() Middleware {
+	return d.middleware(func(p string) string { return p })
+}
+
+// middleware captures non-read requests; apiPath strips the portal's
+// sub-path from request paths.
+func (d *DryRun) middleware(apiPath func(string) string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(r *http.Request) (*http.Response, error) {
+			path := apiPath(r.URL.Path)
+			if isReadRequest(r.Method, path) {
+				return next.RoundTrip(r)
+			}
+			return d.capture(r, path)
+		})
+	}
+}
+
+// isReadRequest reports whether a request cannot change portal state; path
+// is relative to the portal (see apiPath). The authentication POST counts
+// as a read: it only issues a token.
+func isReadRequest(method, path string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, "PROPFIND":
+		return true
+	}
+	return strings.HasPrefix(path, "/api/2.0/authentication")
+}
+
+func (d *DryRun) capture(r *http.Request, path string) (*http.Response, error) {
+	body, err := plannedBody(r)
+	if err != nil {
+		return nil, err
+	}
+	d.mu.Lock()
+	d.lastID--
+	p := PlannedRequest{Method: r.Method, Path: path, Query: r.URL.RawQuery, Body: body, ID: d.lastID}
+	d.plan = append(d.plan, p)
+	d.mu.Unlock()
+
+	// Echo the payload back as the created/updated object so callers that
+	// read fields off the response (id, title, …) keep working.
+	obj := map[string]any{}
+	if m, ok := body.(map[string]any); ok {
+		for k, v := range m {
+			obj[k] = v
+		}
+	}
+	obj["id"] = p.ID
+	raw, err := json.Marshal(map[string]any{"response": obj, "status": 0, "statusCode": http.StatusOK})
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Status:        "200 OK",
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(raw)),
+		ContentLength: int64(len(raw)),
+		Request:       r,
+	}, nil
+}
+
+// plannedBody decodes a request body for the plan.
+func plannedBody(r *http.Request) (any, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	raw, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil || len(raw) == 0 {
+		return nil, err
+	}
+	ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch ct {
+	case "application/json":
+		var v any
+		if json.Unmarshal(raw, &v) == nil {
+			return v, nil
+		}
+	case "application/x-www-form-urlencoded":
+		if form, err := url.ParseQuery(string(raw)); err == nil {
+			out := make(map[string]any, len(form))
+			for k, vals := range form {
+				if len(vals) == 1 {
+					out[k] = vals[0]
+				} else {
+					out[k] = vals
+				}
+			}
+			return out, nil
+		}
+	}
+	if ct == "" {
+		ct = "unknown type"
+	}
+	return fmt.Sprintf("<%d bytes, %s>", len(raw), ct), nil
+}
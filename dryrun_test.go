@@ -0,0 +1,72 @@
+package onlyoffice
+
+// Dry-run tests. Captured writes never reach the transport, so these run
+// on a client that fails on any request; reads and authentication passing
+// through to a live portal are covered by TestIntegrationDryRun.
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDryRunCapturesMutations(t *testing.T) {
+	ctx := context.Background()
+	plan := NewDryRun()
+	c := noRequests(t, WithDryRun(plan))
+
+	co, err := c.CreateCompany(ctx, "Acme GmbH")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if co["id"] != float64(-1) || co["companyName"] != "Acme GmbH" {
+		t.Fatalf("synthetic response = %v", co)
+	}
+	// The synthetic id flows into the next step of the same flow.
+	if _, err := c.AddContactInfo(ctx, "-1", "Email", "info@acme.example", "", true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.putJSONObject(ctx, "/api/2.0/crm/contact/merge.json", map[string]any{"fromContactId": 2, "toContactId": 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	steps := plan.Plan()
+	if len(steps) != 3 {
+		t.Fatalf("plan = %v", steps)
+	}
+	if s := steps[1].String(); !strings.HasPrefix(s, "POST /api/2.0/crm/contact/-1/data.json {") || !strings.Contains(s, `"data":"info@acme.example"`) {
+		t.Errorf("step 2 = %s", s)
+	}
+	if body, _ := steps[2].Body.(map[string]any); body["toContactId"] != float64(1) || steps[2].ID != -3 {
+		t.Errorf("step 3 = %+v", steps[2])
+	}
+}
+
+func TestDryRunUnderSubPath(t *testing.T) {
+	plan := NewDryRun()
+	c := noRequests(t, WithDryRun(plan))
+	c.credentials.Url = "http://portal.invalid/office"
+	if _, err := c.postJSON(context.Background(), "/api/2.0/crm/contact/person.json", map[string]any{"firstName": "A"}); err != nil {
+		t.Fatal(err)
+	}
+	if p := plan.Plan(); len(p) != 1 || p[0].Path != "/api/2.0/crm/contact/person.json" {
+		t.Fatalf("plan %+v", p)
+	}
+}
+
+func TestDryRunSkipsRateLimiter(t *testing.T) {
+	// One token per ~17 minutes: a captured write that waited would time out.
+	l := NewRateLimiter(RateLimit{Rate: 0.001, Burst: 1}, nil)
+	c := noRequests(t, WithDryRun(NewDryRun()), WithRateLimiter(l))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for range 3 {
+		if _, err := c.CreateCompany(ctx, "Acme GmbH"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if st := l.Stats(); len(st) != 0 {
+		t.Fatalf("captured writes went through the limiter: %+v", st)
+	}
+}
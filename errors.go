@@ -0,0 +1,112 @@
+package onlyoffice
+
+// Typed API errors. Every non-2xx response from the untyped helpers in
+// http.go, the Files/WebDAV helpers and attachment downloads surfaces as an
+// *APIError, so callers can branch with errors.Is / errors.As instead of
+// string-matching formatted messages.
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel targets for errors.Is. An *APIError matches the sentinel that
+// corresponds to its HTTP status (and, for validation, to the .NET exception
+// type OnlyOffice reports in the envelope).
+var (
+	ErrNotFound     = errors.New("onlyoffice: not found")
+	ErrUnauthorized = errors.New("onlyoffice: unauthorized")
+	ErrForbidden    = errors.New("onlyoffice: forbidden")
+	ErrRateLimited  = errors.New("onlyoffice: rate limited")
+	ErrValidation   = errors.New("onlyoffice: validation failed")
+
+	// ErrTFARequired is returned when the account has two-factor
+	// authentication enabled and no code was supplied (see WithTFACode).
+	ErrTFARequired = errors.New("onlyoffice: two-factor code required")
+)
+
+// APIError is a non-2xx response from the portal.
+type APIError struct {
+	Method     string // HTTP method of the failed request
+	Path       string // request path (without host), e.g. /api/2.0/crm/contact/1.json
+	StatusCode int    // HTTP status code
+
+	// Decoded OnlyOffice envelope; empty when the body was not JSON (for
+	// example an nginx HTML error page).
+	APIStatusCode int    // envelope "statusCode"
+	Message       string // envelope "error.message"
+	Type          string // envelope "error.type", e.g. System.ArgumentException
+	Stack         string // first line of envelope "error.stack", when sent
+
+	Body string // raw response body, truncated to 400 bytes
+}
+
+// Error formats as "METHOD path: status message", keeping the shape of the
+// historic fmt.Errorf messages.
+func (e *APIError) Error() string {
+	detail := e.Message
+	if detail == "" {
+		detail = e.Body
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s %s: %d %s", e.Method, e.Path, e.StatusCode, detail))
+}
+
+// Is maps the error onto the package sentinels.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || strings.HasSuffix(e.Type, "ItemNotFoundException")
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden || strings.HasSuffix(e.Type, "SecurityException")
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity ||
+			strings.HasPrefix(e.Type, "System.Argument")
+	}
+	return false
+}
+
+// newAPIError builds an *APIError from a failed response, decoding the
+// OnlyOffice error envelope when present:
+//
+//	{"status":1,"statusCode":400,"error":{"message":"…","type":"System.ArgumentException","stack":"…"}}
+func newAPIError(method, path string, status int, raw []byte) *APIError {
+	e := &APIError{
+		Method:     method,
+		Path:       path,
+		StatusCode: status,
+		Body:       truncate(string(raw), 400),
+	}
+	var env struct {
+		StatusCode int `json:"statusCode"`
+		Error      *struct {
+			Message string `json:"message"`
+			Type    string `json:"type"`
+			Stack   string `json:"stack"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(raw, &env) == nil {
+		e.APIStatusCode = env.StatusCode
+		if env.Error != nil {
+			e.Message = env.Error.Message
+			e.Type = env.Error.Type
+			e.Stack, _, _ = strings.Cut(strings.TrimSpace(env.Error.Stack), "\n")
+		}
+	}
+	return e
+}
+
+// AsAPIError reports whether err wraps an *APIError and returns it.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
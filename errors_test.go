@@ -0,0 +1,70 @@
+package onlyoffice
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNewAPIErrorDecodesEnvelope(t *testing.T) {
+	raw := []byte(`{"status":1,"statusCode":400,"error":{"message":"Value does not fall within the expected range.","type":"System.ArgumentException","stack":"   at ASC.Api.CRM.CRMApi.AddHistoryTo()\n   at next frame"}}`)
+	e := newAPIError("POST", "/api/2.0/crm/history.json", 400, raw)
+	if e.Message != "Value does not fall within the expected range." {
+		t.Errorf("message=%q", e.Message)
+	}
+	if e.Type != "System.ArgumentException" || e.APIStatusCode != 400 {
+		t.Errorf("type=%q apiStatus=%d", e.Type, e.APIStatusCode)
+	}
+	if e.Stack != "at ASC.Api.CRM.CRMApi.AddHistoryTo()" {
+		t.Errorf("stack hint=%q", e.Stack)
+	}
+	if got := e.Error(); got != "POST /api/2.0/crm/history.json: 400 Value does not fall within the expected range." {
+		t.Errorf("Error()=%q", got)
+	}
+}
+
+func TestNewAPIErrorNonJSONBody(t *testing.T) {
+	e := newAPIError("GET", "/api/2.0/files/1", 502, []byte("<html>Bad Gateway</html>"))
+	if e.Message != "" || e.Type != "" {
+		t.Errorf("unexpected envelope fields: %+v", e)
+	}
+	if !strings.Contains(e.Error(), "Bad Gateway") {
+		t.Errorf("Error() should fall back to body: %q", e.Error())
+	}
+}
+
+func TestAPIErrorSentinels(t *testing.T) {
+	cases := []struct {
+		err  *APIError
+		want error
+	}{
+		{&APIError{StatusCode: 404}, ErrNotFound},
+		{&APIError{StatusCode: 500, Type: "ASC.Web.Core.ItemNotFoundException"}, ErrNotFound},
+		{&APIError{StatusCode: 401}, ErrUnauthorized},
+		{&APIError{StatusCode: 403}, ErrForbidden},
+		{&APIError{StatusCode: 500, Type: "System.Security.SecurityException"}, ErrForbidden},
+		{&APIError{StatusCode: 429}, ErrRateLimited},
+		{&APIError{StatusCode: 400}, ErrValidation},
+		{&APIError{StatusCode: 500, Type: "System.ArgumentNullException"}, ErrValidation},
+	}
+	for _, tc := range cases {
+		wrapped := fmt.Errorf("ctx: %w", tc.err)
+		if !errors.Is(wrapped, tc.want) {
+			t.Errorf("%+v should match %v", tc.err, tc.want)
+		}
+	}
+	if errors.Is(&APIError{StatusCode: 500}, ErrNotFound) {
+		t.Error("plain 500 must not match ErrNotFound")
+	}
+}
+
+func TestAsAPIError(t *testing.T) {
+	if _, ok := AsAPIError(errors.New("plain")); ok {
+		t.Fatal("plain error reported as API error")
+	}
+	e, ok := AsAPIError(fmt.Errorf("wrap: %w", &APIError{StatusCode: 409}))
+	if !ok || e.StatusCode != 409 {
+		t.Fatalf("AsAPIError=%+v %v", e, ok)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 
 	onlyoffice "github.com/eslider/go-onlyoffice"
 )
@@ -35,13 +36,17 @@ func main() {
 	}
 	parentID := fmt.Sprint(parent["id"])
 	fmt.Printf("parent task id=%s\n", parentID)
+	taskID, err := strconv.Atoi(parentID)
+	if err != nil {
+		log.Fatalf("parent task id %q: %v", parentID, err)
+	}
 
 	for _, title := range []string{"first subtask", "second subtask"} {
-		st, err := client.AddSubtask(ctx, parentID, title)
+		st, err := client.CreateSubtask(ctx, taskID, title, "")
 		if err != nil {
 			log.Fatalf("add subtask %q: %v", title, err)
 		}
-		fmt.Printf("  + subtask id=%v title=%v\n", st["id"], st["title"])
+		fmt.Printf("  + subtask id=%v title=%v\n", st.ID, st.Title)
 	}
 
 	// Cleanup — uncomment once you verified the subtasks in the OnlyOffice UI.
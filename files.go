@@ -292,7 +292,7 @@ func (c *Client) DownloadFile(ctx context.Context, fileID string, dst io.Writer)
 		return 0, fmt.Errorf("file %s has no viewUrl", fileID)
 	}
 	downloadURL := c.resolveAPIURL(*f.ViewURL)
-	auth, err := c.authHeader()
+	auth, err := c.authHeader(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -301,14 +301,14 @@ func (c *Client) DownloadFile(ctx context.Context, fileID string, dst io.Writer)
 		return 0, err
 	}
 	req.Header.Set("Authorization", auth)
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return 0, err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode >= 400 {
 		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
-		return 0, fmt.Errorf("GET viewUrl: %d %s", resp.StatusCode, truncate(string(b), 400))
+		return 0, newAPIError(http.MethodGet, req.URL.Path, resp.StatusCode, b)
 	}
 	n, err := io.Copy(dst, resp.Body)
 	return n, err
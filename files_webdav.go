@@ -211,7 +211,7 @@ func (c *Client) DownloadDavFile(ctx context.Context, id string, w io.Writer) (i
 		return 0, fmt.Errorf("onlyoffice: file %s has no viewUrl", id)
 	}
 	u := c.resolveAPIURL(*file.ViewURL)
-	auth, err := c.authHeader()
+	auth, err := c.authHeader(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -220,13 +220,14 @@ func (c *Client) DownloadDavFile(ctx context.Context, id string, w io.Writer) (i
 		return 0, err
 	}
 	req.Header.Set("Authorization", auth)
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return 0, err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode >= 400 {
-		return 0, fmt.Errorf("onlyoffice: download: %d", resp.StatusCode)
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return 0, newAPIError(http.MethodGet, req.URL.Path, resp.StatusCode, b)
 	}
 	return io.Copy(w, resp.Body)
 }
@@ -243,7 +244,7 @@ func (c *Client) deleteJSON(ctx context.Context, path string, body any) (json.Ra
 		}
 		reader = bytes.NewReader(buf)
 	}
-	auth, err := c.authHeader()
+	auth, err := c.authHeader(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -254,7 +255,7 @@ func (c *Client) deleteJSON(ctx context.Context, path string, body any) (json.Ra
 	req.Header.Set("Authorization", auth)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -264,7 +265,7 @@ func (c *Client) deleteJSON(ctx context.Context, path string, body any) (json.Ra
 		return nil, err
 	}
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("DELETE %s: %d %s", path, resp.StatusCode, truncate(string(raw), 400))
+		return nil, newAPIError(http.MethodDelete, path, resp.StatusCode, raw)
 	}
 	return raw, nil
 }
@@ -283,7 +284,7 @@ func (c *Client) uploadReader(ctx context.Context, path, fieldName, fileName str
 	if err := mw.Close(); err != nil {
 		return nil, err
 	}
-	auth, err := c.authHeader()
+	auth, err := c.authHeader(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -294,7 +295,7 @@ func (c *Client) uploadReader(ctx context.Context, path, fieldName, fileName str
 	req.Header.Set("Authorization", auth)
 	req.Header.Set("Content-Type", mw.FormDataContentType())
 	req.Header.Set("Accept", "application/json")
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -304,7 +305,7 @@ func (c *Client) uploadReader(ctx context.Context, path, fieldName, fileName str
 		return nil, err
 	}
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("upload %s: %d %s", path, resp.StatusCode, truncate(string(raw), 400))
+		return nil, newAPIError(http.MethodPost, path, resp.StatusCode, raw)
 	}
 	return raw, nil
 }
@@ -25,6 +25,15 @@ func (c *Client) baseURL() string {
 	return strings.TrimRight(c.credentials.Url, "/")
 }
 
+// apiPath strips the base URL's own path (a portal under a sub-path) from
+// a request path.
+func (c *Client) apiPath(p string) string {
+	if u, err := url.Parse(c.baseURL()); err == nil && u.Path != "" {
+		return strings.TrimPrefix(p, u.Path)
+	}
+	return p
+}
+
 // truncate crops s to n runes, appending "..." when truncated. Used in error
 // messages to keep OnlyOffice HTML payloads readable.
 func truncate(s string, n int) string {
@@ -146,7 +155,7 @@ func unmarshalResponseObject(raw json.RawMessage) (map[string]any, error) {
 
 // getJSON issues an authenticated GET and returns the raw response body.
 func (c *Client) getJSON(ctx context.Context, path string) (json.RawMessage, error) {
-	auth, err := c.authHeader()
+	auth, err := c.authHeader(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -156,7 +165,7 @@ func (c *Client) getJSON(ctx context.Context, path string) (json.RawMessage, err
 	}
 	req.Header.Set("Authorization", auth)
 	req.Header.Set("Accept", "application/json")
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -166,7 +175,7 @@ func (c *Client) getJSON(ctx context.Context, path string) (json.RawMessage, err
 		return nil, err
 	}
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("GET %s: %d %s", path, resp.StatusCode, truncate(string(raw), 400))
+		return nil, newAPIError(http.MethodGet, path, resp.StatusCode, raw)
 	}
 	return raw, nil
 }
@@ -187,7 +196,7 @@ func (c *Client) deleteForm(ctx context.Context, path string, fields url.Values)
 }
 
 func (c *Client) formRequest(ctx context.Context, method, path string, fields url.Values) (json.RawMessage, error) {
-	auth, err := c.authHeader()
+	auth, err := c.authHeader(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -198,7 +207,7 @@ func (c *Client) formRequest(ctx context.Context, method, path string, fields ur
 	req.Header.Set("Authorization", auth)
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Accept", "application/json")
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -208,14 +217,14 @@ func (c *Client) formRequest(ctx context.Context, method, path string, fields ur
 		return nil, err
 	}
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("%s form %s: %d %s", method, path, resp.StatusCode, truncate(string(raw), 400))
+		return nil, newAPIError(method, path, resp.StatusCode, raw)
 	}
 	return raw, nil
 }
 
 // deleteReq issues an authenticated DELETE.
 func (c *Client) deleteReq(ctx context.Context, path string) (json.RawMessage, error) {
-	auth, err := c.authHeader()
+	auth, err := c.authHeader(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -225,7 +234,7 @@ func (c *Client) deleteReq(ctx context.Context, path string) (json.RawMessage, e
 	}
 	req.Header.Set("Authorization", auth)
 	req.Header.Set("Accept", "application/json")
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -235,7 +244,7 @@ func (c *Client) deleteReq(ctx context.Context, path string) (json.RawMessage, e
 		return nil, err
 	}
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("DELETE %s: %d %s", path, resp.StatusCode, truncate(string(raw), 400))
+		return nil, newAPIError(http.MethodDelete, path, resp.StatusCode, raw)
 	}
 	return raw, nil
 }
@@ -260,7 +269,7 @@ func (c *Client) postJSONObject(ctx context.Context, path string, body any) (map
 
 // postJSON issues an authenticated POST with application/json body.
 func (c *Client) postJSON(ctx context.Context, path string, body any) (json.RawMessage, error) {
-	auth, err := c.authHeader()
+	auth, err := c.authHeader(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -286,7 +295,7 @@ func (c *Client) postJSON(ctx context.Context, path string, body any) (json.RawM
 	req.Header.Set("Authorization", auth)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -296,14 +305,14 @@ func (c *Client) postJSON(ctx context.Context, path string, body any) (json.RawM
 		return nil, err
 	}
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("POST JSON %s: %d %s", path, resp.StatusCode, truncate(string(raw), 400))
+		return nil, newAPIError(http.MethodPost, path, resp.StatusCode, raw)
 	}
 	return raw, nil
 }
 
 // putJSON issues an authenticated PUT with application/json body.
 func (c *Client) putJSON(ctx context.Context, path string, body any) (json.RawMessage, error) {
-	auth, err := c.authHeader()
+	auth, err := c.authHeader(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -329,7 +338,7 @@ func (c *Client) putJSON(ctx context.Context, path string, body any) (json.RawMe
 	req.Header.Set("Authorization", auth)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -339,14 +348,14 @@ func (c *Client) putJSON(ctx context.Context, path string, body any) (json.RawMe
 		return nil, err
 	}
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("PUT JSON %s: %d %s", path, resp.StatusCode, truncate(string(raw), 400))
+		return nil, newAPIError(http.MethodPut, path, resp.StatusCode, raw)
 	}
 	return raw, nil
 }
 
 // uploadMultipart posts a single file to path under the given form field name.
 func (c *Client) uploadMultipart(ctx context.Context, path, fieldName, filePath string) (json.RawMessage, error) {
-	auth, err := c.authHeader()
+	auth, err := c.authHeader(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -375,7 +384,7 @@ func (c *Client) uploadMultipart(ctx context.Context, path, fieldName, filePath
 	req.Header.Set("Authorization", auth)
 	req.Header.Set("Content-Type", mw.FormDataContentType())
 	req.Header.Set("Accept", "application/json")
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -385,7 +394,7 @@ func (c *Client) uploadMultipart(ctx context.Context, path, fieldName, filePath
 		return nil, err
 	}
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("upload %s: %d %s", path, resp.StatusCode, truncate(string(raw), 400))
+		return nil, newAPIError(http.MethodPost, path, resp.StatusCode, raw)
 	}
 	return raw, nil
 }
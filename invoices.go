@@ -4,11 +4,12 @@ package onlyoffice
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
 	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // InvoiceLine is one line on a create-invoice request.
@@ -44,25 +45,14 @@ type CreateInvoiceParams struct {
 
 // ListInvoices returns a page of CRM invoices and the total count.
 func (c *Client) ListInvoices(ctx context.Context, count, startIndex int) ([]map[string]any, int, error) {
-	q := url.Values{}
-	q.Set("count", strconv.Itoa(count))
-	q.Set("startIndex", strconv.Itoa(startIndex))
-	raw, err := c.getJSON(ctx, "/api/2.0/crm/invoice/filter.json?"+q.Encode())
-	if err != nil {
-		return nil, 0, err
-	}
-	var env struct {
-		Response []map[string]any `json:"response"`
-		Total    int              `json:"total"`
-	}
-	if err := json.Unmarshal(raw, &env); err != nil {
-		return nil, 0, err
-	}
-	total := env.Total
-	if total == 0 && len(env.Response) > 0 {
-		total = len(env.Response)
-	}
-	return env.Response, total, nil
+	return pageTotal(c.filterPage(ctx, "/api/2.0/crm/invoice/filter.json", nil, count, startIndex))
+}
+
+// IterInvoices streams every CRM invoice page by page.
+func (c *Client) IterInvoices(ctx context.Context) *Pager[map[string]any] {
+	return NewPager(ctx, DefaultPageSize, func(ctx context.Context, count, start int) ([]map[string]any, int, error) {
+		return c.filterPage(ctx, "/api/2.0/crm/invoice/filter.json", nil, count, start)
+	})
 }
 
 // GetInvoice returns a single invoice by id (includes invoiceLines).
@@ -320,7 +310,9 @@ func (c *Client) ListOpportunityFiles(ctx context.Context, opportunityID string)
 
 // PurgeStaleInvoicePDFs deletes older P-*.pdf copies on the invoice contact (and linked
 // opportunity) while keeping the invoice's current fileID. Returns deleted file ids.
-func (c *Client) PurgeStaleInvoicePDFs(ctx context.Context, invoiceID string) ([]int, error) {
+func (c *Client) PurgeStaleInvoicePDFs(ctx context.Context, invoiceID string) (deleted []int, err error) {
+	ctx, end := c.StartSpan(ctx, "PurgeStaleInvoicePDFs", attribute.String("onlyoffice.invoice.id", invoiceID))
+	defer func() { end(err) }()
 	inv, err := c.GetInvoice(ctx, invoiceID)
 	if err != nil {
 		return nil, err
@@ -396,25 +388,14 @@ func (c *Client) PurgeStaleInvoicePDFs(ctx context.Context, invoiceID string) ([
 
 // ListInvoiceItems returns catalog invoice items.
 func (c *Client) ListInvoiceItems(ctx context.Context, count, startIndex int) ([]map[string]any, int, error) {
-	q := url.Values{}
-	q.Set("count", strconv.Itoa(count))
-	q.Set("startIndex", strconv.Itoa(startIndex))
-	raw, err := c.getJSON(ctx, "/api/2.0/crm/invoiceitem/filter.json?"+q.Encode())
-	if err != nil {
-		return nil, 0, err
-	}
-	var env struct {
-		Response []map[string]any `json:"response"`
-		Total    int              `json:"total"`
-	}
-	if err := json.Unmarshal(raw, &env); err != nil {
-		return nil, 0, err
-	}
-	total := env.Total
-	if total == 0 && len(env.Response) > 0 {
-		total = len(env.Response)
-	}
-	return env.Response, total, nil
+	return pageTotal(c.filterPage(ctx, "/api/2.0/crm/invoiceitem/filter.json", nil, count, startIndex))
+}
+
+// IterInvoiceItems streams the invoice item catalog page by page.
+func (c *Client) IterInvoiceItems(ctx context.Context) *Pager[map[string]any] {
+	return NewPager(ctx, DefaultPageSize, func(ctx context.Context, count, start int) ([]map[string]any, int, error) {
+		return c.filterPage(ctx, "/api/2.0/crm/invoiceitem/filter.json", nil, count, start)
+	})
 }
 
 // CreateInvoiceItem creates a reusable catalog line item.
@@ -0,0 +1,408 @@
+package onlyoffice
+
+// Audit journal. With WithJournal every create/update/delete/merge the
+// client sends is appended to a Journal together with who sent it, the
+// payload, the outcome and — where one GET is enough — a snapshot of the
+// affected objects taken just before the change. When a dedupe merge goes
+// wrong the journal says what was there before.
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Journal operations, derived from the request method and path.
+// JournalUndo marks the record Undo leaves of a reversed entry.
+const (
+	JournalCreate = "create"
+	JournalUpdate = "update"
+	JournalDelete = "delete"
+	JournalMerge  = "merge"
+	JournalUndo   = "undo"
+)
+
+// JournalEntry is one mutating request as recorded by the journal.
+type JournalEntry struct {
+	ID     string    `json:"id"`
+	Time   time.Time `json:"time"`
+	Portal string    `json:"portal"`
+	User   string    `json:"user,omitempty"`
+	Op     string    `json:"op"`
+	Method string    `json:"method"`
+	Path   string    `json:"path"`
+	Query  string    `json:"query,omitempty"`
+	// Body is the decoded payload (see PlannedRequest.Body) with password
+	// fields redacted.
+	Body   any    `json:"body,omitempty"`
+	Status int    `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// Response is the "response" field of the portal's answer, e.g. the
+	// created object with its id.
+	Response json.RawMessage `json:"response,omitempty"`
+	// Snapshot holds the "response" field of each GET taken before the
+	// change, keyed by API path (see JournalSnapshotPaths).
+	Snapshot map[string]json.RawMessage `json:"snapshot,omitempty"`
+	// UndoOf is the id of the entry a JournalUndo record reversed; Body
+	// then holds the UndoResult.
+	UndoOf string `json:"undoOf,omitempty"`
+}
+
+// OK reports whether the portal accepted the request.
+func (e *JournalEntry) OK() bool { return e.Error == "" && e.Status >= 200 && e.Status < 300 }
+
+// JournalFilter narrows Journal.List. The zero value lists everything.
+type JournalFilter struct {
+	Since  time.Time // only entries at or after Since
+	Op     string    // only this operation (JournalDelete, …)
+	Path   string    // only entries whose path contains Path
+	Limit  int       // at most the Limit most recent entries; 0 = all
+	Portal string    // only entries of this portal URL
+}
+
+func (f JournalFilter) match(e *JournalEntry) bool {
+	return (f.Since.IsZero() || !e.Time.Before(f.Since)) &&
+		(f.Op == "" || e.Op == f.Op) &&
+		(f.Path == "" || strings.Contains(e.Path, f.Path)) &&
+		(f.Portal == "" || e.Portal == f.Portal)
+}
+
+// Journal is an append-only log of mutations. List returns entries oldest
+// first; Get returns ErrNotFound for an unknown id.
+type Journal interface {
+	Append(e *JournalEntry) error
+	List(f JournalFilter) ([]JournalEntry, error)
+	Get(id string) (*JournalEntry, error)
+}
+
+// WithJournal records every mutating request the client sends in j. A
+// client in dry-run mode (WithDryRun) sends nothing and records nothing.
+// Journal errors never fail the request; they are logged via WithLogger.
+func WithJournal(j Journal) Option {
+	return func(c *Client) { c.journal = j }
+}
+
+// DefaultJournalPath returns $XDG_STATE_HOME/oo/journal.jsonl, falling back
+// to ~/.local/state/oo/journal.jsonl.
+func DefaultJournalPath() (string, error) {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "oo", "journal.jsonl"), nil
+}
+
+var (
+	journalIDMu   sync.Mutex
+	journalLastID string
+)
+
+const journalIDLayout = "20060102-150405.000000"
+
+// newJournalID returns a sortable id such as "20261016-142503.481922",
+// unique within the process even if the clock stalls or steps back.
+func newJournalID(t time.Time) string {
+	journalIDMu.Lock()
+	defer journalIDMu.Unlock()
+	id := t.UTC().Format(journalIDLayout)
+	if id <= journalLastID {
+		last, _ := time.Parse(journalIDLayout, journalLastID)
+		id = last.Add(time.Microsecond).Format(journalIDLayout)
+	}
+	journalLastID = id
+	return id
+}
+
+// journalOp classifies a mutating request.
+func journalOp(method, path string) string {
+	switch {
+	case strings.HasSuffix(strings.TrimSuffix(path, ".json"), "/crm/contact/merge"):
+		return JournalMerge
+	case method == http.MethodPost:
+		return JournalCreate
+	case method == http.MethodDelete:
+		return JournalDelete
+	}
+	return JournalUpdate
+}
+
+var (
+	journalContactPath = regexp.MustCompile(`^/api/2\.0/crm/contact/(?:person/|company/)?(\d+)(?:\.json)?$`)
+	journalOppPath     = regexp.MustCompile(`^/api/2\.0/crm/opportunity/(\d+)(?:\.json)?$`)
+	journalContactRow  = regexp.MustCompile(`^/api/2\.0/crm/contact/(\d+)/(?:data|address)/\d+(?:\.json)?$`)
+)
+
+// JournalContactPaths returns the snapshot paths taken before a CRM
+// contact is deleted or merged away: the contact itself (with contact
+// info, addresses and tags), its deals and, for a company, its persons.
+func JournalContactPaths(contactID string) []string {
+	id := url.PathEscape(contactID)
+	return []string{
+		"/api/2.0/crm/contact/" + id + ".json",
+		"/api/2.0/crm/opportunity/bycontact/" + id + ".json",
+		"/api/2.0/crm/contact/company/" + id + "/person.json",
+	}
+}
+
+// JournalSnapshotPaths returns the GETs that capture what a mutating
+// request is about to change. Updates and deletes of a single object
+// (".../{id}") snapshot that object; tag removals snapshot the tag list;
+// contact deletes and merges snapshot everything JournalContactPaths
+// lists (a merge also the surviving contact and its deals); deal updates
+// and deletes add the deal's members; removing contact info or an
+// address snapshots the contact. Creates need no snapshot.
+func JournalSnapshotPaths(method, path string, body any) []string {
+	op := journalOp(method, path)
+	if op == JournalCreate {
+		return nil
+	}
+	if op == JournalMerge {
+		fields, _ := body.(map[string]any)
+		var paths []string
+		if from := fmt.Sprint(fields["fromContactId"]); fields["fromContactId"] != nil {
+			paths = append(paths, JournalContactPaths(from)...)
+		}
+		if to := fmt.Sprint(fields["toContactId"]); fields["toContactId"] != nil {
+			paths = append(paths, JournalContactPaths(to)[:2]...)
+		}
+		return paths
+	}
+	if m := journalContactPath.FindStringSubmatch(path); m != nil {
+		if op == JournalDelete {
+			return JournalContactPaths(m[1])
+		}
+		return []string{"/api/2.0/crm/contact/" + m[1] + ".json"}
+	}
+	if m := journalOppPath.FindStringSubmatch(path); m != nil {
+		return []string{path, "/api/2.0/crm/opportunity/" + m[1] + "/contact.json"}
+	}
+	if m := journalContactRow.FindStringSubmatch(path); m != nil {
+		return []string{"/api/2.0/crm/contact/" + m[1] + ".json"}
+	}
+	if strings.HasSuffix(path, "/tag.json") && op == JournalDelete {
+		return []string{path}
+	}
+	if strings.HasSuffix(RouteTemplate(path), "/{id}") {
+		return []string{path}
+	}
+	return nil
+}
+
+// doJournaled is do for a mutating request with a journal configured:
+// snapshot, send, record.
+func (c *Client) doJournaled(req *http.Request) (*http.Response, error) {
+	now := time.Now()
+	path := c.apiPath(req.URL.Path)
+	e := &JournalEntry{
+		ID:     newJournalID(now),
+		Time:   now,
+		Portal: c.baseURL(),
+		User:   c.credentials.User,
+		Op:     journalOp(req.Method, path),
+		Method: req.Method,
+		Path:   path,
+		Query:  req.URL.RawQuery,
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			e.Body, _ = plannedBody(&http.Request{Header: req.Header, Body: body})
+			e.Body = redactPasswords(e.Body)
+		}
+	} else if req.Body != nil && req.Body != http.NoBody {
+		e.Body = "<streamed body>"
+	}
+	for _, p := range JournalSnapshotPaths(req.Method, path, e.Body) {
+		raw, err := c.getJSON(req.Context(), p)
+		if err != nil {
+			continue // not every object has every facet; the snapshot is best effort
+		}
+		if v, err := responseField(raw, "response"); err == nil {
+			if e.Snapshot == nil {
+				e.Snapshot = map[string]json.RawMessage{}
+			}
+			e.Snapshot[p] = v
+		}
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		e.Error = err.Error()
+	} else {
+		e.Status = resp.StatusCode
+		resp.Body, e.Response = journalResponse(resp)
+		if resp.StatusCode >= 400 {
+			e.Error = resp.Status
+		}
+	}
+	if jerr := c.journal.Append(e); jerr != nil && c.logger != nil {
+		c.logger.Warn("onlyoffice: journal append failed", "method", e.Method, "path", e.Path, "error", jerr)
+	}
+	return resp, err
+}
+
+// maxJournalResponse bounds the response kept per entry; bigger answers
+// (file downloads, bulk results) are not worth an audit line.
+const maxJournalResponse = 256 << 10
+
+// journalResponse reads a JSON response for the journal and returns a body
+// that replays it for the caller.
+func journalResponse(resp *http.Response) (io.ReadCloser, json.RawMessage) {
+	if ct := resp.Header.Get("Content-Type"); ct != "" && !strings.Contains(ct, "json") {
+		return resp.Body, nil
+	}
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxJournalResponse+1))
+	body := replayBody{io.MultiReader(bytes.NewReader(raw), resp.Body), resp.Body}
+	if err != nil || len(raw) > maxJournalResponse {
+		return body, nil
+	}
+	v, err := responseField(raw, "response")
+	if err != nil {
+		return body, nil
+	}
+	return body, v
+}
+
+// replayBody serves the bytes already read, then the rest of the original
+// body, and closes the original.
+type replayBody struct {
+	io.Reader
+	io.Closer
+}
+
+// redactPasswords blanks password-like fields of a decoded body.
+func redactPasswords(body any) any {
+	m, ok := body.(map[string]any)
+	if !ok {
+		return body
+	}
+	for k := range m {
+		if strings.Contains(strings.ToLower(k), "password") {
+			m[k] = "[REDACTED]"
+		}
+	}
+	return m
+}
+
+// FileJournal is a Journal in a JSON Lines file, one entry per line,
+// written with 0600 permissions. Appends from several processes interleave
+// whole lines.
+type FileJournal struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// NewFileJournal returns a journal at path, or at DefaultJournalPath when
+// path is empty.
+func NewFileJournal(path string) (*FileJournal, error) {
+	if path == "" {
+		p, err := DefaultJournalPath()
+		if err != nil {
+			return nil, err
+		}
+		path = p
+	}
+	return &FileJournal{Path: path}, nil
+}
+
+// Append implements Journal.
+func (j *FileJournal) Append(e *JournalEntry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(j.Path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(j.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// List implements Journal.
+func (j *FileJournal) List(f JournalFilter) ([]JournalEntry, error) {
+	var out []JournalEntry
+	err := j.scan(func(e *JournalEntry) bool {
+		if f.match(e) {
+			out = append(out, *e)
+		}
+		return true
+	})
+	if f.Limit > 0 && len(out) > f.Limit {
+		out = out[len(out)-f.Limit:]
+	}
+	return out, err
+}
+
+// Get implements Journal.
+func (j *FileJournal) Get(id string) (*JournalEntry, error) {
+	var found *JournalEntry
+	err := j.scan(func(e *JournalEntry) bool {
+		if e.ID == id {
+			found = e
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("journal entry %s: %w", id, ErrNotFound)
+	}
+	return found, nil
+}
+
+// scan calls fn for every entry until it returns false. A missing file is
+// an empty journal.
+func (j *FileJournal) scan(fn func(*JournalEntry) bool) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	f, err := os.Open(j.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64<<10), 16<<20)
+	for n := 1; sc.Scan(); n++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var e JournalEntry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return fmt.Errorf("journal %s:%d: %w", j.Path, n, err)
+		}
+		if !fn(&e) {
+			return nil
+		}
+	}
+	return sc.Err()
+}
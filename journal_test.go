@@ -0,0 +1,78 @@
+package onlyoffice
+
+// Journal tests without a portal: op classification, snapshot paths and
+// the file journal. Snapshots taken from a live portal are covered by
+// TestIntegrationJournalSnapshots.
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJournalOp(t *testing.T) {
+	for _, tc := range []struct{ method, path, want string }{
+		{"POST", "/api/2.0/crm/contact/company.json", JournalCreate},
+		{"PUT", "/api/2.0/crm/contact/company/7.json", JournalUpdate},
+		{"DELETE", "/api/2.0/crm/contact/7.json", JournalDelete},
+		{"PUT", "/api/2.0/crm/contact/merge.json", JournalMerge},
+		{"PUT", "/api/2.0/crm/contact/merge", JournalMerge},
+	} {
+		if got := journalOp(tc.method, tc.path); got != tc.want {
+			t.Errorf("journalOp(%s %s) = %s, want %s", tc.method, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestJournalSnapshotPaths(t *testing.T) {
+	for _, tc := range []struct {
+		method, path string
+		body         any
+		want         string
+	}{
+		{"POST", "/api/2.0/crm/contact/company.json", nil, ""},
+		{"PUT", "/api/2.0/crm/contact/company/7.json", nil, "/api/2.0/crm/contact/7.json"},
+		{"PUT", "/api/2.0/crm/contact/merge.json", map[string]any{"fromContactId": float64(2), "toContactId": float64(1)},
+			"/api/2.0/crm/contact/2.json /api/2.0/crm/opportunity/bycontact/2.json /api/2.0/crm/contact/company/2/person.json /api/2.0/crm/contact/1.json /api/2.0/crm/opportunity/bycontact/1.json"},
+		{"DELETE", "/api/2.0/crm/contact/3/data/17.json", nil, "/api/2.0/crm/contact/3.json"},
+		{"DELETE", "/api/2.0/crm/opportunity/9.json", nil, "/api/2.0/crm/opportunity/9.json /api/2.0/crm/opportunity/9/contact.json"},
+		{"DELETE", "/api/2.0/crm/contact/4/tag.json", nil, "/api/2.0/crm/contact/4/tag.json"},
+		{"PUT", "/api/2.0/project/task/12.json", nil, "/api/2.0/project/task/12.json"},
+	} {
+		if got := strings.Join(JournalSnapshotPaths(tc.method, tc.path, tc.body), " "); got != tc.want {
+			t.Errorf("%s %s = %q, want %q", tc.method, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestFileJournalListFilters(t *testing.T) {
+	j, _ := NewFileJournal(filepath.Join(t.TempDir(), "sub", "journal.jsonl"))
+	if got, err := j.List(JournalFilter{}); err != nil || len(got) != 0 {
+		t.Fatalf("missing file: %v, %v", got, err)
+	}
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	for i, op := range []string{JournalCreate, JournalUpdate, JournalDelete, JournalUpdate} {
+		at := base.Add(time.Duration(i) * time.Hour)
+		if err := j.Append(&JournalEntry{ID: at.Format(journalIDLayout), Time: at, Op: op, Path: "/api/2.0/crm/contact/1.json"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, _ := j.List(JournalFilter{Op: JournalUpdate}); len(got) != 2 {
+		t.Errorf("Op filter: %d entries", len(got))
+	}
+	if got, _ := j.List(JournalFilter{Since: base.Add(90 * time.Minute)}); len(got) != 2 {
+		t.Errorf("Since filter: %d entries", len(got))
+	}
+	got, _ := j.List(JournalFilter{Limit: 2})
+	if len(got) != 2 || got[0].Op != JournalDelete || got[1].Op != JournalUpdate {
+		t.Errorf("Limit keeps the newest, oldest first: %+v", got)
+	}
+	if e, err := j.Get(got[0].ID); err != nil || e.Op != JournalDelete {
+		t.Errorf("Get = %+v, %v", e, err)
+	}
+	if _, err := j.Get("nope"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(unknown) = %v, want ErrNotFound", err)
+	}
+}
@@ -55,36 +55,66 @@ func (c *Client) ListMailMessages(ctx context.Context, f MailMessagesFilter) ([]
 		return c.ResponseArray(ctx, mailMessagesPath(f, 1, want))
 	}
 
+	// Prefetch off: we stop as soon as want rows are collected, and a
+	// speculative request for the following page would be wasted.
+	f.Count = 0
+	p := c.IterMailMessages(ctx, f)
+	p.Prefetch = false
 	var out []map[string]any
-	toSkip := f.StartIndex
-	page := 1
-	for len(out) < want {
-		chunk, err := c.ResponseArray(ctx, mailMessagesPath(f, page, mailMessagesPageSize))
+	for row, err := range p.All() {
 		if err != nil {
 			return nil, err
 		}
-		if len(chunk) == 0 {
+		out = append(out, row)
+		if len(out) >= want {
 			break
 		}
-		if toSkip > 0 {
-			if toSkip >= len(chunk) {
-				toSkip -= len(chunk)
-				page++
-				continue
-			}
-			chunk = chunk[toSkip:]
-			toSkip = 0
-		}
-		pageLen := len(chunk)
-		need := want - len(out)
-		if len(chunk) > need {
-			chunk = chunk[:need]
+	}
+	return out, nil
+}
+
+// IterMailMessages streams the messages matching f page by page, starting
+// at f.StartIndex. f.Count sets the pager's PageSize (default and maximum:
+// the API's 25 per page). The mail API pages by page number; requests for
+// offsets that do not fall on a page boundary, or for more than 25 rows,
+// are served from as many API pages as needed. The endpoint reports no
+// total; Total stays 0.
+func (c *Client) IterMailMessages(ctx context.Context, f MailMessagesFilter) *Pager[map[string]any] {
+	size := f.Count
+	if size <= 0 || size > mailMessagesPageSize {
+		size = mailMessagesPageSize
+	}
+	return NewPager(ctx, size, func(ctx context.Context, count, start int) ([]map[string]any, int, error) {
+		rows, err := collectPages(f.StartIndex+start, count, mailMessagesPageSize, func(page, size int) ([]map[string]any, error) {
+			return c.ResponseArray(ctx, mailMessagesPath(f, page, size))
+		})
+		return rows, 0, err
+	})
+}
+
+// collectPages returns up to count rows from offset start of an API that
+// pages by 1-based page number with at most maxSize rows per page. It
+// reads as many pages as needed and drops the rows before start on the
+// first one.
+func collectPages[T any](start, count, maxSize int, fetch func(page, size int) ([]T, error)) ([]T, error) {
+	size := min(count, maxSize)
+	if size <= 0 {
+		return nil, nil
+	}
+	var out []T
+	for len(out) < count {
+		rows, err := fetch(start/size+1, size)
+		if err != nil {
+			return nil, err
 		}
-		out = append(out, chunk...)
-		if pageLen < mailMessagesPageSize {
+		short := len(rows) < size
+		rows = rows[min(start%size, len(rows)):]
+		rows = rows[:min(len(rows), count-len(out))]
+		out = append(out, rows...)
+		start += len(rows)
+		if short {
 			break
 		}
-		page++
 	}
 	return out, nil
 }
@@ -106,7 +136,7 @@ func (c *Client) DownloadMailAttachment(ctx context.Context, attachmentID string
 	if id == "" {
 		return nil, fmt.Errorf("DownloadMailAttachment: attachment id is required")
 	}
-	auth, err := c.authHeader()
+	auth, err := c.authHeader(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +145,7 @@ func (c *Client) DownloadMailAttachment(ctx context.Context, attachmentID string
 		return nil, err
 	}
 	req.Header.Set("Authorization", auth)
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -125,7 +155,7 @@ func (c *Client) DownloadMailAttachment(ctx context.Context, attachmentID string
 		return nil, err
 	}
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("DownloadMailAttachment %s: %d %s", id, resp.StatusCode, truncate(string(raw), 400))
+		return nil, newAPIError(http.MethodGet, req.URL.Path, resp.StatusCode, raw)
 	}
 	return raw, nil
 }
@@ -3,6 +3,7 @@ package onlyoffice
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/cookiejar"
 	"net/http/httptest"
@@ -48,6 +49,38 @@ func TestMailMessagesPath(t *testing.T) {
 	}
 }
 
+func TestCollectPages(t *testing.T) {
+	all := make([]int, 60)
+	for i := range all {
+		all[i] = i
+	}
+	var pages []string
+	fetch := func(page, size int) ([]int, error) {
+		pages = append(pages, fmt.Sprintf("%d/%d", page, size))
+		lo := min((page-1)*size, len(all))
+		return all[lo:min(lo+size, len(all))], nil
+	}
+	for _, tc := range []struct {
+		start, count int
+		want         []int
+		pages        string
+	}{
+		{0, 10, all[0:10], "[1/10]"},
+		{20, 10, all[20:30], "[3/10]"},
+		{5, 10, all[5:15], "[1/10 2/10]"},   // off a page boundary
+		{0, 40, all[0:40], "[1/25 2/25]"},   // more than one API page
+		{50, 25, all[50:60], "[3/25]"},      // short last page
+		{30, 25, all[30:55], "[2/25 3/25]"}, // both
+		{70, 5, nil, "[15/5]"},              // past the end
+	} {
+		pages = nil
+		got, err := collectPages(tc.start, tc.count, 25, fetch)
+		if err != nil || fmt.Sprint(got) != fmt.Sprint(tc.want) || fmt.Sprint(pages) != tc.pages {
+			t.Errorf("collectPages(%d, %d) = %v, %v via %v; want %v via %s", tc.start, tc.count, got, err, pages, tc.want, tc.pages)
+		}
+	}
+}
+
 func TestParseMailAddress(t *testing.T) {
 	tests := []struct {
 		raw         string
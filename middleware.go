@@ -0,0 +1,156 @@
+package onlyoffice
+
+// Request/response middleware. Every request the client sends — the http.go
+// helpers, Query, authentication, WebDAV transfers and mail attachment
+// downloads — goes through (*Client).do, which runs the middleware chain once
+// per attempt. Middleware therefore sees retries and re-auth replays as
+// separate round trips, after rate limiting and before the cookie jar.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Middleware wraps the next RoundTripper in the chain. Implementations may
+// inspect or modify the request, short-circuit with their own response, or
+// post-process the response. Clone the request (req.Clone) before changing
+// it if the change must not survive into a retry.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripFunc adapts a function to http.RoundTripper, which keeps simple
+// middleware to a closure:
+//
+//	func(next http.RoundTripper) http.RoundTripper {
+//		return onlyoffice.RoundTripFunc(func(r *http.Request) (*http.Response, error) {
+//			…
+//			return next.RoundTrip(r)
+//		})
+//	}
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (f RoundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// WithMiddleware appends mw to the client's chain. The first middleware
+// given is the outermost: it sees the request first and the response last.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) { c.Use(mw...) }
+}
+
+// Use is the method form of WithMiddleware. Like the other Set* methods it
+// must be called before the client is shared.
+func (c *Client) Use(mw ...Middleware) {
+	for _, m := range mw {
+		if m != nil {
+			c.middleware = append(c.middleware, m)
+		}
+	}
+}
+
+// roundTrip sends one attempt of req through the middleware chain. The
+// innermost handler is the client's http.Client, so cookies, redirects and
+// WithTimeout still apply.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	if len(c.middleware) == 0 {
+		return c.httpDo(req)
+	}
+	var rt http.RoundTripper = RoundTripFunc(c.httpDo)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		rt = c.middleware[i](rt)
+	}
+	return rt.RoundTrip(req)
+}
+
+// httpDo sends req with the client's http.Client, cancelling it when no
+// response headers arrive within the WithTimeout deadline. Once headers are
+// in, the body may take as long as it needs; closing it releases the timer's
+// context.
+func (c *Client) httpDo(req *http.Request) (*http.Response, error) {
+	if c.timeout <= 0 {
+		return c.client.Do(req)
+	}
+	ctx, cancel := context.WithCancelCause(req.Context())
+	timer := time.AfterFunc(c.timeout, func() { cancel(errResponseTimeout) })
+	resp, err := c.client.Do(req.WithContext(ctx))
+	timer.Stop()
+	if err != nil {
+		if context.Cause(ctx) == errResponseTimeout {
+			err = &responseTimeoutError{method: req.Method, path: req.URL.Path, after: c.timeout}
+		}
+		cancel(nil)
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: func() { cancel(nil) }}
+	return resp, nil
+}
+
+var errResponseTimeout = errors.New("no response headers in time")
+
+// responseTimeoutError is returned when WithTimeout expires before the
+// portal answers. It is a net.Error with Timeout() true.
+type responseTimeoutError struct {
+	method, path string
+	after        time.Duration
+}
+
+func (e *responseTimeoutError) Error() string {
+	return fmt.Sprintf("%s %s: no response within %v", e.method, e.path, e.after)
+}
+func (e *responseTimeoutError) Timeout() bool   { return true }
+func (e *responseTimeoutError) Temporary() bool { return true }
+
+// cancelOnClose releases a request context when the body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel func()
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// HeaderMiddleware sets the given headers on every request that does not
+// already carry them, e.g. a tenant id or a reverse-proxy secret.
+func HeaderMiddleware(h http.Header) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(r *http.Request) (*http.Response, error) {
+			for k, vals := range h {
+				if r.Header.Get(k) != "" {
+					continue
+				}
+				for _, v := range vals {
+					r.Header.Add(k, v)
+				}
+			}
+			return next.RoundTrip(r)
+		})
+	}
+}
+
+// sensitiveHeaders are masked by RedactHeaders.
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Proxy-Authorization"}
+
+// RedactHeaders returns a copy of h with credentials (Authorization,
+// cookies) replaced by "REDACTED", for audit or debug middleware that logs
+// headers.
+func RedactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	for _, k := range sensitiveHeaders {
+		if _, ok := out[http.CanonicalHeaderKey(k)]; ok {
+			out.Set(k, "REDACTED")
+		}
+	}
+	for k := range out {
+		if strings.HasPrefix(strings.ToLower(k), "x-auth") {
+			out.Set(k, "REDACTED")
+		}
+	}
+	return out
+}
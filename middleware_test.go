@@ -0,0 +1,94 @@
+package onlyoffice
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMiddlewareOrderAndPerAttempt(t *testing.T) {
+	var trace []string
+	mw := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripFunc(func(r *http.Request) (*http.Response, error) {
+				trace = append(trace, name+">")
+				resp, err := next.RoundTrip(r)
+				trace = append(trace, "<"+name)
+				return resp, err
+			})
+		}
+	}
+	calls := 0
+	c := stubClient(func(r *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return stubResponse(http.StatusServiceUnavailable, nil), nil
+		}
+		return stubResponse(http.StatusOK, nil), nil
+	}, RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond})
+	c.Use(mw("a"), mw("b"))
+
+	req, _ := http.NewRequest(http.MethodGet, "http://portal.invalid/api/2.0/people/@self.json", nil)
+	resp, err := c.do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("resp=%v err=%v", resp, err)
+	}
+	got := strings.Join(trace, " ")
+	want := "a> b> <b <a a> b> <b <a"
+	if got != want {
+		t.Fatalf("trace %q, want %q", got, want)
+	}
+}
+
+func TestMiddlewareCanShortCircuit(t *testing.T) {
+	c := stubClient(func(r *http.Request) (*http.Response, error) {
+		t.Fatal("transport reached")
+		return nil, nil
+	}, RetryPolicy{})
+	c.Use(func(http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(r *http.Request) (*http.Response, error) {
+			return stubResponse(http.StatusTeapot, nil), nil
+		})
+	})
+	err := c.QueryContext(context.Background(), Request{Uri: "/x", NoAuth: true}, nil)
+	if apiErr, ok := AsAPIError(err); !ok || apiErr.StatusCode != http.StatusTeapot {
+		t.Fatalf("err=%v", err)
+	}
+}
+
+func TestHeaderMiddlewareKeepsExisting(t *testing.T) {
+	var seen http.Header
+	c := stubClient(func(r *http.Request) (*http.Response, error) {
+		seen = r.Header.Clone()
+		return stubResponse(http.StatusOK, nil), nil
+	}, RetryPolicy{})
+	c.Use(HeaderMiddleware(http.Header{"X-Tenant": {"acme"}, "Accept": {"text/plain"}}))
+
+	err := c.QueryContext(context.Background(), Request{Uri: "/x", NoAuth: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seen.Get("X-Tenant") != "acme" || seen.Get("Accept") != "application/json" {
+		t.Fatalf("headers %v", seen)
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	h := http.Header{
+		"Authorization": {"secret-token"},
+		"Cookie":        {"asc_auth_key=secret"},
+		"X-Auth-Token":  {"secret"},
+		"Accept":        {"application/json"},
+	}
+	r := RedactHeaders(h)
+	for _, k := range []string{"Authorization", "Cookie", "X-Auth-Token"} {
+		if r.Get(k) != "REDACTED" {
+			t.Fatalf("%s=%q", k, r.Get(k))
+		}
+	}
+	if r.Get("Accept") != "application/json" || h.Get("Authorization") != "secret-token" {
+		t.Fatalf("redacted=%v original=%v", r, h)
+	}
+}
@@ -0,0 +1,140 @@
+package onlyoffice
+
+// Milestone management beyond create/delete (projects.go): reading and
+// updating a milestone, opening/closing it, and the tasks planned for it.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MilestoneStatus is the open/closed state of a milestone. Unlike tasks,
+// milestones count from 0.
+type MilestoneStatus int
+
+const (
+	MilestoneOpen   MilestoneStatus = 0
+	MilestoneClosed MilestoneStatus = 1
+)
+
+// ParseMilestoneStatus accepts "open", "closed" or the numeric codes.
+func ParseMilestoneStatus(s string) (MilestoneStatus, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "open", "0":
+		return MilestoneOpen, nil
+	case "closed", "close", "1":
+		return MilestoneClosed, nil
+	}
+	return 0, fmt.Errorf("unknown milestone status %q (want open or closed)", s)
+}
+
+// MilestoneUpdate lists the milestone fields to change; zero values keep
+// the current ones.
+type MilestoneUpdate struct {
+	Title         string
+	Deadline      time.Time
+	Description   *string
+	IsKey         *bool
+	IsNotify      *bool
+	ResponsibleID string
+}
+
+// GetMilestone returns one milestone.
+// GET /api/2.0/project/milestone/{id}
+func (c *Client) GetMilestone(ctx context.Context, id int64) (*Milestone, error) {
+	raw, err := c.getJSON(ctx, fmt.Sprintf("/api/2.0/project/milestone/%d.json", id))
+	if err != nil {
+		return nil, err
+	}
+	out := new(Milestone)
+	return out, decodeResponse(raw, out)
+}
+
+// UpdateMilestone changes the title, deadline, description, key flag or
+// responsible of a milestone. The portal expects the full record on every
+// update, so the current milestone is read first and merged with u.
+// PUT /api/2.0/project/milestone/{id}
+func (c *Client) UpdateMilestone(ctx context.Context, id int64, u MilestoneUpdate) (*Milestone, error) {
+	cur, err := c.GetMilestone(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]any{"title": u.Title}
+	if u.Title == "" && cur.Title != nil {
+		body["title"] = *cur.Title
+	}
+	switch {
+	case !u.Deadline.IsZero():
+		body["deadline"] = Time(u.Deadline)
+	case cur.Deadline != nil:
+		body["deadline"] = Time(*cur.Deadline)
+	}
+	if d := pick(u.Description, cur.Description); d != nil {
+		body["description"] = *d
+	}
+	if k := pick(u.IsKey, cur.IsKey); k != nil {
+		body["isKey"] = *k
+	}
+	if n := pick(u.IsNotify, cur.IsNotify); n != nil {
+		body["isNotify"] = *n
+	}
+	switch {
+	case u.ResponsibleID != "":
+		body["responsible"] = u.ResponsibleID
+	case cur.Responsible != nil && cur.Responsible.ID != nil:
+		body["responsible"] = *cur.Responsible.ID
+	}
+	raw, err := c.putJSON(ctx, fmt.Sprintf("/api/2.0/project/milestone/%d.json", id), body)
+	if err != nil {
+		return nil, err
+	}
+	out := new(Milestone)
+	return out, decodeResponse(raw, out)
+}
+
+// pick returns v when set, else def.
+func pick[T any](v, def *T) *T {
+	if v != nil {
+		return v
+	}
+	return def
+}
+
+// SetMilestoneStatus opens or closes a milestone. The portal refuses to
+// close a milestone that still has open tasks.
+// PUT /api/2.0/project/milestone/{id}/status
+func (c *Client) SetMilestoneStatus(ctx context.Context, id int64, status MilestoneStatus) (*Milestone, error) {
+	raw, err := c.putJSON(ctx, fmt.Sprintf("/api/2.0/project/milestone/%d/status.json", id),
+		map[string]any{"status": status})
+	if err != nil {
+		return nil, err
+	}
+	out := new(Milestone)
+	return out, decodeResponse(raw, out)
+}
+
+// ListMilestoneTasks returns the tasks planned for a milestone.
+// GET /api/2.0/project/milestone/{id}/task
+func (c *Client) ListMilestoneTasks(ctx context.Context, id int64) ([]*Task, error) {
+	raw, err := c.getJSON(ctx, fmt.Sprintf("/api/2.0/project/milestone/%d/task.json", id))
+	if err != nil {
+		return nil, err
+	}
+	var out []*Task
+	return out, decodeResponse(raw, &out)
+}
+
+// MoveTaskToMilestone plans taskID for milestoneID; 0 takes the task out of
+// its milestone.
+// PUT /api/2.0/project/task/{taskid}/milestone
+func (c *Client) MoveTaskToMilestone(ctx context.Context, taskID int, milestoneID int64) (*Task, error) {
+	raw, err := c.putJSON(ctx, fmt.Sprintf("/api/2.0/project/task/%d/milestone.json", taskID),
+		map[string]any{"milestoneid": milestoneID})
+	if err != nil {
+		return nil, err
+	}
+	out := new(Task)
+	return out, decodeResponse(raw, out)
+}
@@ -0,0 +1,14 @@
+package onlyoffice
+
+import "testing"
+
+func TestParseMilestoneStatus(t *testing.T) {
+	for in, want := range map[string]MilestoneStatus{"open": MilestoneOpen, "Closed": MilestoneClosed, "1": MilestoneClosed} {
+		if got, err := ParseMilestoneStatus(in); err != nil || got != want {
+			t.Errorf("ParseMilestoneStatus(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParseMilestoneStatus("done"); err == nil {
+		t.Error("unknown status must fail")
+	}
+}
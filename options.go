@@ -0,0 +1,89 @@
+package onlyoffice
+
+// Functional options for NewClient. Options run after the defaults are in
+// place and in the order given, so each one only overrides what it names.
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures a Client at construction time. Pass any number to
+// NewClient; the plain NewClient(creds) form keeps working unchanged.
+type Option func(*Client)
+
+// WithRateLimiter throttles every request the client sends through l. The
+// same limiter may be shared by several clients to enforce a portal-wide
+// budget. Use l.Stats() to see how long requests waited.
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(c *Client) { c.limiter = l }
+}
+
+// WithHTTPClient uses hc for all requests (proxies, custom TLS roots,
+// instrumentation). The client is copied; when it has no cookie jar the
+// default jar is kept, because DownloadMailAttachment authenticates via the
+// session cookie.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		if hc == nil {
+			return
+		}
+		cp := *hc
+		if cp.Jar == nil {
+			cp.Jar = c.client.Jar
+		}
+		c.client = &cp
+	}
+}
+
+// WithTransport replaces the RoundTripper of the underlying http.Client,
+// e.g. an *http.Transport with custom TLSClientConfig for a self-hosted
+// portal or a Proxy func.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) { c.client.Transport = rt }
+}
+
+// WithTimeout bounds how long each attempt may wait for the portal's
+// response headers, connection setup included. Reading the body is not
+// bounded, so large downloads and uploads are not cut off; a stalled
+// attempt fails with a net.Error whose Timeout() is true and is retried like
+// any other timeout. Zero means no timeout (the default). For per-call
+// deadlines pass a context with a deadline to the ctx-aware methods instead.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) { c.timeout = d }
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) { c.userAgent = ua }
+}
+
+// WithBaseURL overrides the portal URL from Credentials.Url, e.g. to reach
+// the portal over an internal hostname.
+func WithBaseURL(u string) Option {
+	return func(c *Client) { c.credentials.Url = strings.TrimRight(u, "/") }
+}
+
+// WithDefaults is the constructor form of SetDefaults.
+func WithDefaults(d Defaults) Option {
+	return func(c *Client) { c.defaults = d }
+}
+
+// WithRetryPolicy is the constructor form of SetRetryPolicy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) { c.retry = p }
+}
+
+// WithConcurrency is the constructor form of SetConcurrency.
+func WithConcurrency(n int) Option {
+	return func(c *Client) { c.parallelism = n }
+}
+
+// WithLogger logs every attempt at Debug level (method, path, status,
+// duration) and retries at Warn level. Authorization headers are never
+// logged. A nil logger disables logging (the default).
+func WithLogger(l *slog.Logger) Option {
+	return func(c *Client) { c.logger = l }
+}
@@ -0,0 +1,115 @@
+package onlyoffice
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewClientWithoutOptionsKeepsCookieJar(t *testing.T) {
+	c := NewClient(Credentials{Url: "http://portal.invalid"})
+	if c.client.Jar == nil {
+		t.Fatal("default client must have a cookie jar")
+	}
+	if c.client.Timeout != 0 || c.timeout != 0 {
+		t.Fatalf("default timeout=%v/%v", c.client.Timeout, c.timeout)
+	}
+}
+
+func TestWithHTTPClientCopiesAndKeepsJar(t *testing.T) {
+	hc := &http.Client{Timeout: 3 * time.Second}
+	c := NewClient(Credentials{}, WithHTTPClient(hc))
+	if c.client == hc {
+		t.Fatal("caller's http.Client must not be mutated in place")
+	}
+	if c.client.Jar == nil || hc.Jar != nil {
+		t.Fatal("jar should be added to the copy only")
+	}
+	if c.client.Timeout != 3*time.Second {
+		t.Fatalf("timeout=%v", c.client.Timeout)
+	}
+}
+
+func TestOptionsApplyInOrder(t *testing.T) {
+	d := Defaults{CalendarID: "7", ProjectID: "9"}
+	c := NewClient(Credentials{Url: "http://a"},
+		WithBaseURL("https://b.example/"),
+		WithTimeout(time.Second),
+		WithDefaults(d),
+		WithRetryPolicy(DefaultRetryPolicy()),
+		WithConcurrency(3),
+	)
+	if c.baseURL() != "https://b.example" {
+		t.Errorf("baseURL=%q", c.baseURL())
+	}
+	if c.timeout != time.Second {
+		t.Errorf("timeout=%v", c.timeout)
+	}
+	if c.defaults != d || c.retry.MaxAttempts != 4 || c.Concurrency() != 3 {
+		t.Errorf("defaults=%+v retry=%+v concurrency=%d", c.defaults, c.retry, c.Concurrency())
+	}
+}
+
+func TestWithUserAgentAndTransport(t *testing.T) {
+	var gotUA string
+	rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		gotUA = r.Header.Get("User-Agent")
+		return stubResponse(http.StatusOK, nil), nil
+	})
+	c := NewClient(Credentials{Url: "http://portal.invalid"}, WithTransport(rt), WithUserAgent("oo-test/1"))
+	req, _ := http.NewRequest(http.MethodGet, "http://portal.invalid/x", nil)
+	if _, err := c.do(req); err != nil {
+		t.Fatal(err)
+	}
+	if gotUA != "oo-test/1" {
+		t.Fatalf("User-Agent=%q", gotUA)
+	}
+}
+
+// slowReader delivers its payload only after a delay, like a large
+// download trickling in, and fails once the request context is cancelled
+// as a real transport's body would.
+type slowReader struct {
+	ctx   context.Context
+	r     io.Reader
+	delay time.Duration
+}
+
+func (s *slowReader) Read(p []byte) (int, error) {
+	time.Sleep(s.delay)
+	if err := s.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return s.r.Read(p)
+}
+
+func TestWithTimeoutBoundsHeadersNotBody(t *testing.T) {
+	ctx := context.Background()
+	stalled := NewClient(Credentials{Url: "http://portal.invalid", Token: "tok"},
+		WithTimeout(20*time.Millisecond),
+		WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			<-r.Context().Done()
+			return nil, r.Context().Err()
+		})))
+	_, err := stalled.getJSON(ctx, "/api/2.0/people/@self.json")
+	var ne net.Error
+	if !errors.As(err, &ne) || !ne.Timeout() {
+		t.Fatalf("stalled portal: err = %v, want a timeout net.Error", err)
+	}
+
+	slow := NewClient(Credentials{Url: "http://portal.invalid", Token: "tok"},
+		WithTimeout(20*time.Millisecond),
+		WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			resp := stubResponse(http.StatusOK, nil)
+			resp.Body = io.NopCloser(&slowReader{r.Context(), strings.NewReader(`{"response":{"id":"u1"}}`), 60 * time.Millisecond})
+			return resp, nil
+		})))
+	if _, err := slow.getJSON(ctx, "/api/2.0/people/@self.json"); err != nil {
+		t.Fatalf("slow body cut off: %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package onlyoffice
+
+// Streaming pagination. The CRM filter endpoints (and, with page numbers
+// instead of offsets, the mail message list) all page with count/startIndex;
+// Pager turns any of them into an iter.Seq2 so callers can range over rows
+// without loading the whole list and stop early with a plain break.
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// DefaultPageSize is the page size used by the Iter* constructors.
+const DefaultPageSize = 100
+
+// PageFunc fetches count rows starting at startIndex and reports the total
+// row count from the response envelope (0 when the endpoint does not send
+// one).
+type PageFunc[T any] func(ctx context.Context, count, startIndex int) (rows []T, total int, err error)
+
+// Pager streams a paged list endpoint. Configure PageSize and Prefetch
+// before calling All; a Pager may be iterated more than once (each All
+// starts from the first page).
+type Pager[T any] struct {
+	PageSize int  // rows per request; <= 0 means DefaultPageSize
+	Prefetch bool // fetch page N+1 while the caller consumes page N
+
+	ctx   context.Context
+	fetch PageFunc[T]
+
+	mu    sync.Mutex
+	total int
+}
+
+// NewPager returns a pager over fetch with prefetching enabled.
+func NewPager[T any](ctx context.Context, pageSize int, fetch PageFunc[T]) *Pager[T] {
+	return &Pager[T]{PageSize: pageSize, Prefetch: true, ctx: ctx, fetch: fetch, total: -1}
+}
+
+// Total returns the row count reported by the most recent page, or -1
+// before the first page has been fetched. Endpoints without a total in
+// their envelope report 0.
+func (p *Pager[T]) Total() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.total
+}
+
+type pageResult[T any] struct {
+	rows  []T
+	total int
+	err   error
+}
+
+// All yields every row in order. A fetch error is yielded once (with the
+// zero T) and ends the iteration. Breaking out of the loop cancels an
+// in-flight prefetch.
+func (p *Pager[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		ctx, cancel := context.WithCancel(p.ctx)
+		defer cancel()
+		size := p.PageSize
+		if size <= 0 {
+			size = DefaultPageSize
+		}
+		load := func(start int) <-chan pageResult[T] {
+			ch := make(chan pageResult[T], 1) // buffered: an abandoned prefetch never blocks
+			run := func() {
+				rows, total, err := p.fetch(ctx, size, start)
+				ch <- pageResult[T]{rows, total, err}
+			}
+			if p.Prefetch {
+				go run()
+			} else {
+				run()
+			}
+			return ch
+		}
+
+		next := load(0)
+		for start := 0; ; {
+			res := <-next
+			if res.err != nil {
+				var zero T
+				yield(zero, res.err)
+				return
+			}
+			p.mu.Lock()
+			p.total = res.total
+			p.mu.Unlock()
+
+			end := start + len(res.rows)
+			more := len(res.rows) >= size && (res.total <= 0 || end < res.total)
+			if more && p.Prefetch {
+				next = load(end)
+			}
+			for _, row := range res.rows {
+				if !yield(row, nil) {
+					return
+				}
+			}
+			if !more {
+				return
+			}
+			if !p.Prefetch {
+				next = load(end)
+			}
+			start = end
+		}
+	}
+}
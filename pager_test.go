@@ -0,0 +1,131 @@
+package onlyoffice
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// fakePages serves rows 0..n-1 in count/startIndex pages and records the
+// requested offsets.
+type fakePages struct {
+	mu     sync.Mutex
+	n      int
+	starts []int
+	failAt int // startIndex that returns an error; -1 = never
+}
+
+func (f *fakePages) fetch(_ context.Context, count, start int) ([]int, int, error) {
+	f.mu.Lock()
+	f.starts = append(f.starts, start)
+	f.mu.Unlock()
+	if start == f.failAt {
+		return nil, 0, errors.New("boom")
+	}
+	var rows []int
+	for i := start; i < start+count && i < f.n; i++ {
+		rows = append(rows, i)
+	}
+	return rows, f.n, nil
+}
+
+func (f *fakePages) requested() []int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]int(nil), f.starts...)
+}
+
+func TestPagerYieldsAllRowsInOrder(t *testing.T) {
+	for _, prefetch := range []bool{true, false} {
+		f := &fakePages{n: 23, failAt: -1}
+		p := NewPager(context.Background(), 5, f.fetch)
+		p.Prefetch = prefetch
+		if p.Total() != -1 {
+			t.Fatalf("Total before fetch=%d", p.Total())
+		}
+		var got []int
+		for row, err := range p.All() {
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, row)
+		}
+		if len(got) != 23 {
+			t.Fatalf("prefetch=%v got %d rows", prefetch, len(got))
+		}
+		for i, v := range got {
+			if v != i {
+				t.Fatalf("prefetch=%v row %d = %d", prefetch, i, v)
+			}
+		}
+		if p.Total() != 23 {
+			t.Fatalf("Total=%d", p.Total())
+		}
+		if n := len(f.requested()); n != 5 {
+			t.Fatalf("prefetch=%v requests=%d, want 5", prefetch, n)
+		}
+	}
+}
+
+func TestPagerEarlyBreakStopsFetching(t *testing.T) {
+	f := &fakePages{n: 1000, failAt: -1}
+	p := NewPager(context.Background(), 10, f.fetch)
+	p.Prefetch = false
+	for row := range p.All() {
+		if row == 3 {
+			break
+		}
+	}
+	if got := f.requested(); len(got) != 1 {
+		t.Fatalf("requests=%v", got)
+	}
+}
+
+func TestPagerPrefetchRequestsNextPageOnce(t *testing.T) {
+	f := &fakePages{n: 1000, failAt: -1}
+	p := NewPager(context.Background(), 10, f.fetch)
+	for row := range p.All() {
+		if row == 15 {
+			break
+		}
+	}
+	// Page 0 and 10 consumed, page 20 prefetched; nothing beyond.
+	if got := f.requested(); len(got) > 3 {
+		t.Fatalf("requests=%v", got)
+	}
+}
+
+func TestPagerYieldsErrorAndStops(t *testing.T) {
+	f := &fakePages{n: 100, failAt: 20}
+	p := NewPager(context.Background(), 10, f.fetch)
+	rows, errs := 0, 0
+	for _, err := range p.All() {
+		if err != nil {
+			errs++
+			continue
+		}
+		rows++
+	}
+	if rows != 20 || errs != 1 {
+		t.Fatalf("rows=%d errs=%d", rows, errs)
+	}
+}
+
+func TestPagerUnknownTotalStopsOnShortPage(t *testing.T) {
+	calls := 0
+	p := NewPager(context.Background(), 3, func(_ context.Context, count, start int) ([]string, int, error) {
+		calls++
+		if start >= 6 {
+			return []string{"x"}, 0, nil
+		}
+		return []string{"a", "b", "c"}, 0, nil
+	})
+	n := 0
+	for range p.All() {
+		n++
+	}
+	if n != 7 || calls != 3 || p.Total() != 0 {
+		t.Fatalf("rows=%d calls=%d total=%d", n, calls, p.Total())
+	}
+}
@@ -0,0 +1,121 @@
+package onlyoffice
+
+// Bounded fan-out helpers. OnlyOffice has no batch read endpoints, so index
+// builds and dedupe passes issue one GET per page or per row; these helpers
+// run such calls on a small worker pool instead of strictly sequentially.
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// SetConcurrency sets how many requests the client's fan-out helpers
+// (ListAllContacts, ListAllOpportunities, the dedupe prefetch passes) keep
+// in flight. n <= 1 keeps them sequential, which is the default.
+func (c *Client) SetConcurrency(n int) { c.parallelism = n }
+
+// Concurrency returns the fan-out limit configured via SetConcurrency
+// (at least 1).
+func (c *Client) Concurrency() int {
+	if c.parallelism < 1 {
+		return 1
+	}
+	return c.parallelism
+}
+
+// ParallelMap calls fn for every item with at most limit calls in flight and
+// returns the results in input order. The first error cancels the context
+// passed to the remaining calls and is returned; limit <= 1 runs
+// sequentially.
+func ParallelMap[T, R any](ctx context.Context, limit int, items []T, fn func(context.Context, T) (R, error)) ([]R, error) {
+	out := make([]R, len(items))
+	err := ParallelForEach(ctx, limit, items, func(ctx context.Context, i int, item T) error {
+		r, err := fn(ctx, item)
+		if err != nil {
+			return err
+		}
+		out[i] = r
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ParallelForEach calls fn(ctx, index, item) for every item with at most
+// limit calls in flight. The first error cancels the remaining calls and is
+// returned.
+func ParallelForEach[T any](ctx context.Context, limit int, items []T, fn func(context.Context, int, T) error) error {
+	if limit < 1 {
+		limit = 1
+	}
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(limit)
+	for i, item := range items {
+		if gctx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err // an earlier call failed while we waited for a slot
+			}
+			return fn(gctx, i, item)
+		})
+	}
+	return g.Wait()
+}
+
+// concurrencyOf returns the fan-out limit of client when it exposes one
+// (the *Client does), else 1. Used by helpers that accept narrow interfaces.
+func concurrencyOf(client any) int {
+	if cc, ok := client.(interface{ Concurrency() int }); ok {
+		return cc.Concurrency()
+	}
+	return 1
+}
+
+// listAllPages fetches every page of a count/startIndex endpoint. The first
+// page is fetched alone to learn the total; the remaining pages are fetched
+// with up to limit requests in flight and concatenated in order.
+func listAllPages(ctx context.Context, limit, page int, fetch PageFunc[map[string]any]) ([]map[string]any, error) {
+	first, total, err := fetch(ctx, page, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(first) == 0 || page >= total {
+		return first, nil
+	}
+	if limit <= 1 {
+		// Sequential path keeps the historic stop-on-short-page behaviour.
+		all := first
+		for start := page; start < total; start += page {
+			chunk, _, err := fetch(ctx, page, start)
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, chunk...)
+			if len(chunk) == 0 {
+				break
+			}
+		}
+		return all, nil
+	}
+	var starts []int
+	for start := page; start < total; start += page {
+		starts = append(starts, start)
+	}
+	chunks, err := ParallelMap(ctx, limit, starts, func(ctx context.Context, start int) ([]map[string]any, error) {
+		chunk, _, err := fetch(ctx, page, start)
+		return chunk, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	all := first
+	for _, chunk := range chunks {
+		all = append(all, chunk...)
+	}
+	return all, nil
+}
@@ -0,0 +1,113 @@
+package onlyoffice
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelMapPreservesOrderAndLimit(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	var inFlight, peak int32
+	out, err := ParallelMap(context.Background(), 3, items, func(ctx context.Context, n int) (int, error) {
+		cur := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if cur <= p || atomic.CompareAndSwapInt32(&peak, p, cur) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		return n * 10, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, n := range items {
+		if out[i] != n*10 {
+			t.Fatalf("out[%d]=%d, want %d", i, out[i], n*10)
+		}
+	}
+	if peak > 3 {
+		t.Fatalf("peak in-flight %d exceeds limit 3", peak)
+	}
+}
+
+func TestParallelMapStopsOnError(t *testing.T) {
+	boom := errors.New("boom")
+	var calls int32
+	_, err := ParallelMap(context.Background(), 1, []int{1, 2, 3, 4}, func(ctx context.Context, n int) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		if n == 2 {
+			return 0, boom
+		}
+		return n, nil
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("err=%v", err)
+	}
+	if calls > 2 {
+		t.Fatalf("sequential run continued after error: %d calls", calls)
+	}
+}
+
+func TestListAllPagesParallelKeepsOrder(t *testing.T) {
+	const total = 250
+	fetch := func(ctx context.Context, count, start int) ([]map[string]any, int, error) {
+		var rows []map[string]any
+		for i := start; i < start+count && i < total; i++ {
+			rows = append(rows, map[string]any{"id": float64(i)})
+		}
+		return rows, total, nil
+	}
+	for _, limit := range []int{1, 4} {
+		all, err := listAllPages(context.Background(), limit, 100, fetch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(all) != total {
+			t.Fatalf("limit %d: got %d rows", limit, len(all))
+		}
+		for i, row := range all {
+			if flexInt(row["id"]) != int64(i) {
+				t.Fatalf("limit %d: row %d has id %v", limit, i, row["id"])
+			}
+		}
+	}
+}
+
+func TestClientTokenStateIsRaceFree(t *testing.T) {
+	// Run with -race: exercises the guarded token/self-id accessors without
+	// touching the network.
+	c := NewClient(Credentials{Url: "http://portal.invalid"})
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.setToken(&Token{Value: "t", Expires: Time(time.Now().Add(time.Hour))})
+			_ = c.tokenValid()
+			_ = c.tokenValue()
+			c.InvalidateToken()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConcurrencyDefaultsToSequential(t *testing.T) {
+	c := NewClient(Credentials{})
+	if c.Concurrency() != 1 {
+		t.Fatalf("default concurrency=%d", c.Concurrency())
+	}
+	c.SetConcurrency(8)
+	if concurrencyOf(c) != 8 {
+		t.Fatalf("concurrencyOf=%d", concurrencyOf(c))
+	}
+	if concurrencyOf(struct{}{}) != 1 {
+		t.Fatal("non-client should default to 1")
+	}
+}
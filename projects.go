@@ -114,8 +114,13 @@ type ProjectUpdateRequest struct {
 }
 
 // GetProjects returns all projects, including private ones the caller can see.
-func (c *Client) GetProjects() (list Projects, err error) {
-	return list, c.Query(Request{Uri: `/api/2.0/project/filter.json?simple=true`},
+func (c *Client) GetProjects() (Projects, error) {
+	return c.GetProjectsContext(context.Background())
+}
+
+// GetProjectsContext is the context-aware variant of GetProjects.
+func (c *Client) GetProjectsContext(ctx context.Context) (list Projects, err error) {
+	return list, c.QueryContext(ctx, Request{Uri: `/api/2.0/project/filter.json?simple=true`},
 		&struct {
 			MetaResponse `json:",inline"`
 			Response     *Projects
@@ -148,15 +153,20 @@ type NewMilestoneRequest struct {
 // CreateMilestone creates a project milestone (Gantt row).
 // POST /api/2.0/project/{id}/milestone
 func (c *Client) CreateMilestone(req NewMilestoneRequest) (*Milestone, error) {
+	return c.CreateMilestoneContext(context.Background(), req)
+}
+
+// CreateMilestoneContext is the context-aware variant of CreateMilestone.
+func (c *Client) CreateMilestoneContext(ctx context.Context, req NewMilestoneRequest) (*Milestone, error) {
 	if req.Responsible == "" {
-		uid, err := c.SelfUserID(context.Background())
+		uid, err := c.SelfUserID(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("CreateMilestone: self: %w", err)
 		}
 		req.Responsible = uid
 	}
 	ms := new(Milestone)
-	err := c.Query(Request{
+	err := c.QueryContext(ctx, Request{
 		Uri:    fmt.Sprintf("/api/2.0/project/%d/milestone", req.ProjectID),
 		Method: "POST",
 		Body:   req,
@@ -170,7 +180,12 @@ func (c *Client) CreateMilestone(req NewMilestoneRequest) (*Milestone, error) {
 // DeleteMilestone removes a milestone by id.
 // DELETE /api/2.0/project/milestone/{id}
 func (c *Client) DeleteMilestone(id int64) error {
-	return c.Query(Request{
+	return c.DeleteMilestoneContext(context.Background(), id)
+}
+
+// DeleteMilestoneContext is the context-aware variant of DeleteMilestone.
+func (c *Client) DeleteMilestoneContext(ctx context.Context, id int64) error {
+	return c.QueryContext(ctx, Request{
 		Uri:    fmt.Sprintf("/api/2.0/project/milestone/%d", id),
 		Method: "DELETE",
 	}, &struct {
@@ -182,8 +197,14 @@ func (c *Client) DeleteMilestone(id int64) error {
 // GetProjectMilestones returns milestones for the given project.
 // https://api1.onlyoffice.com/portals/method/project/post/api/2.0/project/%7bid%7d/milestone
 func (c *Client) GetProjectMilestones(project *Project) ([]*Milestone, error) {
+	return c.GetProjectMilestonesContext(context.Background(), project)
+}
+
+// GetProjectMilestonesContext is the context-aware variant of
+// GetProjectMilestones.
+func (c *Client) GetProjectMilestonesContext(ctx context.Context, project *Project) ([]*Milestone, error) {
 	var list []*Milestone
-	err := c.Query(Request{Uri: fmt.Sprintf(`/api/2.0/project/%d/milestone`, *project.ID)},
+	err := c.QueryContext(ctx, Request{Uri: fmt.Sprintf(`/api/2.0/project/%d/milestone`, *project.ID)},
 		&struct {
 			MetaResponse `json:",inline"`
 			Response     *[]*Milestone
@@ -195,8 +216,13 @@ func (c *Client) GetProjectMilestones(project *Project) ([]*Milestone, error) {
 //   - if ResponsibleID is empty, the first user matching the client's User
 //     email is picked; failing that, the first portal user.
 func (c *Client) CreateProject(np NewProjectRequest) (*Project, error) {
+	return c.CreateProjectContext(context.Background(), np)
+}
+
+// CreateProjectContext is the context-aware variant of CreateProject.
+func (c *Client) CreateProjectContext(ctx context.Context, np NewProjectRequest) (*Project, error) {
 	if np.ResponsibleID == "" {
-		users, err := c.GetUsers()
+		users, err := c.GetUsersContext(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -212,7 +238,7 @@ func (c *Client) CreateProject(np NewProjectRequest) (*Project, error) {
 	}
 
 	prj := new(Project)
-	return prj, c.Query(Request{
+	return prj, c.QueryContext(ctx, Request{
 		Uri:    "/api/2.0/project.json",
 		Method: "POST",
 		Body:   np,
@@ -226,8 +252,13 @@ func (c *Client) CreateProject(np NewProjectRequest) (*Project, error) {
 
 // DeleteProject deletes a project by numeric ID.
 func (c *Client) DeleteProject(id int) (*Project, error) {
+	return c.DeleteProjectContext(context.Background(), id)
+}
+
+// DeleteProjectContext is the context-aware variant of DeleteProject.
+func (c *Client) DeleteProjectContext(ctx context.Context, id int) (*Project, error) {
 	p := &Project{}
-	return p, c.Query(
+	return p, c.QueryContext(ctx,
 		Request{
 			Uri:    fmt.Sprintf("/api/2.0/project/%d.json", id),
 			Method: "DELETE",
@@ -241,13 +272,18 @@ func (c *Client) DeleteProject(id int) (*Project, error) {
 // OnlyOffice requires responsibleId on PUT; callers should set ResponsibleID
 // (the CLI fills it from the current project when omitted).
 func (c *Client) UpdateProject(req ProjectUpdateRequest) (*Project, error) {
+	return c.UpdateProjectContext(context.Background(), req)
+}
+
+// UpdateProjectContext is the context-aware variant of UpdateProject.
+func (c *Client) UpdateProjectContext(ctx context.Context, req ProjectUpdateRequest) (*Project, error) {
 	p := new(Project)
 	body := map[string]any{
 		"title":         req.Title,
 		"description":   req.Description,
 		"responsibleId": req.ResponsibleID,
 	}
-	err := c.Query(Request{
+	err := c.QueryContext(ctx, Request{
 		Uri:    fmt.Sprintf("/api/2.0/project/%d.json", req.ID),
 		Method: "PUT",
 		Body:   body,
@@ -262,8 +298,14 @@ func (c *Client) UpdateProject(req ProjectUpdateRequest) (*Project, error) {
 
 // UpdateProjectStatus sets project lifecycle status (open, paused, closed).
 func (c *Client) UpdateProjectStatus(id int, status string) (*Project, error) {
+	return c.UpdateProjectStatusContext(context.Background(), id, status)
+}
+
+// UpdateProjectStatusContext is the context-aware variant of
+// UpdateProjectStatus.
+func (c *Client) UpdateProjectStatusContext(ctx context.Context, id int, status string) (*Project, error) {
 	p := &Project{}
-	return p, c.Query(Request{
+	return p, c.QueryContext(ctx, Request{
 		Uri:    fmt.Sprintf("/api/2.0/project/%d/status", id),
 		Method: "PUT",
 		Body:   map[string]string{"status": status},
@@ -0,0 +1,230 @@
+package onlyoffice
+
+// Client-side request throttling. A RateLimiter holds one token bucket per
+// endpoint group (people, crm, mail, files, …) plus a default bucket shared
+// by every group without its own budget. (*Client).do waits on it before
+// every attempt, so the http.go helpers, Query, WebDAV uploads and mail
+// attachment downloads are all covered — including retries.
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Endpoint groups used as RateLimiter budget keys. EndpointGroup maps a
+// request path onto one of these.
+const (
+	RateGroupDefault  = "*"
+	RateGroupPeople   = "people"
+	RateGroupCRM      = "crm"
+	RateGroupMail     = "mail"
+	RateGroupFiles    = "files"
+	RateGroupProject  = "project"
+	RateGroupCalendar = "calendar"
+	RateGroupAuth     = "authentication"
+)
+
+// RateLimit is a token-bucket budget: Rate requests per second on average
+// with bursts of up to Burst requests. Rate <= 0 means unlimited.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimitStats reports limiter activity for one endpoint group.
+type RateLimitStats struct {
+	Requests int           // requests admitted
+	Delayed  int           // requests that had to wait
+	Waited   time.Duration // total time spent waiting
+}
+
+// RateLimiter is a set of token buckets shared by all requests of a Client
+// (or of several clients talking to the same portal). Safe for concurrent
+// use.
+type RateLimiter struct {
+	mu      sync.Mutex
+	limits  map[string]RateLimit
+	buckets map[string]*tokenBucket
+	stats   map[string]*RateLimitStats
+	now     func() time.Time
+}
+
+// SetRateLimiter throttles every request the client sends through l; nil
+// turns throttling off. The same limiter may be shared by several clients to
+// enforce a portal-wide budget. Use l.Stats() to see how long requests waited.
+func (c *Client) SetRateLimiter(l *RateLimiter) { c.limiter = l }
+
+// NewRateLimiter returns a limiter applying def to every endpoint group that
+// has no entry in groups.
+func NewRateLimiter(def RateLimit, groups map[string]RateLimit) *RateLimiter {
+	limits := map[string]RateLimit{RateGroupDefault: def}
+	for g, l := range groups {
+		limits[g] = l
+	}
+	return &RateLimiter{
+		limits:  limits,
+		buckets: map[string]*tokenBucket{},
+		stats:   map[string]*RateLimitStats{},
+		now:     time.Now,
+	}
+}
+
+// ParseRateLimiter builds a limiter from a compact spec such as
+// "10" (10 req/s for everything) or "crm=5/10,mail=2,*=20", where each
+// entry is group=rate[/burst]. Burst defaults to max(1, rate). An empty
+// spec returns (nil, nil) — no limiting.
+func ParseRateLimiter(spec string) (*RateLimiter, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+	var def RateLimit
+	groups := map[string]RateLimit{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		group, val, ok := strings.Cut(part, "=")
+		if !ok {
+			group, val = RateGroupDefault, part
+		}
+		l, err := parseRateLimit(val)
+		if err != nil {
+			return nil, fmt.Errorf("rate limit %q: %w", part, err)
+		}
+		if group = strings.TrimSpace(group); group == RateGroupDefault {
+			def = l
+		} else {
+			groups[group] = l
+		}
+	}
+	return NewRateLimiter(def, groups), nil
+}
+
+func parseRateLimit(v string) (RateLimit, error) {
+	rateStr, burstStr, hasBurst := strings.Cut(strings.TrimSpace(v), "/")
+	rate, err := strconv.ParseFloat(rateStr, 64)
+	if err != nil {
+		return RateLimit{}, err
+	}
+	burst := int(rate)
+	if hasBurst {
+		if burst, err = strconv.Atoi(burstStr); err != nil {
+			return RateLimit{}, err
+		}
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return RateLimit{Rate: rate, Burst: burst}, nil
+}
+
+// Wait blocks until a request to group may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context, group string) error {
+	l.mu.Lock()
+	key := group
+	if _, ok := l.limits[key]; !ok {
+		key = RateGroupDefault
+	}
+	lim := l.limits[key]
+	st := l.stats[group]
+	if st == nil {
+		st = &RateLimitStats{}
+		l.stats[group] = st
+	}
+	st.Requests++
+	if lim.Rate <= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	b := l.buckets[key]
+	if b == nil {
+		b = &tokenBucket{tokens: float64(lim.Burst), last: l.now()}
+		l.buckets[key] = b
+	}
+	delay := b.reserve(lim, l.now())
+	if delay > 0 {
+		st.Delayed++
+		st.Waited += delay
+	}
+	l.mu.Unlock()
+
+	if err := sleepContext(ctx, delay); err != nil {
+		l.mu.Lock()
+		// Give the reservation back: the request was never admitted.
+		b.tokens++
+		st.Requests--
+		if delay > 0 {
+			st.Delayed--
+			st.Waited -= delay
+		}
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Stats returns a snapshot of per-group activity.
+func (l *RateLimiter) Stats() map[string]RateLimitStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make(map[string]RateLimitStats, len(l.stats))
+	for g, st := range l.stats {
+		out[g] = *st
+	}
+	return out
+}
+
+// TotalWait sums the time spent waiting across all groups.
+func (l *RateLimiter) TotalWait() time.Duration {
+	var total time.Duration
+	for _, st := range l.Stats() {
+		total += st.Waited
+	}
+	return total
+}
+
+// tokenBucket allows tokens to go negative: each reservation takes one
+// token immediately and waits out the debt, so concurrent callers queue in
+// arrival order instead of polling.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) reserve(l RateLimit, now time.Time) time.Duration {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = min(float64(l.Burst), b.tokens+elapsed*l.Rate)
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / l.Rate * float64(time.Second))
+}
+
+// EndpointGroup maps an API path onto its rate-limit group:
+// /api/2.0/crm/… → "crm", /api/2.0/people/… → "people",
+// /addons/mail/… → "mail", and so on. Unknown paths map to RateGroupDefault.
+func EndpointGroup(path string) string {
+	switch {
+	case strings.HasPrefix(path, "/addons/mail/"):
+		return RateGroupMail
+	case !strings.HasPrefix(path, "/api/2.0/"):
+		return RateGroupDefault
+	}
+	seg, _, _ := strings.Cut(strings.TrimPrefix(path, "/api/2.0/"), "/")
+	seg, _, _ = strings.Cut(seg, "?")
+	seg = strings.TrimSuffix(seg, ".json")
+	switch seg {
+	case RateGroupPeople, RateGroupCRM, RateGroupMail, RateGroupFiles, RateGroupProject, RateGroupCalendar, RateGroupAuth:
+		return seg
+	}
+	return RateGroupDefault
+}
@@ -0,0 +1,140 @@
+package onlyoffice
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock advances only when the test says so; Wait still sleeps for the
+// computed delay, so tests keep rates high enough for the sleeps to be tiny.
+type fakeClock struct{ t time.Time }
+
+func (f *fakeClock) now() time.Time { return f.t }
+
+func TestTokenBucketReserve(t *testing.T) {
+	l := RateLimit{Rate: 2, Burst: 2}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := &tokenBucket{tokens: 2, last: start}
+	if d := b.reserve(l, start); d != 0 {
+		t.Fatalf("first burst token delayed %v", d)
+	}
+	if d := b.reserve(l, start); d != 0 {
+		t.Fatalf("second burst token delayed %v", d)
+	}
+	if d := b.reserve(l, start); d != 500*time.Millisecond {
+		t.Fatalf("third request delay %v, want 500ms", d)
+	}
+	if d := b.reserve(l, start); d != time.Second {
+		t.Fatalf("fourth request should queue behind the third: %v", d)
+	}
+	if d := b.reserve(l, start.Add(10*time.Second)); d != 0 {
+		t.Fatalf("bucket should have refilled: %v", d)
+	}
+}
+
+func TestRateLimiterGroupsAndStats(t *testing.T) {
+	clk := &fakeClock{t: time.Now()}
+	l := NewRateLimiter(RateLimit{}, map[string]RateLimit{RateGroupCRM: {Rate: 1000, Burst: 1}})
+	l.now = clk.now
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx, RateGroupCRM); err != nil {
+			t.Fatal(err)
+		}
+		if err := l.Wait(ctx, RateGroupPeople); err != nil {
+			t.Fatal(err)
+		}
+	}
+	st := l.Stats()
+	if st[RateGroupCRM].Requests != 3 || st[RateGroupCRM].Delayed != 2 {
+		t.Fatalf("crm stats %+v", st[RateGroupCRM])
+	}
+	if st[RateGroupPeople].Delayed != 0 {
+		t.Fatalf("unlimited default group should never wait: %+v", st[RateGroupPeople])
+	}
+	if l.TotalWait() <= 0 {
+		t.Fatal("expected recorded wait time")
+	}
+}
+
+func TestRateLimiterWaitHonoursContext(t *testing.T) {
+	l := NewRateLimiter(RateLimit{Rate: 0.001, Burst: 1}, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, RateGroupFiles); err != nil {
+		t.Fatalf("burst token should be free: %v", err)
+	}
+	if err := l.Wait(ctx, RateGroupFiles); err == nil {
+		t.Fatal("expected context error while waiting ~1000s for a token")
+	}
+	// The cancelled wait leaves no trace: only the first request counts
+	// and its token is all the bucket is short of.
+	if st := l.Stats()[RateGroupFiles]; st != (RateLimitStats{Requests: 1}) {
+		t.Errorf("stats after cancel = %+v, want one undelayed request", st)
+	}
+	if tokens := l.buckets[RateGroupDefault].tokens; tokens < -0.01 || tokens > 0.01 {
+		t.Errorf("tokens after cancel = %v, want the reservation back (0)", tokens)
+	}
+}
+
+func TestParseRateLimiter(t *testing.T) {
+	if l, err := ParseRateLimiter(""); l != nil || err != nil {
+		t.Fatalf("empty spec: %v %v", l, err)
+	}
+	l, err := ParseRateLimiter("crm=5/10, mail=2, *=20")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := l.limits[RateGroupCRM]; got != (RateLimit{Rate: 5, Burst: 10}) {
+		t.Errorf("crm=%+v", got)
+	}
+	if got := l.limits[RateGroupMail]; got != (RateLimit{Rate: 2, Burst: 2}) {
+		t.Errorf("mail=%+v", got)
+	}
+	if got := l.limits[RateGroupDefault]; got != (RateLimit{Rate: 20, Burst: 20}) {
+		t.Errorf("default=%+v", got)
+	}
+	if _, err := ParseRateLimiter("crm=fast"); err == nil {
+		t.Error("expected parse error")
+	}
+}
+
+func TestEndpointGroup(t *testing.T) {
+	cases := map[string]string{
+		"/api/2.0/crm/contact/filter.json?count=1": RateGroupCRM,
+		"/api/2.0/people/@self.json":               RateGroupPeople,
+		"/api/2.0/project.json":                    RateGroupProject,
+		"/api/2.0/files/@root":                     RateGroupFiles,
+		"/api/2.0/mail/messages/1":                 RateGroupMail,
+		"/addons/mail/httphandlers/download.ashx":  RateGroupMail,
+		"/api/2.0/authentication.json":             RateGroupAuth,
+		"/api/2.0/settings/version":                RateGroupDefault,
+		"/products/files/httphandlers/x":           RateGroupDefault,
+	}
+	for path, want := range cases {
+		if got := EndpointGroup(path); got != want {
+			t.Errorf("EndpointGroup(%q)=%q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestRateLimiterGroupsUnderSubPath(t *testing.T) {
+	l := NewRateLimiter(RateLimit{}, nil)
+	c := NewClient(Credentials{Url: "http://portal.invalid/office/", Token: "tok"},
+		WithRateLimiter(l),
+		WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			resp := stubResponse(http.StatusOK, nil)
+			resp.Body = io.NopCloser(strings.NewReader(`{"response":[]}`))
+			return resp, nil
+		})))
+	if _, err := c.getJSON(context.Background(), "/api/2.0/crm/contact.json"); err != nil {
+		t.Fatal(err)
+	}
+	if st := l.Stats(); st[RateGroupCRM].Requests != 1 {
+		t.Fatalf("stats %+v, want one crm request", st)
+	}
+}
@@ -8,6 +8,7 @@ package onlyoffice
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -47,8 +48,18 @@ func (r Request) GetMethod() string {
 //   - If request.Token is non-nil it is used verbatim as the Authorization
 //     header.
 //   - If request.NoAuth is true then no token is fetched — the caller is
-//     responsible for authenticating requests (used internally by Auth()).
+//     responsible for authenticating requests (e.g. anonymous endpoints).
+//
+// Prefer QueryContext; Query is QueryContext with context.Background().
 func (c *Client) Query(request Request, result interface{}) error {
+	return c.QueryContext(context.Background(), request, result)
+}
+
+// QueryContext is the context-aware variant of Query. ctx bounds
+// authentication, rate-limit waits, retries and the request itself. A
+// non-2xx response is returned as an *APIError (matching the untyped
+// helpers in http.go) instead of being decoded into result.
+func (c *Client) QueryContext(ctx context.Context, request Request, result interface{}) error {
 	url := c.credentials.Url + request.Uri
 
 	if request.Params != nil {
@@ -64,7 +75,7 @@ func (c *Client) Query(request Request, result interface{}) error {
 		return err
 	}
 
-	req, err := http.NewRequest(request.GetMethod(), url, rdr)
+	req, err := http.NewRequestWithContext(ctx, request.GetMethod(), url, rdr)
 	if err != nil {
 		return err
 	}
@@ -73,23 +84,26 @@ func (c *Client) Query(request Request, result interface{}) error {
 	req.Header.Set("Pragma", "no-cache")
 
 	if !request.NoAuth {
-		if err := c.ensureToken(); err != nil {
+		if err := c.AuthenticateContext(ctx); err != nil {
 			return fmt.Errorf("failed to authenticate: %w", err)
 		}
 	}
-	switch {
-	case request.Token != nil:
+	if request.Token != nil {
 		req.Header.Set("Authorization", *request.Token)
-	case c.token != nil:
-		req.Header.Set("Authorization", c.token.Value)
+	} else if tok := c.tokenValue(); tok != "" {
+		req.Header.Set("Authorization", tok)
 	}
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		raw, _ := io.ReadAll(resp.Body)
+		return newAPIError(request.GetMethod(), request.Uri, resp.StatusCode, raw)
+	}
 	if result == nil {
 		return nil
 	}
@@ -171,15 +185,31 @@ func (t Time) After(u Time) bool {
 	return time.Time(t).After(time.Time(u))
 }
 
-// UnmarshalJSON decodes the OnlyOffice wire format into Time.
+// timeLayouts are the timestamp shapes OnlyOffice emits, most common first.
+var timeLayouts = []string{
+	"2006-01-02T15:04:05.0000000-07:00",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.9999999",
+	"2006-01-02",
+}
+
+// UnmarshalJSON decodes the OnlyOffice wire format into Time. RFC 3339 and
+// zone-less timestamps (CRM deadlines) are accepted too; "" leaves t zero.
 func (r *Time) UnmarshalJSON(data []byte) error {
-	data = data[1 : len(data)-1] // trim surrounding quotes
-	t, err := time.Parse("2006-01-02T15:04:05.0000000-07:00", string(data))
-	if err != nil {
-		return err
+	s := string(unquoteFlex(data))
+	if s == "" || s == "null" {
+		*r = Time{}
+		return nil
+	}
+	var err error
+	for _, layout := range timeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			*r = Time(t)
+			return nil
+		}
 	}
-	*r = Time(t)
-	return nil
+	return err
 }
 
 // MarshalJSON emits the OnlyOffice-friendly short form.
@@ -0,0 +1,54 @@
+package onlyoffice
+
+// QueryContext status/cancellation tests, using the bare-status stub
+// RoundTripper from retry_test.go.
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestQueryContextReturnsAPIErrorOnNon2xx(t *testing.T) {
+	c := stubClient(func(r *http.Request) (*http.Response, error) {
+		resp := stubResponse(http.StatusNotFound, nil)
+		resp.Body = io.NopCloser(strings.NewReader(`{"status":1,"statusCode":404,"error":{"message":"Item not found"}}`))
+		return resp, nil
+	}, RetryPolicy{MaxAttempts: 1})
+
+	var out struct {
+		Response *Project `json:"response"`
+	}
+	err := c.QueryContext(context.Background(), Request{Uri: "/api/2.0/project/1.json", NoAuth: true}, &out)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err=%v, want ErrNotFound", err)
+	}
+	apiErr, ok := AsAPIError(err)
+	if !ok || apiErr.Method != http.MethodGet || apiErr.Path != "/api/2.0/project/1.json" || apiErr.Message != "Item not found" {
+		t.Fatalf("apiErr=%+v", apiErr)
+	}
+	if out.Response != nil {
+		t.Fatal("error body decoded into result")
+	}
+}
+
+func TestQueryContextHonoursCancellation(t *testing.T) {
+	calls := 0
+	c := stubClient(func(r *http.Request) (*http.Response, error) {
+		calls++
+		if err := r.Context().Err(); err != nil {
+			return nil, err
+		}
+		return stubResponse(http.StatusOK, nil), nil
+	}, RetryPolicy{MaxAttempts: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := c.QueryContext(ctx, Request{Uri: "/api/2.0/project.json", NoAuth: true}, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err=%v calls=%d, want context.Canceled", err, calls)
+	}
+}
@@ -0,0 +1,268 @@
+package onlyoffice
+
+// Retry policy for the transport layer. Every request path (the untyped
+// helpers in http.go, Query in request.go, auth, file and attachment
+// downloads) funnels through (*Client).do, so transient portal failures —
+// 429 throttling, 502/503/504 from the reverse proxy, dropped connections —
+// are retried in one place.
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures how (*Client).do retries transient failures.
+//
+// The zero value disables retries (one attempt), which keeps NewClient
+// backwards compatible. Use DefaultRetryPolicy for unattended jobs.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	// Values <= 1 disable retries.
+	MaxAttempts int
+	// BaseDelay is the first backoff step; it doubles on every attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps a single backoff sleep. A Retry-After header asking for
+	// longer than MaxDelay is not waited out — the response is returned as-is.
+	// Zero caps backoff at DefaultRetryPolicy's MaxDelay and waits out any
+	// Retry-After.
+	MaxDelay time.Duration
+	// RetryNonIdempotent also replays POST/PATCH after 5xx responses and
+	// connection errors. By default those methods are only retried when the
+	// server provably did not process the request (429, connection refused),
+	// so a create is never applied twice.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns the policy used by the CLI tools: four attempts
+// with 500ms exponential backoff capped at 30s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// SetRetryPolicy replaces the client's retry policy. Pass the zero value to
+// disable retries.
+func (c *Client) SetRetryPolicy(p RetryPolicy) { c.retry = p }
+
+// do sends req, retrying per the client's RetryPolicy and waiting on the
+// client's RateLimiter (if any) before every attempt. The request body is
+// re-created via req.GetBody between attempts, which http.NewRequest sets
+// for the bytes/strings readers used throughout this package; requests with
+// a non-replayable body are sent once. Each attempt runs the client's
+// middleware chain.
+//
+// A mutating request drops the cached lookups it may affect (WithCache)
+// and is recorded in the journal (WithJournal).
+//
+// With a TokenStore configured, a 401 on a request that carried the cached
+// token triggers one re-authentication and replay: a persisted token can be
+// revoked server-side long before its Expires timestamp.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.cache != nil {
+		defer c.invalidateFor(req)
+	}
+	if c.journal != nil && c.dryRun == nil && !isReadRequest(req.Method, c.apiPath(req.URL.Path)) {
+		return c.doJournaled(req)
+	}
+	return c.send(req)
+}
+
+// send is do without the cache and journal bookkeeping.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if c.tel != nil {
+		return c.doTraced(req)
+	}
+	resp, _, err := c.doAttempts(req)
+	return resp, err
+}
+
+// doAttempts is the retry loop behind do. It also reports how many round
+// trips were sent (retries and re-auth replays included).
+func (c *Client) doAttempts(req *http.Request) (resp *http.Response, sent int, err error) {
+	p := c.retry
+	reauthed := false
+	if c.userAgent != "" && req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	for attempt := 1; ; attempt++ {
+		// A write the dry run captures never reaches the portal, so it
+		// neither waits for nor spends a rate-limit token.
+		if c.limiter != nil && (c.dryRun == nil || isReadRequest(req.Method, c.apiPath(req.URL.Path))) {
+			if err := c.limiter.Wait(req.Context(), EndpointGroup(c.apiPath(req.URL.Path))); err != nil {
+				return nil, sent, err
+			}
+		}
+		start := time.Now()
+		resp, err = c.roundTrip(req)
+		sent++
+		c.logAttempt(req, resp, err, attempt, time.Since(start))
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && !reauthed && c.refreshStaleToken(req) {
+			reauthed = true
+			attempt--
+			resp.Body.Close()
+			if req.GetBody != nil {
+				body, gerr := req.GetBody()
+				if gerr != nil {
+					return nil, sent, gerr
+				}
+				req.Body = body
+			}
+			continue
+		}
+		if attempt >= p.MaxAttempts || !p.shouldRetry(req, resp, err) {
+			return resp, sent, err
+		}
+		if req.Body != nil && req.GetBody == nil {
+			return resp, sent, err
+		}
+		wait := p.backoff(attempt)
+		if resp != nil {
+			if ra, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				if p.MaxDelay > 0 && ra > p.MaxDelay {
+					return resp, sent, err
+				}
+				wait = ra
+			}
+			// Drain so the connection can be reused by the next attempt.
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+		if c.logger != nil {
+			c.logger.Warn("onlyoffice: retrying request",
+				"method", req.Method, "path", req.URL.Path, "attempt", attempt, "wait", wait)
+		}
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, sent, err
+		}
+		if req.GetBody != nil {
+			body, gerr := req.GetBody()
+			if gerr != nil {
+				return nil, sent, gerr
+			}
+			req.Body = body
+		}
+	}
+}
+
+// logAttempt records one round trip at Debug level. Only method, path,
+// status and timing are logged — never headers or bodies.
+func (c *Client) logAttempt(req *http.Request, resp *http.Response, err error, attempt int, d time.Duration) {
+	if c.logger == nil {
+		return
+	}
+	attrs := []any{"method", req.Method, "path", req.URL.Path, "attempt", attempt, "duration", d}
+	if err != nil {
+		c.logger.Debug("onlyoffice: request failed", append(attrs, "error", err)...)
+		return
+	}
+	c.logger.Debug("onlyoffice: request", append(attrs, "status", resp.StatusCode)...)
+}
+
+// shouldRetry decides whether the outcome of one attempt is transient and
+// safe to replay for req's method.
+func (p RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	idempotent := isIdempotent(req.Method) || p.RetryNonIdempotent
+	if err != nil {
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			return true // never reached the server
+		}
+		return idempotent && isTransientNetError(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true // throttled before processing
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// backoff returns the sleep before attempt+1: exponential growth from
+// BaseDelay with equal jitter, capped at MaxDelay.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	base := p.BaseDelay
+	if base <= 0 {
+		base = 100 * time.Millisecond
+	}
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryPolicy().MaxDelay
+	}
+	// Past 2^30 steps the shift overflows; maxDelay applies long before.
+	d := base << min(max(attempt-1, 0), 30)
+	if d <= 0 || d > maxDelay {
+		d = maxDelay
+	}
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+// isIdempotent reports whether replaying method cannot duplicate side effects.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isTransientNetError matches dropped connections and timeouts, but not
+// configuration errors such as unknown hosts or bad TLS roots.
+func isTransientNetError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var ne net.Error
+	return errors.As(err, &ne) && ne.Timeout()
+}
+
+// retryAfter parses a Retry-After header given either as delay-seconds or as
+// an HTTP date relative to now.
+func retryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	if d := t.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,209 @@
+package onlyoffice
+
+// Retry loop tests. The stub RoundTripper returns bare status codes — it
+// exercises our own retry/backoff behaviour, not the OnlyOffice protocol.
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func stubResponse(status int, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: status, Header: header, Body: io.NopCloser(strings.NewReader(""))}
+}
+
+func stubClient(rt roundTripFunc, p RetryPolicy) *Client {
+	c := NewClient(Credentials{Url: "http://portal.invalid"})
+	c.client = &http.Client{Transport: rt}
+	c.SetRetryPolicy(p)
+	return c
+}
+
+func TestDoRetriesIdempotentOn503(t *testing.T) {
+	calls := 0
+	var bodies []string
+	c := stubClient(func(r *http.Request) (*http.Response, error) {
+		calls++
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if calls < 3 {
+			return stubResponse(http.StatusServiceUnavailable, nil), nil
+		}
+		return stubResponse(http.StatusOK, nil), nil
+	}, RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond})
+
+	req, _ := http.NewRequest(http.MethodPut, "http://portal.invalid/x", strings.NewReader("payload"))
+	resp, err := c.do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || calls != 3 {
+		t.Fatalf("status=%d calls=%d", resp.StatusCode, calls)
+	}
+	for i, b := range bodies {
+		if b != "payload" {
+			t.Fatalf("attempt %d body=%q; GetBody not replayed", i+1, b)
+		}
+	}
+}
+
+func TestDoDoesNotReplayPostOn503(t *testing.T) {
+	calls := 0
+	c := stubClient(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return stubResponse(http.StatusServiceUnavailable, nil), nil
+	}, RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond})
+
+	req, _ := http.NewRequest(http.MethodPost, "http://portal.invalid/x", strings.NewReader("{}"))
+	resp, err := c.do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable || calls != 1 {
+		t.Fatalf("status=%d calls=%d", resp.StatusCode, calls)
+	}
+}
+
+func TestDoRetriesPostOn429WithRetryAfter(t *testing.T) {
+	calls := 0
+	c := stubClient(func(r *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return stubResponse(http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}}), nil
+		}
+		return stubResponse(http.StatusOK, nil), nil
+	}, RetryPolicy{MaxAttempts: 2, BaseDelay: time.Hour})
+
+	req, _ := http.NewRequest(http.MethodPost, "http://portal.invalid/x", strings.NewReader("{}"))
+	start := time.Now()
+	resp, err := c.do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || calls != 2 {
+		t.Fatalf("status=%d calls=%d", resp.StatusCode, calls)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("Retry-After: 0 should override the hour-long backoff")
+	}
+}
+
+func TestDoGivesUpWhenRetryAfterExceedsMaxDelay(t *testing.T) {
+	calls := 0
+	c := stubClient(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return stubResponse(http.StatusTooManyRequests, http.Header{"Retry-After": {"120"}}), nil
+	}, RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second})
+
+	req, _ := http.NewRequest(http.MethodGet, "http://portal.invalid/x", nil)
+	resp, err := c.do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests || calls != 1 {
+		t.Fatalf("status=%d calls=%d", resp.StatusCode, calls)
+	}
+}
+
+func TestDoZeroPolicySendsOnce(t *testing.T) {
+	calls := 0
+	c := stubClient(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return nil, syscall.ECONNRESET
+	}, RetryPolicy{})
+	req, _ := http.NewRequest(http.MethodGet, "http://portal.invalid/x", nil)
+	if _, err := c.do(req); err == nil {
+		t.Fatal("expected transport error")
+	}
+	if calls != 1 {
+		t.Fatalf("calls=%d", calls)
+	}
+}
+
+func TestDoStopsOnContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	c := stubClient(func(r *http.Request) (*http.Response, error) {
+		calls++
+		cancel()
+		return stubResponse(http.StatusServiceUnavailable, nil), nil
+	}, RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond})
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://portal.invalid/x", nil)
+	_, _ = c.do(req)
+	if calls != 1 {
+		t.Fatalf("calls=%d", calls)
+	}
+}
+
+func TestShouldRetryConnectionErrors(t *testing.T) {
+	p := DefaultRetryPolicy()
+	get, _ := http.NewRequest(http.MethodGet, "http://x", nil)
+	post, _ := http.NewRequest(http.MethodPost, "http://x", nil)
+	if !p.shouldRetry(get, nil, syscall.ECONNRESET) {
+		t.Error("GET should retry on connection reset")
+	}
+	if p.shouldRetry(post, nil, syscall.ECONNRESET) {
+		t.Error("POST must not be replayed on connection reset")
+	}
+	if !p.shouldRetry(post, nil, syscall.ECONNREFUSED) {
+		t.Error("POST may be retried when the connection was refused")
+	}
+	p.RetryNonIdempotent = true
+	if !p.shouldRetry(post, nil, syscall.ECONNRESET) {
+		t.Error("RetryNonIdempotent should allow POST replay")
+	}
+}
+
+func TestBackoffBounds(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt := 1; attempt <= 10; attempt++ {
+		d := p.backoff(attempt)
+		if d < 0 || d > time.Second {
+			t.Fatalf("attempt %d: backoff %v out of bounds", attempt, d)
+		}
+	}
+	if d := p.backoff(1); d < 50*time.Millisecond || d > 100*time.Millisecond {
+		t.Fatalf("first backoff %v outside [base/2, base]", d)
+	}
+}
+
+func TestBackoffNeverHotLoops(t *testing.T) {
+	def := DefaultRetryPolicy().MaxDelay
+	for _, attempt := range []int{40, 64, 100} {
+		if d := (RetryPolicy{BaseDelay: time.Second}).backoff(attempt); d < def/2 || d > def {
+			t.Errorf("no MaxDelay, attempt %d: backoff %v, want default cap", attempt, d)
+		}
+		if d := (RetryPolicy{MaxDelay: time.Second}).backoff(attempt); d < time.Second/2 {
+			t.Errorf("attempt %d: backoff %v below MaxDelay/2", attempt, d)
+		}
+	}
+}
+
+func TestRetryAfterParsing(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if d, ok := retryAfter("7", now); !ok || d != 7*time.Second {
+		t.Fatalf("seconds: %v %v", d, ok)
+	}
+	date := now.Add(90 * time.Second).Format(http.TimeFormat)
+	if d, ok := retryAfter(date, now); !ok || d != 90*time.Second {
+		t.Fatalf("http-date: %v %v", d, ok)
+	}
+	if _, ok := retryAfter("soon", now); ok {
+		t.Fatal("garbage should not parse")
+	}
+	if _, ok := retryAfter("", now); ok {
+		t.Fatal("empty should not parse")
+	}
+}
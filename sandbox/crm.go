@@ -0,0 +1,563 @@
+package sandbox
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// crmFilter is the shared filter.json handler: filterValue search plus
+// count/startIndex paging over the rows keep accepts.
+func (s *Server) crmFilter(name string, keep func(row map[string]any, p params) bool) handler {
+	return func(r *http.Request, p params) (any, error) {
+		return window(s.t(name).list(func(row map[string]any) bool {
+			return matches(row, p.str("filterValue")) && (keep == nil || keep(row, p))
+		}), p), nil
+	}
+}
+
+// contactRef is the compact contact embedded in deals, cases and invoices.
+func contactRef(c map[string]any) map[string]any {
+	return map[string]any{"id": c["id"], "displayName": c["displayName"], "isCompany": c["isCompany"], "isPrivate": false}
+}
+
+// members returns row[key] as a list of contact refs.
+func members(row map[string]any, key string) []any {
+	list, _ := row[key].([]any)
+	return list
+}
+
+// addMember links contact id to row[key]; removeMember unlinks it.
+func (s *Server) addMember(row map[string]any, key, id string) (map[string]any, error) {
+	c, err := s.find(tContacts, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range members(row, key) {
+		if idOf(m.(map[string]any)["id"]) == id {
+			return c, nil
+		}
+	}
+	row[key] = append(members(row, key), contactRef(c))
+	return c, nil
+}
+
+func removeMember(row map[string]any, key, id string) {
+	list := members(row, key)
+	for i, m := range list {
+		if idOf(m.(map[string]any)["id"]) == id {
+			row[key] = append(list[:i], list[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *Server) registerCRM() {
+	s.registerContacts()
+
+	// Deals.
+	s.handle("GET /api/2.0/crm/opportunity/filter", s.crmFilter(tOpportunities, func(o map[string]any, p params) bool {
+		id := p.str("contactid")
+		if id == "" {
+			return true
+		}
+		for _, m := range members(o, "members") {
+			if idOf(m.(map[string]any)["id"]) == id {
+				return true
+			}
+		}
+		return false
+	}))
+	s.handle("GET /api/2.0/crm/opportunity/stage", func(*http.Request, params) (any, error) {
+		return s.t(tStages).list(nil), nil
+	})
+	s.handle("POST /api/2.0/crm/opportunity", func(r *http.Request, p params) (any, error) {
+		if p.str("title") == "" {
+			return nil, badRequest("title is required")
+		}
+		o := s.stamp(map[string]any{"bidValue": 0, "bidType": 0, "perPeriodValue": 0, "successProbability": 0,
+			"isPrivate": false, "members": []any{}, "canEdit": true})
+		s.updateOpportunity(o, p)
+		s.t(tOpportunities).put(o)
+		return o, nil
+	})
+	s.handle("GET /api/2.0/crm/opportunity/bycontact/{id}", func(r *http.Request, p params) (any, error) {
+		id := r.PathValue("id")
+		if _, err := s.find(tContacts, id); err != nil {
+			return nil, err
+		}
+		return s.t(tOpportunities).list(func(o map[string]any) bool {
+			for _, m := range members(o, "members") {
+				if idOf(m.(map[string]any)["id"]) == id {
+					return true
+				}
+			}
+			return false
+		}), nil
+	})
+	s.handle("GET /api/2.0/crm/opportunity/{id}/contact", func(r *http.Request, p params) (any, error) {
+		o, err := s.find(tOpportunities, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		return members(o, "members"), nil
+	})
+	s.handle("POST /api/2.0/crm/opportunity/{id}/contact/{contact}", func(r *http.Request, p params) (any, error) {
+		o, err := s.find(tOpportunities, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		return s.addMember(o, "members", r.PathValue("contact"))
+	})
+	s.handle("DELETE /api/2.0/crm/opportunity/{id}/contact/{contact}", func(r *http.Request, p params) (any, error) {
+		o, err := s.find(tOpportunities, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		c, err := s.find(tContacts, r.PathValue("contact"))
+		if err != nil {
+			return nil, err
+		}
+		removeMember(o, "members", r.PathValue("contact"))
+		return c, nil
+	})
+	s.handle("GET /api/2.0/crm/opportunity/{id}/files", func(r *http.Request, p params) (any, error) {
+		if _, err := s.find(tOpportunities, r.PathValue("id")); err != nil {
+			return nil, err
+		}
+		return s.linked(tFiles, "crm/opportunity/"+r.PathValue("id")), nil
+	})
+	s.handle("POST /api/2.0/crm/opportunity/{id}/files/upload", func(r *http.Request, p params) (any, error) {
+		if _, err := s.find(tOpportunities, r.PathValue("id")); err != nil {
+			return nil, err
+		}
+		f, err := s.upload(r, crmFolder)
+		if err != nil {
+			return nil, err
+		}
+		s.link("crm/opportunity/"+r.PathValue("id"), str(f, "id"))
+		return f, nil
+	})
+	s.handle("GET /api/2.0/crm/opportunity/{id}", func(r *http.Request, p params) (any, error) {
+		return s.find(tOpportunities, r.PathValue("id"))
+	})
+	s.handle("PUT /api/2.0/crm/opportunity/{id}", func(r *http.Request, p params) (any, error) {
+		o, err := s.find(tOpportunities, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		s.updateOpportunity(o, p)
+		if ids := p.strings("members"); ids != nil {
+			o["members"] = []any{}
+			for _, id := range ids {
+				if _, err := s.addMember(o, "members", id); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return o, nil
+	})
+	s.handle("DELETE /api/2.0/crm/opportunity/{id}", func(r *http.Request, p params) (any, error) {
+		return s.remove(tOpportunities, r.PathValue("id"))
+	})
+
+	// Cases.
+	s.handle("GET /api/2.0/crm/case/filter", s.crmFilter(tCases, nil))
+	s.handle("POST /api/2.0/crm/case", func(r *http.Request, p params) (any, error) {
+		if p.str("title") == "" {
+			return nil, badRequest("title is required")
+		}
+		c := s.stamp(map[string]any{"isClosed": false, "isPrivate": false, "members": []any{}})
+		fill(c, p, "members")
+		s.t(tCases).put(c)
+		return c, nil
+	})
+	s.handle("POST /api/2.0/crm/case/{id}/contact/{contact}", func(r *http.Request, p params) (any, error) {
+		c, err := s.find(tCases, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		return s.addMember(c, "members", r.PathValue("contact"))
+	})
+	s.handle("GET /api/2.0/crm/case/{id}", func(r *http.Request, p params) (any, error) {
+		return s.find(tCases, r.PathValue("id"))
+	})
+	s.handle("PUT /api/2.0/crm/case/{id}", func(r *http.Request, p params) (any, error) {
+		c, err := s.find(tCases, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		fill(c, p, "members")
+		return c, nil
+	})
+	s.handle("DELETE /api/2.0/crm/case/{id}", func(r *http.Request, p params) (any, error) {
+		return s.remove(tCases, r.PathValue("id"))
+	})
+
+	// CRM tasks.
+	s.handle("GET /api/2.0/crm/task/filter", s.crmFilter(tCRMTasks, nil))
+	s.handle("GET /api/2.0/crm/task/category", func(*http.Request, params) (any, error) {
+		return s.t(tTaskCategories).list(nil), nil
+	})
+	s.handle("POST /api/2.0/crm/task", func(r *http.Request, p params) (any, error) {
+		if p.str("title") == "" {
+			return nil, badRequest("title is required")
+		}
+		t := s.stamp(map[string]any{"isClosed": false, "alertValue": 0})
+		s.updateCRMTask(t, p)
+		s.t(tCRMTasks).put(t)
+		return t, nil
+	})
+	s.handle("GET /api/2.0/crm/task/{id}", func(r *http.Request, p params) (any, error) {
+		return s.find(tCRMTasks, r.PathValue("id"))
+	})
+	s.handle("PUT /api/2.0/crm/task/{id}", func(r *http.Request, p params) (any, error) {
+		t, err := s.find(tCRMTasks, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		s.updateCRMTask(t, p)
+		return t, nil
+	})
+	s.handle("DELETE /api/2.0/crm/task/{id}", func(r *http.Request, p params) (any, error) {
+		return s.remove(tCRMTasks, r.PathValue("id"))
+	})
+
+	// History.
+	s.handle("GET /api/2.0/crm/history/filter", s.crmFilter(tHistory, func(h map[string]any, p params) bool {
+		typ, id := p.str("entityType"), p.str("entityId")
+		if typ == "" || id == "" || id == "0" {
+			return true
+		}
+		e := nested(h, "entity")
+		if typ == "contact" {
+			return str(nested(h, "contact"), "id") == id
+		}
+		return strings.EqualFold(str(e, "entityType"), typ) && str(e, "entityId") == id
+	}))
+	s.handle("GET /api/2.0/crm/history/category", func(*http.Request, params) (any, error) {
+		return s.t(tHistoryCats).list(nil), nil
+	})
+	s.handle("POST /api/2.0/crm/history", func(r *http.Request, p params) (any, error) {
+		h := s.stamp(map[string]any{"content": p.str("content")})
+		if cat := s.t(tHistoryCats).get(p.str("categoryId")); cat != nil {
+			h["category"] = cat
+		}
+		typ, id := p.str("entityType"), p.str("entityId")
+		if typ == "contact" {
+			c, err := s.find(tContacts, id)
+			if err != nil {
+				return nil, err
+			}
+			h["contact"] = contactRef(c)
+		} else if typ != "" {
+			h["entity"] = map[string]any{"entityType": typ, "entityId": num(id)}
+		}
+		s.t(tHistory).put(h)
+		return h, nil
+	})
+
+	s.registerInvoices()
+}
+
+func (s *Server) registerContacts() {
+	s.handle("GET /api/2.0/crm/contact/filter", s.crmFilter(tContacts, func(c map[string]any, p params) bool {
+		switch strings.ToLower(p.str("contactListView")) {
+		case "company":
+			if c["isCompany"] != true {
+				return false
+			}
+		case "person":
+			if c["isCompany"] == true {
+				return false
+			}
+		}
+		tag := p.str("tags")
+		return tag == "" || contains(stringsOf(c["tags"]), tag)
+	}))
+	s.handle("GET /api/2.0/crm/contact/tag", func(*http.Request, params) (any, error) {
+		counts := map[string]int{}
+		for _, t := range s.tags {
+			counts[t] = 0
+		}
+		for _, c := range s.t(tContacts).list(nil) {
+			for _, t := range stringsOf(c["tags"]) {
+				counts[t]++
+			}
+		}
+		out := []map[string]any{}
+		for _, t := range s.tagOrder(counts) {
+			out = append(out, map[string]any{"title": t, "relativeItemsCount": counts[t]})
+		}
+		return out, nil
+	})
+	s.handle("POST /api/2.0/crm/contact/tag", func(r *http.Request, p params) (any, error) {
+		name := p.str("tagName")
+		if name == "" {
+			return nil, badRequest("tagName is required")
+		}
+		if contains(s.tags, name) {
+			return nil, badRequest("tag %q already exists", name)
+		}
+		s.tags = append(s.tags, name)
+		return map[string]any{"title": name}, nil
+	})
+	s.handle("POST /api/2.0/crm/contact/company", func(r *http.Request, p params) (any, error) {
+		name := p.str("companyName")
+		if name == "" {
+			return nil, badRequest("companyName is required")
+		}
+		c := s.newContact(true)
+		fill(c, p)
+		c["displayName"] = name
+		s.t(tContacts).put(c)
+		return c, nil
+	})
+	s.handle("POST /api/2.0/crm/contact/person", func(r *http.Request, p params) (any, error) {
+		if p.str("firstName") == "" && p.str("lastName") == "" {
+			return nil, badRequest("firstName or lastName is required")
+		}
+		c := s.newContact(false)
+		if err := s.updatePerson(c, p); err != nil {
+			return nil, err
+		}
+		s.t(tContacts).put(c)
+		return c, nil
+	})
+	s.handle("PUT /api/2.0/crm/contact/merge", func(r *http.Request, p params) (any, error) {
+		from, to := p.str("fromContactId"), p.str("toContactId")
+		dst, err := s.find(tContacts, to)
+		if err != nil {
+			return nil, err
+		}
+		src, err := s.remove(tContacts, from)
+		if err != nil {
+			return nil, err
+		}
+		dst["commonData"] = append(members(dst, "commonData"), members(src, "commonData")...)
+		for _, t := range stringsOf(src["tags"]) {
+			if !contains(stringsOf(dst["tags"]), t) {
+				dst["tags"] = append(stringsOf(dst["tags"]), t)
+			}
+		}
+		// Deals, cases and project links follow the surviving contact.
+		for _, name := range []string{tOpportunities, tCases} {
+			for _, row := range s.t(name).list(nil) {
+				for _, m := range members(row, "members") {
+					if idOf(m.(map[string]any)["id"]) == from {
+						removeMember(row, "members", from)
+						_, _ = s.addMember(row, "members", to)
+						break
+					}
+				}
+			}
+		}
+		for key, ids := range s.links {
+			if strings.HasPrefix(key, "project/") && contains(ids, from) {
+				s.unlink(key, from)
+				s.link(key, to)
+			}
+		}
+		return dst, nil
+	})
+	s.handle("GET /api/2.0/crm/contact/company/{id}/person", func(r *http.Request, p params) (any, error) {
+		id := r.PathValue("id")
+		if _, err := s.find(tContacts, id); err != nil {
+			return nil, err
+		}
+		return s.t(tContacts).list(func(c map[string]any) bool { return str(nested(c, "company"), "id") == id }), nil
+	})
+	s.handle("PUT /api/2.0/crm/contact/company/{id}", func(r *http.Request, p params) (any, error) {
+		c, err := s.find(tContacts, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		fill(c, p)
+		if name := p.str("companyName"); name != "" {
+			c["displayName"] = name
+		}
+		return c, nil
+	})
+	s.handle("PUT /api/2.0/crm/contact/person/{id}", func(r *http.Request, p params) (any, error) {
+		c, err := s.find(tContacts, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		return c, s.updatePerson(c, p)
+	})
+	s.handle("GET /api/2.0/crm/contact/project/{id}", func(r *http.Request, p params) (any, error) {
+		return s.linked(tContacts, "project/"+r.PathValue("id")), nil
+	})
+	s.handle("POST /api/2.0/crm/contact/{id}/data", func(r *http.Request, p params) (any, error) {
+		c, err := s.find(tContacts, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		s.nextInfo++
+		info := map[string]any{"id": s.nextInfo, "infoType": p.str("infoType"), "category": p.str("category"),
+			"data": p.str("data"), "isPrimary": p.bool("isPrimary")}
+		c["commonData"] = append(members(c, "commonData"), info)
+		return info, nil
+	})
+	s.handle("DELETE /api/2.0/crm/contact/{id}/data/{data}", func(r *http.Request, p params) (any, error) {
+		c, err := s.find(tContacts, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		list := members(c, "commonData")
+		for i, d := range list {
+			if idOf(d.(map[string]any)["id"]) == r.PathValue("data") {
+				c["commonData"] = append(list[:i], list[i+1:]...)
+				return d, nil
+			}
+		}
+		return nil, notFound("contact info", r.PathValue("data"))
+	})
+	s.handle("POST /api/2.0/crm/contact/{id}/address", func(r *http.Request, p params) (any, error) {
+		c, err := s.find(tContacts, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		s.nextInfo++
+		addr := map[string]any{"id": s.nextInfo}
+		fill(addr, p)
+		c["addresses"] = append(members(c, "addresses"), addr)
+		return addr, nil
+	})
+	s.handle("POST /api/2.0/crm/contact/{id}/tag", func(r *http.Request, p params) (any, error) {
+		c, err := s.find(tContacts, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		tag := p.str("tagName")
+		if tag == "" {
+			return nil, badRequest("tagName is required")
+		}
+		if !contains(stringsOf(c["tags"]), tag) {
+			c["tags"] = append(stringsOf(c["tags"]), tag)
+		}
+		if !contains(s.tags, tag) {
+			s.tags = append(s.tags, tag)
+		}
+		return c, nil
+	})
+	s.handle("DELETE /api/2.0/crm/contact/{id}/tag", func(r *http.Request, p params) (any, error) {
+		c, err := s.find(tContacts, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		tags := stringsOf(c["tags"])
+		for i, t := range tags {
+			if t == p.str("tagName") {
+				c["tags"] = append(tags[:i], tags[i+1:]...)
+				break
+			}
+		}
+		return c, nil
+	})
+	s.handle("GET /api/2.0/crm/contact/{id}/files", func(r *http.Request, p params) (any, error) {
+		if _, err := s.find(tContacts, r.PathValue("id")); err != nil {
+			return nil, err
+		}
+		return s.linked(tFiles, "crm/contact/"+r.PathValue("id")), nil
+	})
+	s.handle("GET /api/2.0/crm/contact/{id}", func(r *http.Request, p params) (any, error) {
+		return s.find(tContacts, r.PathValue("id"))
+	})
+	s.handle("DELETE /api/2.0/crm/contact/{id}", func(r *http.Request, p params) (any, error) {
+		return s.remove(tContacts, r.PathValue("id"))
+	})
+	s.handle("DELETE /api/2.0/crm/files/{id}", func(r *http.Request, p params) (any, error) {
+		f, err := s.remove(tFiles, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		delete(s.blobs, r.PathValue("id"))
+		return f, nil
+	})
+}
+
+func (s *Server) newContact(company bool) map[string]any {
+	return s.stamp(map[string]any{"isCompany": company, "isPrivate": false, "isShared": false,
+		"commonData": []any{}, "addresses": []any{}, "tags": []string{}, "canEdit": true, "canDelete": true})
+}
+
+// updatePerson applies person fields, keeping displayName and the company
+// link in sync.
+func (s *Server) updatePerson(c map[string]any, p params) error {
+	fill(c, p, "jobTitle", "companyId")
+	if v, ok := p["jobTitle"]; ok {
+		c["title"] = v
+	}
+	c["displayName"] = strings.TrimSpace(str(c, "firstName") + " " + str(c, "lastName"))
+	if id := p.str("companyId"); id != "" && id != "0" {
+		co, err := s.find(tContacts, id)
+		if err != nil {
+			return err
+		}
+		c["company"] = contactRef(co)
+		co["personsCount"] = countOf(co, "personsCount") + 1
+	}
+	return nil
+}
+
+// updateOpportunity applies deal fields, resolving stage, responsible and
+// currency into the nested objects the portal returns.
+func (s *Server) updateOpportunity(o map[string]any, p params) {
+	fill(o, p, "stageId", "stageid", "responsibleId", "responsibleid", "bidCurrencyAbbr", "members", "contactid", "accessList", "opportunityid", "isNotify")
+	stage := p.str("stageId")
+	if stage == "" {
+		stage = p.str("stageid")
+	}
+	if st := s.t(tStages).get(stage); st != nil {
+		o["stage"] = st
+		o["successProbability"] = st["successProbability"]
+	}
+	resp := p.str("responsibleId")
+	if resp == "" {
+		resp = p.str("responsibleid")
+	}
+	if u := s.userRef(resp); u != nil {
+		o["responsible"] = u
+	}
+	if cur := p.str("bidCurrencyAbbr"); cur != "" {
+		o["bidCurrency"] = map[string]any{"abbreviation": cur, "title": cur, "symbol": cur}
+	}
+	if id := p.str("contactid"); id != "" {
+		if c := s.t(tContacts).get(id); c != nil {
+			o["contact"] = contactRef(c)
+		}
+	}
+}
+
+// updateCRMTask applies CRM task fields, resolving category, responsible
+// and the linked contact.
+func (s *Server) updateCRMTask(t map[string]any, p params) {
+	fill(t, p, "categoryId", "responsibleId", "contactId", "entityType", "entityId")
+	if cat := s.t(tTaskCategories).get(p.str("categoryId")); cat != nil {
+		t["category"] = cat
+	}
+	if u := s.userRef(p.str("responsibleId")); u != nil {
+		t["responsible"] = u
+	}
+	if c := s.t(tContacts).get(p.str("contactId")); c != nil {
+		t["contact"] = contactRef(c)
+	}
+	if typ := p.str("entityType"); typ != "" {
+		t["entity"] = map[string]any{"entityType": typ, "entityId": num(p.str("entityId"))}
+	}
+}
+
+// tagOrder lists tags known to the sandbox first (creation order), then any
+// seen only on contacts, sorted.
+func (s *Server) tagOrder(counts map[string]int) []string {
+	out := append([]string(nil), s.tags...)
+	var extra []string
+	for t := range counts {
+		if !contains(out, t) {
+			extra = append(extra, t)
+		}
+	}
+	slices.Sort(extra)
+	return append(out, extra...)
+}
@@ -0,0 +1,306 @@
+package sandbox
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// crmFolder holds CRM attachments and generated invoice PDFs, as the
+// portal's hidden CRM storage does.
+const crmFolder = "2"
+
+// downloadPath is the Files download handler that viewUrl points at.
+const downloadPath = "/Products/Files/HttpHandlers/filehandler.ashx"
+
+// Root folder types (onlyoffice.DavFolder.RootType).
+const (
+	rootMy       = 5
+	rootProjects = 8
+)
+
+func (s *Server) registerFiles() {
+	s.handle("GET /api/2.0/files/@root", func(*http.Request, params) (any, error) {
+		var out []any
+		for _, f := range s.t(tFolders).list(func(f map[string]any) bool {
+			return (str(f, "parentId") == "0" || str(f, "parentId") == "") && str(f, "id") != crmFolder
+		}) {
+			out = append(out, s.listing(f))
+		}
+		return out, nil
+	})
+	s.handle("POST /api/2.0/files/folder/{id}", func(r *http.Request, p params) (any, error) {
+		parent, err := s.folder(r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		if p.str("title") == "" {
+			return nil, badRequest("title is required")
+		}
+		f := s.stamp(map[string]any{
+			"title":          p.str("title"),
+			"parentId":       parent["id"],
+			"rootFolderType": parent["rootFolderType"],
+			"access":         0,
+			"shared":         false,
+		})
+		s.t(tFolders).put(f)
+		return s.folderView(f), nil
+	})
+	s.handle("PUT /api/2.0/files/folder/{id}", func(r *http.Request, p params) (any, error) {
+		f, err := s.find(tFolders, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		if p.str("title") == "" {
+			return nil, badRequest("title is required")
+		}
+		f["title"], f["updated"] = p.str("title"), now()
+		return s.folderView(f), nil
+	})
+	s.handle("DELETE /api/2.0/files/folder/{id}", func(r *http.Request, p params) (any, error) {
+		if _, err := s.find(tFolders, r.PathValue("id")); err != nil {
+			return nil, err
+		}
+		s.deleteFolder(r.PathValue("id"))
+		return fileOps("delete"), nil
+	})
+	s.handle("GET /api/2.0/files/file/{id}", func(r *http.Request, p params) (any, error) {
+		return s.find(tFiles, r.PathValue("id"))
+	})
+	s.handle("PUT /api/2.0/files/file/{id}", func(r *http.Request, p params) (any, error) {
+		f, err := s.find(tFiles, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		if p.str("title") == "" {
+			return nil, badRequest("title is required")
+		}
+		f["title"], f["fileExst"], f["updated"] = p.str("title"), path.Ext(p.str("title")), now()
+		return f, nil
+	})
+	s.handle("DELETE /api/2.0/files/file/{id}", func(r *http.Request, p params) (any, error) {
+		if _, err := s.find(tFiles, r.PathValue("id")); err != nil {
+			return nil, err
+		}
+		s.deleteFile(r.PathValue("id"))
+		return fileOps("delete"), nil
+	})
+	s.handle("PUT /api/2.0/files/fileops/delete", func(r *http.Request, p params) (any, error) {
+		for _, id := range p.strings("folderIds") {
+			s.deleteFolder(id)
+		}
+		for _, id := range p.strings("fileIds") {
+			s.deleteFile(id)
+		}
+		return fileOps("delete"), nil
+	})
+	s.handle("PUT /api/2.0/files/fileops/move", func(r *http.Request, p params) (any, error) {
+		return s.moveItems(p, false)
+	})
+	s.handle("PUT /api/2.0/files/fileops/copy", func(r *http.Request, p params) (any, error) {
+		return s.moveItems(p, true)
+	})
+	s.handle("POST /api/2.0/files/{id}/upload", func(r *http.Request, p params) (any, error) {
+		if _, err := s.folder(r.PathValue("id")); err != nil {
+			return nil, err
+		}
+		return s.upload(r, r.PathValue("id"))
+	})
+	s.handle("GET /api/2.0/files/{id}", func(r *http.Request, p params) (any, error) {
+		f, err := s.folder(r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		l := s.listing(f)
+		l["total"] = len(l["files"].([]map[string]any)) + len(l["folders"].([]map[string]any))
+		return l, nil
+	})
+
+	// Downloads are raw bytes, not an API envelope.
+	s.routes = append(s.routes, route{method: http.MethodGet, segs: strings.Split(strings.Trim(downloadPath, "/"), "/"), serve: s.download})
+}
+
+// folder resolves a folder id, including the symbolic "@my".
+func (s *Server) folder(id string) (map[string]any, error) {
+	if id == "@my" {
+		// Prefer a seeded "My documents" over the built-in one.
+		var my map[string]any
+		for _, f := range s.t(tFolders).list(func(f map[string]any) bool {
+			return str(f, "rootFolderType") == fmt.Sprint(rootMy) && (str(f, "parentId") == "0" || str(f, "parentId") == "")
+		}) {
+			my = f
+		}
+		if my != nil {
+			return my, nil
+		}
+		id = myFolderID
+	}
+	return s.find(tFolders, id)
+}
+
+// listing is the {current, files, folders} object of a folder.
+func (s *Server) listing(f map[string]any) map[string]any {
+	id := str(f, "id")
+	return map[string]any{"current": s.folderView(f), "files": s.folderFiles(id), "folders": s.subfolders(id)}
+}
+
+// folderView returns f with its file and folder counts refreshed.
+func (s *Server) folderView(f map[string]any) map[string]any {
+	id := str(f, "id")
+	f["filesCount"], f["foldersCount"] = len(s.folderFiles(id)), len(s.subfolders(id))
+	return f
+}
+
+func (s *Server) subfolders(id string) []map[string]any {
+	out := s.t(tFolders).list(func(f map[string]any) bool { return id != "" && str(f, "parentId") == id })
+	for _, f := range out {
+		s.folderView(f)
+	}
+	return out
+}
+
+func (s *Server) folderFiles(id string) []map[string]any {
+	return s.t(tFiles).list(func(f map[string]any) bool { return id != "" && str(f, "folderId") == id })
+}
+
+// putFile stores data as a new file titled title in folder and returns its
+// row.
+func (s *Server) putFile(folder, title string, data []byte) map[string]any {
+	f := s.stamp(map[string]any{
+		"title":             title,
+		"folderId":          num(folder),
+		"fileExst":          path.Ext(title),
+		"contentLength":     fmt.Sprintf("%d bytes", len(data)),
+		"pureContentLength": len(data),
+		"version":           1,
+	})
+	id := s.t(tFiles).put(f)
+	f["viewUrl"] = downloadPath + "?action=download&fileid=" + id
+	f["webUrl"] = f["viewUrl"]
+	s.blobs[id] = data
+	return f
+}
+
+// upload stores the request's file (multipart field "file", or the raw body
+// titled by the "title" query parameter) in folder.
+func (s *Server) upload(r *http.Request, folder string) (map[string]any, error) {
+	ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if ct == "multipart/form-data" {
+		file, hdr, err := r.FormFile("file")
+		if err != nil {
+			return nil, badRequest("file is required: %v", err)
+		}
+		defer file.Close()
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return nil, err
+		}
+		return s.putFile(folder, hdr.Filename, data), nil
+	}
+	title := r.URL.Query().Get("title")
+	if title == "" {
+		return nil, badRequest("title is required for a raw upload")
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	return s.putFile(folder, title, data), nil
+}
+
+func (s *Server) deleteFile(id string) {
+	s.t(tFiles).del(id)
+	delete(s.blobs, id)
+}
+
+func (s *Server) deleteFolder(id string) {
+	for _, f := range s.folderFiles(id) {
+		s.deleteFile(str(f, "id"))
+	}
+	for _, sub := range s.t(tFolders).list(func(f map[string]any) bool { return str(f, "parentId") == id }) {
+		s.deleteFolder(str(sub, "id"))
+	}
+	s.t(tFolders).del(id)
+}
+
+// moveItems implements fileops/move and fileops/copy. Copies of folders are
+// shallow: the folder row is duplicated with its files, not its subfolders.
+func (s *Server) moveItems(p params, copying bool) (any, error) {
+	dest, err := s.folder(p.str("destFolderId"))
+	if err != nil {
+		return nil, err
+	}
+	destID := str(dest, "id")
+	for _, id := range p.strings("fileIds") {
+		f, err := s.find(tFiles, id)
+		if err != nil {
+			return nil, err
+		}
+		if copying {
+			s.putFile(destID, str(f, "title"), s.blobs[id])
+			continue
+		}
+		f["folderId"] = dest["id"]
+	}
+	for _, id := range p.strings("folderIds") {
+		f, err := s.find(tFolders, id)
+		if err != nil {
+			return nil, err
+		}
+		if !copying {
+			f["parentId"] = dest["id"]
+			continue
+		}
+		dup := map[string]any{}
+		for k, v := range f {
+			dup[k] = v
+		}
+		delete(dup, "id")
+		dup["parentId"] = dest["id"]
+		newID := s.t(tFolders).put(dup)
+		for _, file := range s.folderFiles(id) {
+			s.putFile(newID, str(file, "title"), s.blobs[str(file, "id")])
+		}
+	}
+	if copying {
+		return fileOps("copy"), nil
+	}
+	return fileOps("move"), nil
+}
+
+// fileOps is the finished-operation list fileops endpoints answer with.
+func fileOps(op string) []map[string]any {
+	return []map[string]any{{"id": newGUID(), "operation": op, "progress": 100, "error": "", "processed": "1", "finished": true}}
+}
+
+// download serves a file's bytes for its viewUrl.
+func (s *Server) download(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") == "" && !hasAuthCookie(r) {
+		writeError(w, &apiError{http.StatusUnauthorized, "System.Security.Authentication.AuthenticationException", "Unauthorized"})
+		return
+	}
+	id := r.URL.Query().Get("fileid")
+	s.mu.Lock()
+	f := s.t(tFiles).get(id)
+	data, ok := s.blobs[id]
+	s.mu.Unlock()
+	if f == nil {
+		writeError(w, notFound(tFiles, id).(*apiError))
+		return
+	}
+	if !ok {
+		// Seeded files carry metadata only.
+		data = []byte("sandbox: no content recorded for " + str(f, "title") + "\n")
+	}
+	ct := mime.TypeByExtension(path.Ext(str(f, "title")))
+	if ct == "" {
+		ct = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", ct)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": str(f, "title")}))
+	_, _ = w.Write(data)
+}
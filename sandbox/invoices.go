@@ -0,0 +1,153 @@
+package sandbox
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// invoiceStatus are the CRM invoice status codes and titles.
+var invoiceStatus = map[int]string{1: "Draft", 2: "Billed", 3: "Rejected", 4: "Paid", 5: "Archived"}
+
+func (s *Server) registerInvoices() {
+	s.handle("GET /api/2.0/crm/invoice/filter", s.crmFilter(tInvoices, func(inv map[string]any, p params) bool {
+		st := p.str("status")
+		return st == "" || st == "0" || str(nested(inv, "status"), "id") == st
+	}))
+	s.handle("POST /api/2.0/crm/invoice", func(r *http.Request, p params) (any, error) {
+		if p.str("number") == "" {
+			return nil, badRequest("number is required")
+		}
+		inv := s.stamp(map[string]any{"canEdit": true, "canDelete": true, "templateType": 0})
+		if err := s.updateInvoice(inv, p); err != nil {
+			return nil, err
+		}
+		inv["status"] = map[string]any{"id": 1, "title": invoiceStatus[1]}
+		s.t(tInvoices).put(inv)
+		return inv, nil
+	})
+	s.handle("PUT /api/2.0/crm/invoice/status/{status}", func(r *http.Request, p params) (any, error) {
+		code, _ := strconv.Atoi(r.PathValue("status"))
+		if invoiceStatus[code] == "" {
+			return nil, badRequest("unknown invoice status %s", r.PathValue("status"))
+		}
+		var ids []string
+		for _, v := range p.strings("invoiceids") {
+			ids = append(ids, strings.Split(v, ",")...)
+		}
+		var out []any
+		for _, id := range ids {
+			inv, err := s.find(tInvoices, strings.TrimSpace(id))
+			if err != nil {
+				return nil, err
+			}
+			inv["status"] = map[string]any{"id": code, "title": invoiceStatus[code]}
+			inv["canEdit"] = code == 1
+			out = append(out, inv)
+		}
+		return map[string]any{"invoices": out}, nil
+	})
+	s.handle("GET /api/2.0/crm/invoice/{id}/pdf", func(r *http.Request, p params) (any, error) {
+		inv, err := s.find(tInvoices, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		if f := s.t(tFiles).get(str(inv, "fileID")); f != nil {
+			return f, nil
+		}
+		// A placeholder document stands in for the rendered invoice.
+		title := "Invoice " + str(inv, "number") + ".pdf"
+		f := s.putFile(crmFolder, title, []byte("%PDF-1.4\n% sandbox placeholder for "+title+"\n%%EOF\n"))
+		inv["fileID"] = f["id"]
+		if c := nested(inv, "contact"); c != nil {
+			s.link("crm/contact/"+str(c, "id"), str(f, "id"))
+		}
+		if e := nested(inv, "entity"); e != nil {
+			s.link("crm/opportunity/"+str(e, "entityId"), str(f, "id"))
+		}
+		return f, nil
+	})
+	s.handle("GET /api/2.0/crm/invoice/{id}", func(r *http.Request, p params) (any, error) {
+		return s.find(tInvoices, r.PathValue("id"))
+	})
+	s.handle("PUT /api/2.0/crm/invoice/{id}", func(r *http.Request, p params) (any, error) {
+		inv, err := s.find(tInvoices, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		if inv["canEdit"] == false {
+			return nil, badRequest("invoice %s is not editable in status %s", r.PathValue("id"), str(nested(inv, "status"), "title"))
+		}
+		if err := s.updateInvoice(inv, p); err != nil {
+			return nil, err
+		}
+		delete(inv, "fileID") // edits invalidate the cached PDF
+		return inv, nil
+	})
+	s.handle("DELETE /api/2.0/crm/invoice/{id}", func(r *http.Request, p params) (any, error) {
+		return s.remove(tInvoices, r.PathValue("id"))
+	})
+
+	s.handle("GET /api/2.0/crm/invoiceitem/filter", s.crmFilter(tInvoiceItems, nil))
+	s.handle("POST /api/2.0/crm/invoiceitem", func(r *http.Request, p params) (any, error) {
+		if p.str("title") == "" {
+			return nil, badRequest("title is required")
+		}
+		item := s.stamp(map[string]any{})
+		fill(item, p, "currency")
+		item["currency"] = map[string]any{"abbreviation": p.str("currency"), "title": p.str("currency")}
+		s.t(tInvoiceItems).put(item)
+		return item, nil
+	})
+	s.handle("DELETE /api/2.0/crm/invoiceitem/{id}", func(r *http.Request, p params) (any, error) {
+		return s.remove(tInvoiceItems, r.PathValue("id"))
+	})
+}
+
+// updateInvoice applies a create/update body: contact and consignee become
+// nested contacts, currency an object, entityId/entityType the entity link,
+// and the lines are totalled into cost.
+func (s *Server) updateInvoice(inv map[string]any, p params) error {
+	fill(inv, p, "contactId", "consigneeId", "currency", "entityId", "entityType", "invoiceLines", "status")
+	for field, key := range map[string]string{"contact": "contactId", "consignee": "consigneeId"} {
+		id := p.str(key)
+		if id == "" || id == "0" {
+			continue
+		}
+		c, err := s.find(tContacts, id)
+		if err != nil {
+			return err
+		}
+		inv[field] = contactRef(c)
+	}
+	if inv["contact"] == nil {
+		return badRequest("contactId is required")
+	}
+	if cur := p.str("currency"); cur != "" {
+		inv["currency"] = map[string]any{"abbreviation": cur, "title": cur}
+	}
+	if id := p.str("entityId"); id != "" && id != "0" {
+		inv["entity"] = map[string]any{"entityType": "opportunity", "entityId": num(id)}
+	}
+	if lines, ok := p["invoiceLines"].([]any); ok {
+		cost := 0.0
+		for i, l := range lines {
+			line, ok := l.(map[string]any)
+			if !ok {
+				return badRequest("invoiceLines[%d] is not an object", i)
+			}
+			if idOf(line["id"]) == "" || idOf(line["id"]) == "0" {
+				s.nextInfo++
+				line["id"] = s.nextInfo
+			}
+			q, _ := strconv.ParseFloat(idOf(line["quantity"]), 64)
+			price, _ := strconv.ParseFloat(idOf(line["price"]), 64)
+			disc, _ := strconv.ParseFloat(idOf(line["discount"]), 64)
+			cost += q * price * (1 - disc/100)
+		}
+		inv["invoiceLines"] = lines
+		inv["cost"] = fmt.Sprintf("%.2f", cost)
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package sandbox
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Mail folder ids (onlyoffice.MailFolderInbox …).
+const (
+	folderInbox  = 1
+	folderSent   = 2
+	folderDrafts = 3
+	folderTrash  = 4
+)
+
+// mailPageSize is the mail API's default (and maximum) page size.
+const mailPageSize = 25
+
+func (s *Server) registerMail() {
+	s.handle("GET /api/2.0/mail/accounts", func(*http.Request, params) (any, error) {
+		return s.t(tMailAccounts).list(nil), nil
+	})
+	s.handle("GET /api/2.0/mail/folders", func(*http.Request, params) (any, error) {
+		folders := s.t(tMailFolders).list(nil)
+		for _, f := range folders {
+			id := str(f, "id")
+			total, unread := 0, 0
+			for _, m := range s.t(tMessages).list(nil) {
+				if str(m, "folder") == id {
+					total++
+					if m["isNew"] == true {
+						unread++
+					}
+				}
+			}
+			// Seeded counters stand until the sandbox holds messages of its own.
+			if total > 0 || f["total_count"] == nil {
+				f["total_count"], f["total_messages"] = total, total
+				f["unread"], f["unread_messages"] = unread, unread
+			}
+		}
+		return folders, nil
+	})
+	// The mail API pages by 1-based page number; the envelope has no total.
+	s.handle("GET /api/2.0/mail/messages", func(r *http.Request, p params) (any, error) {
+		folder := p.str("folder")
+		if folder == "" {
+			folder = "1"
+		}
+		rows := s.t(tMessages).list(func(m map[string]any) bool {
+			return str(m, "folder") == folder && matches(m, p.str("search"))
+		})
+		size := p.int("page_size", p.int("count", mailPageSize))
+		start := min((max(p.int("page", 1), 1)-1)*size, len(rows))
+		return rows[start:min(start+size, len(rows))], nil
+	})
+	s.handle("PUT /api/2.0/mail/messages/remove", func(r *http.Request, p params) (any, error) {
+		var done []string
+		for _, id := range p.strings("ids") {
+			m := s.t(tMessages).get(id)
+			if m == nil {
+				continue
+			}
+			if str(m, "folder") == "4" {
+				s.t(tMessages).del(id)
+			} else {
+				m["folder"] = folderTrash
+			}
+			done = append(done, id)
+		}
+		return done, nil
+	})
+	s.handle("PUT /api/2.0/mail/drafts/save", func(r *http.Request, p params) (any, error) {
+		m, err := s.saveMessage(p, folderDrafts)
+		if err != nil {
+			return nil, err
+		}
+		return m, nil
+	})
+	s.handle("PUT /api/2.0/mail/messages/send", func(r *http.Request, p params) (any, error) {
+		if len(p.strings("to")) == 0 {
+			return nil, badRequest("to is required")
+		}
+		m, err := s.saveMessage(p, folderSent)
+		if err != nil {
+			return nil, err
+		}
+		return m["id"], nil
+	})
+	s.handle("GET /api/2.0/mail/messages/{id}", func(r *http.Request, p params) (any, error) {
+		m, err := s.find(tMessages, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		m["isNew"] = false
+		return m, nil
+	})
+}
+
+// saveMessage creates or updates (when p has an id) a message in folder.
+func (s *Server) saveMessage(p params, folder int) (map[string]any, error) {
+	m := map[string]any{}
+	if id := p.str("id"); id != "" && id != "0" {
+		old, err := s.find(tMessages, id)
+		if err != nil {
+			return nil, err
+		}
+		m = old
+	}
+	ts := now()
+	for _, k := range []string{"to", "cc", "bcc"} {
+		if list := p.strings(k); list != nil {
+			m[k] = strings.Join(list, ", ")
+		}
+	}
+	m["from"], m["subject"] = p.str("from"), p.str("subject")
+	m["htmlBody"] = p.str("body")
+	m["introduction"] = introduction(p.str("body"))
+	m["folder"], m["date"], m["chainDate"], m["isNew"] = folder, ts, ts, false
+	s.t(tMessages).put(m)
+	return m, nil
+}
+
+// introduction is the plain-text preview the list endpoint shows.
+func introduction(html string) string {
+	var b strings.Builder
+	in := false
+	for _, r := range html {
+		switch {
+		case r == '<':
+			in = true
+		case r == '>':
+			in = false
+		case !in:
+			b.WriteRune(r)
+		}
+	}
+	out := strings.Join(strings.Fields(b.String()), " ")
+	if len(out) > 200 {
+		out = out[:200]
+	}
+	return out
+}
+
+// Calendars hold their events inline, as the portal's range endpoint
+// returns them.
+func (s *Server) registerCalendar() {
+	s.handle("GET /api/2.0/calendar/calendars/{start}/{end}", func(r *http.Request, p params) (any, error) {
+		from, ok1 := parseTime(r.PathValue("start"))
+		to, ok2 := parseTime(r.PathValue("end"))
+		if !ok1 || !ok2 {
+			return nil, badRequest("invalid date range %s/%s", r.PathValue("start"), r.PathValue("end"))
+		}
+		to = to.Add(24 * time.Hour)
+		var out []map[string]any
+		for _, cal := range s.t(tCalendars).list(nil) {
+			view := map[string]any{}
+			for k, v := range cal {
+				view[k] = v
+			}
+			var events []any
+			for _, e := range members(cal, "events") {
+				ev := e.(map[string]any)
+				start, ok := parseTime(str(ev, "start"))
+				if !ok || (!start.Before(from) && start.Before(to)) {
+					events = append(events, ev)
+				}
+			}
+			view["events"] = events
+			out = append(out, view)
+		}
+		return out, nil
+	})
+	s.handle("POST /api/2.0/calendar/{id}/event", func(r *http.Request, p params) (any, error) {
+		cal, err := s.find(tCalendars, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		if p.str("name") == "" {
+			return nil, badRequest("name is required")
+		}
+		s.nextInfo++
+		ev := map[string]any{
+			"objectId":       s.nextInfo,
+			"title":          p.str("name"),
+			"description":    p.str("description"),
+			"allDayLong":     p.bool("isAllDayLong"),
+			"calendarId":     cal["objectId"],
+			"isEditable":     true,
+			"repeatRule":     "",
+			"sourceId":       cal["objectId"],
+			"eventUid":       newGUID(),
+			"owner":          s.userRef(s.self),
+			"ownerId":        s.self,
+			"isShared":       false,
+			"textColor":      cal["textColor"],
+			"background":     cal["backgroundColor"],
+			"canUnsubscribe": false,
+		}
+		for field, key := range map[string]string{"start": "startDate", "end": "endDate"} {
+			if t, ok := parseTime(p.str(key)); ok {
+				ev[field] = t.Format(portalTime)
+			}
+		}
+		cal["events"] = append(members(cal, "events"), ev)
+		return []any{ev}, nil
+	})
+	s.handle("DELETE /api/2.0/calendar/events/{id}", func(r *http.Request, p params) (any, error) {
+		for _, cal := range s.t(tCalendars).list(nil) {
+			list := members(cal, "events")
+			for i, e := range list {
+				if idOf(e.(map[string]any)["objectId"]) == r.PathValue("id") {
+					cal["events"] = append(list[:i], list[i+1:]...)
+					return e, nil
+				}
+			}
+		}
+		return nil, notFound("event", r.PathValue("id"))
+	})
+}
@@ -0,0 +1,903 @@
+package sandbox
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// register installs every route. Within a group, literal paths come before
+// the wildcard paths they overlap.
+func (s *Server) register() {
+	// Authentication: any credentials (and any two-factor code) sign in.
+	signIn := func(*http.Request, params) (any, error) {
+		return map[string]any{
+			"token":   "sandbox-" + newGUID(),
+			"expires": time.Now().Add(24 * time.Hour).Format(portalTime),
+		}, nil
+	}
+	s.handle("POST /api/2.0/authentication", signIn)
+	s.handle("POST /api/2.0/authentication/{code}", signIn)
+	s.handle("GET /api/2.0/settings/version", func(*http.Request, params) (any, error) {
+		return map[string]any{"communityServer": "sandbox", "documentServer": "", "mailServer": ""}, nil
+	})
+
+	s.registerPeople()
+	s.registerProjects()
+	s.registerCRM()
+	s.registerMail()
+	s.registerCalendar()
+	s.registerFiles()
+}
+
+// userRef is the compact user object embedded in other entities
+// (createdBy, responsible, …).
+func (s *Server) userRef(id string) map[string]any {
+	u := s.t(tPeople).get(id)
+	if u == nil {
+		return nil
+	}
+	return map[string]any{"id": u["id"], "displayName": u["displayName"], "email": u["email"], "avatarSmall": ""}
+}
+
+// stamp sets the creation fields every portal entity carries.
+func (s *Server) stamp(row map[string]any) map[string]any {
+	ts := now()
+	row["created"], row["updated"] = ts, ts
+	row["createdBy"], row["createBy"] = s.userRef(s.self), s.userRef(s.self)
+	row["createdById"] = s.self
+	return row
+}
+
+// find returns the row of table name with id, or a 404.
+func (s *Server) find(name, id string) (map[string]any, error) {
+	if row := s.t(name).get(id); row != nil {
+		return row, nil
+	}
+	return nil, notFound(name, id)
+}
+
+// remove deletes and returns the row of table name with id, or a 404.
+func (s *Server) remove(name, id string) (map[string]any, error) {
+	if row := s.t(name).del(id); row != nil {
+		return row, nil
+	}
+	return nil, notFound(name, id)
+}
+
+// num renders a numeric id as a JSON number.
+func num(id string) any {
+	if n, err := strconv.ParseInt(id, 10, 64); err == nil {
+		return n
+	}
+	return id
+}
+
+// link and unlink maintain the id lists in s.links (project contacts, task
+// files, CRM entity files).
+func (s *Server) link(key string, ids ...string) {
+	for _, id := range ids {
+		if !contains(s.links[key], id) {
+			s.links[key] = append(s.links[key], id)
+		}
+	}
+}
+
+func (s *Server) unlink(key, id string) {
+	list := s.links[key]
+	for i, v := range list {
+		if v == id {
+			s.links[key] = append(list[:i], list[i+1:]...)
+			return
+		}
+	}
+}
+
+// linked returns the rows of table name whose ids are linked under key.
+func (s *Server) linked(name, key string) []map[string]any {
+	out := []map[string]any{}
+	for _, id := range s.links[key] {
+		if row := s.t(name).get(id); row != nil {
+			out = append(out, row)
+		}
+	}
+	return out
+}
+
+func (s *Server) registerPeople() {
+	list := func(r *http.Request, p params) (any, error) {
+		return window(s.t(tPeople).list(func(u map[string]any) bool { return matches(u, p.str("filterValue")) }), p), nil
+	}
+	s.handle("GET /api/2.0/people", list)
+	s.handle("GET /api/2.0/people/filter", list)
+	s.handle("GET /api/2.0/people/@self", func(*http.Request, params) (any, error) {
+		return s.find(tPeople, s.self)
+	})
+	s.handle("GET /api/2.0/people/{id}", func(r *http.Request, p params) (any, error) {
+		id := r.PathValue("id")
+		if strings.Contains(id, "@") {
+			for _, u := range s.t(tPeople).list(nil) {
+				if strings.EqualFold(str(u, "email"), id) {
+					return u, nil
+				}
+			}
+			return nil, notFound("user", id)
+		}
+		return s.find(tPeople, id)
+	})
+	s.handle("PUT /api/2.0/people/{id}", func(r *http.Request, p params) (any, error) {
+		u, err := s.find(tPeople, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		fill(u, p)
+		if first, last := str(u, "firstName"), str(u, "lastName"); first != "" || last != "" {
+			u["displayName"] = strings.TrimSpace(first + " " + last)
+		}
+		return u, nil
+	})
+	// /people/status/{status} and /people/{id}/password share a shape.
+	s.handle("PUT /api/2.0/people/{id}/{action}", func(r *http.Request, p params) (any, error) {
+		if r.PathValue("id") == "status" {
+			code := map[string]int{"active": 1, "terminated": 2}[strings.ToLower(r.PathValue("action"))]
+			if code == 0 {
+				return nil, badRequest("unknown status %q", r.PathValue("action"))
+			}
+			var out []map[string]any
+			for _, id := range p.strings("userIds") {
+				if u := s.t(tPeople).get(id); u != nil {
+					u["status"] = code
+					out = append(out, u)
+				}
+			}
+			return out, nil
+		}
+		if r.PathValue("action") != "password" {
+			return nil, notFound("route", r.URL.Path)
+		}
+		return s.find(tPeople, r.PathValue("id"))
+	})
+}
+
+// projectStatus maps the status names UpdateProjectStatus sends to the
+// portal's codes.
+var projectStatus = map[string]int{"open": 0, "closed": 1, "paused": 2}
+
+func (s *Server) registerProjects() {
+	projects := func(r *http.Request, p params) (any, error) {
+		return window(s.t(tProjects).list(func(row map[string]any) bool { return matches(row, p.str("filterValue")) }), p), nil
+	}
+	s.handle("GET /api/2.0/project", projects)
+	s.handle("GET /api/2.0/project/filter", projects)
+	s.handle("POST /api/2.0/project", func(r *http.Request, p params) (any, error) {
+		if p.str("title") == "" {
+			return nil, badRequest("title is required")
+		}
+		row := s.stamp(map[string]any{"status": 0, "isPrivate": false, "taskCount": 0, "milestoneCount": 0, "participantCount": 1})
+		fill(row, p)
+		resp := p.str("responsibleId")
+		if resp == "" {
+			resp = s.self
+		}
+		row["responsibleId"], row["responsible"] = resp, s.userRef(resp)
+		s.t(tProjects).put(row)
+		folder := s.t(tFolders).put(s.stamp(map[string]any{"title": p.str("title"), "parentId": num(projectsFolderID), "rootFolderType": rootProjects}))
+		row["projectFolder"] = num(folder)
+		return row, nil
+	})
+
+	// Project tasks, milestones and the task-scoped routes overlap
+	// /project/{id}/…, so they go first.
+	s.handle("GET /api/2.0/project/task/filter", func(r *http.Request, p params) (any, error) {
+		pid := p.str("projectId")
+		return window(s.t(tTasks).list(func(t map[string]any) bool {
+			return (pid == "" || pid == "0" || str(nested(t, "projectOwner"), "id") == pid) && matches(t, p.str("filterValue"))
+		}), p), nil
+	})
+	s.handle("GET /api/2.0/project/task/@self", func(*http.Request, params) (any, error) {
+		return s.t(tTasks).list(func(t map[string]any) bool {
+			return contains(stringsOf(t["responsibleIds"]), s.self)
+		}), nil
+	})
+	s.registerTime()
+	s.registerComments()
+	s.handle("DELETE /api/2.0/project/milestone/{id}", func(r *http.Request, p params) (any, error) {
+		return s.remove(tMilestones, r.PathValue("id"))
+	})
+	s.handle("GET /api/2.0/project/milestone/{id}/task", func(r *http.Request, p params) (any, error) {
+		if _, err := s.find(tMilestones, r.PathValue("id")); err != nil {
+			return nil, err
+		}
+		return s.milestoneTasks(r.PathValue("id")), nil
+	})
+	// Closing needs every task of the milestone closed, as on the portal.
+	s.handle("PUT /api/2.0/project/milestone/{id}/status", func(r *http.Request, p params) (any, error) {
+		m, err := s.milestone(r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		switch strings.ToLower(p.str("status")) {
+		case "0", "open":
+			m["status"] = 0
+		case "1", "closed":
+			if countOf(m, "activeTaskCount") > 0 {
+				return nil, badRequest("Can not close a milestone with open tasks")
+			}
+			m["status"] = 1
+		default:
+			return nil, badRequest("status must be open or closed")
+		}
+		m["updated"] = now()
+		return m, nil
+	})
+	s.handle("GET /api/2.0/project/milestone/{id}", func(r *http.Request, p params) (any, error) {
+		return s.milestone(r.PathValue("id"))
+	})
+	s.handle("PUT /api/2.0/project/milestone/{id}", func(r *http.Request, p params) (any, error) {
+		m, err := s.milestone(r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		if p.str("title") == "" {
+			return nil, badRequest("title is required")
+		}
+		if _, ok := parseTime(p.str("deadline")); !ok {
+			return nil, badRequest("deadline is required")
+		}
+		if resp := p.str("responsible"); resp != "" {
+			u := s.userRef(resp)
+			if u == nil {
+				return nil, notFound(tPeople, resp)
+			}
+			m["responsible"] = u
+		}
+		fill(m, p, "responsible")
+		m["updated"] = now()
+		return m, nil
+	})
+	s.handle("GET /api/2.0/project/task/{id}/files", func(r *http.Request, p params) (any, error) {
+		if _, err := s.find(tTasks, r.PathValue("id")); err != nil {
+			return nil, err
+		}
+		return s.linked(tFiles, "task/"+r.PathValue("id")), nil
+	})
+	s.handle("POST /api/2.0/project/task/{id}/files", func(r *http.Request, p params) (any, error) {
+		t, err := s.find(tTasks, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range p.strings("files") {
+			if s.t(tFiles).get(id) == nil {
+				return nil, notFound("file", id)
+			}
+			s.link("task/"+r.PathValue("id"), id)
+		}
+		return t, nil
+	})
+	s.handle("DELETE /api/2.0/project/task/{id}/files", func(r *http.Request, p params) (any, error) {
+		t, err := s.find(tTasks, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		s.unlink("task/"+r.PathValue("id"), p.str("fileid"))
+		return t, nil
+	})
+	// Gantt links live on both ends, like the portal lists them.
+	s.handle("POST /api/2.0/project/task/{id}/link", func(r *http.Request, p params) (any, error) {
+		parent, err := s.find(tTasks, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		dep, err := s.find(tTasks, p.str("dependenceTaskId"))
+		if err != nil {
+			return nil, err
+		}
+		if idOf(parent["id"]) == idOf(dep["id"]) {
+			return nil, badRequest("a task cannot depend on itself")
+		}
+		link := map[string]any{"parentTaskId": parent["id"], "dependenceTaskId": dep["id"], "linkType": p.int("linkType", 1)}
+		for _, t := range []map[string]any{parent, dep} {
+			t["links"] = append(dropLink(t, idOf(parent["id"]), idOf(dep["id"])), link)
+		}
+		return dep, nil
+	})
+	s.handle("DELETE /api/2.0/project/task/{id}/link", func(r *http.Request, p params) (any, error) {
+		parent, err := s.find(tTasks, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		dep, err := s.find(tTasks, p.str("dependenceTaskId"))
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range []map[string]any{parent, dep} {
+			t["links"] = dropLink(t, idOf(parent["id"]), idOf(dep["id"]))
+		}
+		return dep, nil
+	})
+	s.handle("PUT /api/2.0/project/task/{id}/status", func(r *http.Request, p params) (any, error) {
+		t, err := s.find(tTasks, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		t["status"], t["updated"] = p.int("status", 1), now()
+		return t, nil
+	})
+	s.handle("PUT /api/2.0/project/task/{id}/milestone", func(r *http.Request, p params) (any, error) {
+		t, err := s.find(tTasks, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		mid := p.str("milestoneid")
+		if mid == "" || mid == "0" {
+			delete(t, "milestone")
+			t["milestoneId"] = 0
+		} else {
+			m, err := s.find(tMilestones, mid)
+			if err != nil {
+				return nil, err
+			}
+			if idOf(nested(m, "projectOwner")["id"]) != idOf(nested(t, "projectOwner")["id"]) {
+				return nil, badRequest("milestone belongs to another project")
+			}
+			t["milestoneId"], t["milestone"] = m["id"], map[string]any{"id": m["id"], "title": m["title"], "deadline": m["deadline"]}
+		}
+		t["updated"] = now()
+		return t, nil
+	})
+	s.handle("GET /api/2.0/project/task/{id}", func(r *http.Request, p params) (any, error) {
+		return s.find(tTasks, r.PathValue("id"))
+	})
+	s.handle("PUT /api/2.0/project/task/{id}", func(r *http.Request, p params) (any, error) {
+		t, err := s.find(tTasks, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		fill(t, p, "responsibles")
+		s.setResponsibles(t, p)
+		t["updated"] = now()
+		return t, nil
+	})
+	// POST /project/task/{id} adds a subtask to task {id}.
+	s.handle("POST /api/2.0/project/task/{id}", func(r *http.Request, p params) (any, error) {
+		t, err := s.find(tTasks, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		if p.str("title") == "" {
+			return nil, badRequest("title is required")
+		}
+		sub := s.stamp(map[string]any{"status": 1, "taskid": t["id"], "canEdit": true})
+		fill(sub, p, "responsible")
+		if err := s.setSubtaskResponsible(sub, p.str("responsible")); err != nil {
+			return nil, err
+		}
+		s.t(tSubtasks).put(sub)
+		subs, _ := t["subtasks"].([]any)
+		t["subtasks"] = append(subs, sub)
+		return sub, nil
+	})
+	s.handle("PUT /api/2.0/project/task/{id}/{subid}", func(r *http.Request, p params) (any, error) {
+		_, sub, err := s.subtask(r.PathValue("id"), r.PathValue("subid"))
+		if err != nil {
+			return nil, err
+		}
+		if p.str("title") == "" {
+			return nil, badRequest("title is required")
+		}
+		if err := s.setSubtaskResponsible(sub, p.str("responsible")); err != nil {
+			return nil, err
+		}
+		sub["title"], sub["updated"], sub["updatedBy"] = p.str("title"), now(), s.userRef(s.self)
+		return sub, nil
+	})
+	s.handle("PUT /api/2.0/project/task/{id}/{subid}/status", func(r *http.Request, p params) (any, error) {
+		_, sub, err := s.subtask(r.PathValue("id"), r.PathValue("subid"))
+		if err != nil {
+			return nil, err
+		}
+		switch strings.ToLower(p.str("status")) {
+		case "1", "open":
+			sub["status"] = 1
+		case "2", "closed":
+			sub["status"] = 2
+		default:
+			return nil, badRequest("status must be open or closed")
+		}
+		sub["updated"], sub["updatedBy"] = now(), s.userRef(s.self)
+		return sub, nil
+	})
+	s.handle("DELETE /api/2.0/project/task/{id}/{subid}", func(r *http.Request, p params) (any, error) {
+		t, sub, err := s.subtask(r.PathValue("id"), r.PathValue("subid"))
+		if err != nil {
+			return nil, err
+		}
+		keep := []any{}
+		for _, o := range t["subtasks"].([]any) {
+			if m, _ := o.(map[string]any); idOf(m["id"]) != r.PathValue("subid") {
+				keep = append(keep, o)
+			}
+		}
+		t["subtasks"] = keep
+		s.t(tSubtasks).del(r.PathValue("subid"))
+		return sub, nil
+	})
+	s.handle("DELETE /api/2.0/project/task/{id}", func(r *http.Request, p params) (any, error) {
+		t, err := s.remove(tTasks, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		if pr := s.t(tProjects).get(str(nested(t, "projectOwner"), "id")); pr != nil {
+			pr["taskCount"] = max(0, countOf(pr, "taskCount")-1)
+		}
+		for _, l := range linksOf(t) {
+			for _, other := range []string{idOf(l["parentTaskId"]), idOf(l["dependenceTaskId"])} {
+				if o := s.t(tTasks).get(other); o != nil {
+					o["links"] = dropLink(o, idOf(l["parentTaskId"]), idOf(l["dependenceTaskId"]))
+				}
+			}
+		}
+		return t, nil
+	})
+
+	s.handle("GET /api/2.0/project/{id}/task", func(r *http.Request, p params) (any, error) {
+		pid := r.PathValue("id")
+		if _, err := s.find(tProjects, pid); err != nil {
+			return nil, err
+		}
+		return s.t(tTasks).list(func(t map[string]any) bool { return str(nested(t, "projectOwner"), "id") == pid }), nil
+	})
+	s.handle("POST /api/2.0/project/{id}/task", func(r *http.Request, p params) (any, error) {
+		pr, err := s.find(tProjects, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		if p.str("title") == "" {
+			return nil, badRequest("title is required")
+		}
+		t := s.stamp(map[string]any{"status": 1, "priority": 0, "subtasks": []any{}, "canEdit": true, "canDelete": true,
+			"projectOwner": map[string]any{"id": pr["id"], "title": pr["title"], "status": pr["status"]}})
+		fill(t, p, "responsibles")
+		s.setResponsibles(t, p)
+		s.t(tTasks).put(t)
+		pr["taskCount"] = countOf(pr, "taskCount") + 1
+		return t, nil
+	})
+	s.handle("GET /api/2.0/project/{id}/milestone", func(r *http.Request, p params) (any, error) {
+		pid := r.PathValue("id")
+		if _, err := s.find(tProjects, pid); err != nil {
+			return nil, err
+		}
+		list := s.t(tMilestones).list(func(m map[string]any) bool { return str(nested(m, "projectOwner"), "id") == pid })
+		for _, m := range list {
+			s.countMilestoneTasks(m)
+		}
+		return list, nil
+	})
+	s.handle("POST /api/2.0/project/{id}/milestone", func(r *http.Request, p params) (any, error) {
+		pr, err := s.find(tProjects, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		if p.str("title") == "" {
+			return nil, badRequest("title is required")
+		}
+		m := s.stamp(map[string]any{"status": 0, "activeTaskCount": 0, "closedTaskCount": 0,
+			"projectOwner": map[string]any{"id": pr["id"], "title": pr["title"], "status": pr["status"]}})
+		fill(m, p)
+		resp := p.str("responsible")
+		if resp == "" {
+			resp = s.self
+		}
+		m["responsible"] = s.userRef(resp)
+		s.t(tMilestones).put(m)
+		pr["milestoneCount"] = countOf(pr, "milestoneCount") + 1
+		return m, nil
+	})
+	s.handle("GET /api/2.0/project/{id}/team", func(r *http.Request, p params) (any, error) {
+		pr, err := s.find(tProjects, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		team := []map[string]any{s.t(tPeople).get(s.self)}
+		if id := str(pr, "responsibleId"); id != s.self {
+			if u := s.t(tPeople).get(id); u != nil {
+				team = append(team, u)
+			}
+		}
+		return team, nil
+	})
+	s.handle("GET /api/2.0/project/{id}/files", func(r *http.Request, p params) (any, error) {
+		pr, err := s.find(tProjects, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		folder := str(pr, "projectFolder")
+		return map[string]any{"folders": s.subfolders(folder), "files": s.folderFiles(folder)}, nil
+	})
+	s.handle("POST /api/2.0/project/{id}/contact", func(r *http.Request, p params) (any, error) {
+		if _, err := s.find(tProjects, r.PathValue("id")); err != nil {
+			return nil, err
+		}
+		c, err := s.find(tContacts, p.str("contactId"))
+		if err != nil {
+			return nil, err
+		}
+		s.link("project/"+r.PathValue("id"), p.str("contactId"))
+		return c, nil
+	})
+	s.handle("DELETE /api/2.0/project/{id}/contact", func(r *http.Request, p params) (any, error) {
+		c, err := s.find(tContacts, p.str("contactId"))
+		if err != nil {
+			return nil, err
+		}
+		s.unlink("project/"+r.PathValue("id"), p.str("contactId"))
+		return c, nil
+	})
+	s.handle("PUT /api/2.0/project/{id}/status", func(r *http.Request, p params) (any, error) {
+		pr, err := s.find(tProjects, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		code, ok := projectStatus[strings.ToLower(p.str("status"))]
+		if !ok {
+			return nil, badRequest("unknown project status %q", p.str("status"))
+		}
+		pr["status"], pr["updated"] = code, now()
+		return pr, nil
+	})
+	s.handle("GET /api/2.0/project/{id}", func(r *http.Request, p params) (any, error) {
+		return s.find(tProjects, r.PathValue("id"))
+	})
+	s.handle("PUT /api/2.0/project/{id}", func(r *http.Request, p params) (any, error) {
+		pr, err := s.find(tProjects, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		fill(pr, p)
+		if id := p.str("responsibleId"); id != "" {
+			pr["responsible"] = s.userRef(id)
+		}
+		pr["updated"] = now()
+		return pr, nil
+	})
+	s.handle("DELETE /api/2.0/project/{id}", func(r *http.Request, p params) (any, error) {
+		pid := r.PathValue("id")
+		pr, err := s.remove(tProjects, pid)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range s.t(tTasks).list(func(t map[string]any) bool { return str(nested(t, "projectOwner"), "id") == pid }) {
+			s.t(tTasks).del(str(t, "id"))
+		}
+		return pr, nil
+	})
+}
+
+// setResponsibles fills responsibleIds/responsibles from the "responsibles"
+// field (a list of user ids), defaulting to the signed-in user.
+func (s *Server) setResponsibles(t map[string]any, p params) {
+	ids := p.strings("responsibles")
+	if len(ids) == 0 {
+		if _, ok := t["responsibleIds"]; ok {
+			return
+		}
+		ids = []string{s.self}
+	}
+	refs := make([]any, 0, len(ids))
+	for _, id := range ids {
+		if u := s.userRef(id); u != nil {
+			refs = append(refs, u)
+		}
+	}
+	t["responsibleIds"], t["responsibles"] = ids, refs
+}
+
+// countOf reads a counter field regardless of its JSON number type.
+func countOf(row map[string]any, key string) int {
+	n, _ := strconv.Atoi(str(row, key))
+	return n
+}
+
+// stringsOf renders a JSON array of ids as strings.
+func stringsOf(v any) []string {
+	return params{"v": v}.strings("v")
+}
+
+// registerTime serves time tracking: entries logged on tasks, filtered
+// across projects, and their billing status.
+func (s *Server) registerTime() {
+	s.handle("GET /api/2.0/project/time/filter", func(r *http.Request, p params) (any, error) {
+		return window(s.timeEntries(p), p), nil
+	})
+	s.handle("GET /api/2.0/project/time/filter/total", func(r *http.Request, p params) (any, error) {
+		var total float64
+		for _, e := range s.timeEntries(p) {
+			total += e["hours"].(float64)
+		}
+		return total, nil
+	})
+	s.handle("PUT /api/2.0/project/time/times/status", func(r *http.Request, p params) (any, error) {
+		var out []map[string]any
+		for _, id := range p.strings("timeids") {
+			e, err := s.find(tTime, id)
+			if err != nil {
+				return nil, err
+			}
+			e["paymentStatus"], e["statusChanged"] = p.int("status", 0), now()
+			out = append(out, e)
+		}
+		return out, nil
+	})
+	s.handle("DELETE /api/2.0/project/time/times/remove", func(r *http.Request, p params) (any, error) {
+		var out []map[string]any
+		for _, id := range p.strings("timeids") {
+			e, err := s.remove(tTime, id)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, e)
+		}
+		return out, nil
+	})
+	s.handle("PUT /api/2.0/project/time/{id}", func(r *http.Request, p params) (any, error) {
+		e, err := s.find(tTime, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		return e, s.setTime(e, p)
+	})
+	s.handle("GET /api/2.0/project/task/{id}/time", func(r *http.Request, p params) (any, error) {
+		id := r.PathValue("id")
+		if _, err := s.find(tTasks, id); err != nil {
+			return nil, err
+		}
+		return s.t(tTime).list(func(e map[string]any) bool { return idOf(e["relatedTask"]) == id }), nil
+	})
+	s.handle("POST /api/2.0/project/task/{id}/time", func(r *http.Request, p params) (any, error) {
+		t, err := s.find(tTasks, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		e := map[string]any{"relatedTask": t["id"], "relatedTaskTitle": t["title"], "relatedProject": nested(t, "projectOwner")["id"],
+			"paymentStatus": 0, "canEdit": true, "canEditPaymentStatus": true, "createdBy": s.userRef(s.self), "createdOn": now()}
+		if err := s.setTime(e, p); err != nil {
+			return nil, err
+		}
+		s.t(tTime).put(e)
+		return e, nil
+	})
+}
+
+// subtask finds subtask sid in the subtasks of task id; seeded tasks carry
+// theirs only inline.
+func (s *Server) subtask(id, sid string) (task, sub map[string]any, err error) {
+	t, err := s.find(tTasks, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	subs, _ := t["subtasks"].([]any)
+	for _, o := range subs {
+		if m, ok := o.(map[string]any); ok && idOf(m["id"]) == sid {
+			return t, m, nil
+		}
+	}
+	return nil, nil, notFound(tSubtasks, sid)
+}
+
+// setSubtaskResponsible assigns a subtask to user id; "" leaves it as is.
+func (s *Server) setSubtaskResponsible(sub map[string]any, id string) error {
+	if id == "" {
+		return nil
+	}
+	u := s.userRef(id)
+	if u == nil {
+		return notFound(tPeople, id)
+	}
+	sub["responsible"] = u
+	return nil
+}
+
+// milestone finds a milestone and refreshes its task counters.
+func (s *Server) milestone(id string) (map[string]any, error) {
+	m, err := s.find(tMilestones, id)
+	if err == nil {
+		s.countMilestoneTasks(m)
+	}
+	return m, err
+}
+
+// milestoneTasks lists the tasks planned for milestone id.
+func (s *Server) milestoneTasks(id string) []map[string]any {
+	return s.t(tTasks).list(func(t map[string]any) bool { return str(t, "milestoneId") == id })
+}
+
+// countMilestoneTasks sets activeTaskCount / closedTaskCount from the tasks
+// that point at m.
+func (s *Server) countMilestoneTasks(m map[string]any) {
+	active, closed := 0, 0
+	for _, t := range s.milestoneTasks(str(m, "id")) {
+		if str(t, "status") == "2" {
+			closed++
+		} else {
+			active++
+		}
+	}
+	m["activeTaskCount"], m["closedTaskCount"] = active, closed
+}
+
+// linksOf returns the Gantt links of a task row.
+func linksOf(t map[string]any) []map[string]any {
+	var out []map[string]any
+	raw, _ := t["links"].([]any)
+	for _, l := range raw {
+		if m, ok := l.(map[string]any); ok {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// dropLink returns the links of t without the parent → dependent one.
+func dropLink(t map[string]any, parent, dependent string) []any {
+	out := []any{}
+	for _, l := range linksOf(t) {
+		if idOf(l["parentTaskId"]) != parent || idOf(l["dependenceTaskId"]) != dependent {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// setTime applies the date, hours, note and personId of a log/update.
+func (s *Server) setTime(e map[string]any, p params) error {
+	hours, err := strconv.ParseFloat(p.str("hours"), 64)
+	if err != nil || hours <= 0 {
+		return badRequest("hours must be a positive number")
+	}
+	day, ok := parseTime(p.str("date"))
+	if !ok {
+		return badRequest("date is required")
+	}
+	person := p.str("personId")
+	if person == "" {
+		person = s.self
+	}
+	if s.t(tPeople).get(person) == nil {
+		return notFound(tPeople, person)
+	}
+	e["hours"], e["date"], e["note"], e["person"] = hours, day.Format(portalTime), p.str("note"), s.userRef(person)
+	return nil
+}
+
+// timeEntries filters time entries by project, participant, date range and
+// payment status.
+func (s *Server) timeEntries(p params) []map[string]any {
+	from, hasFrom := parseTime(p.str("createdStart"))
+	to, hasTo := parseTime(p.str("createdStop"))
+	return s.t(tTime).list(func(e map[string]any) bool {
+		day, _ := parseTime(str(e, "date"))
+		return (p.str("projectid") == "" || idOf(e["relatedProject"]) == p.str("projectid")) &&
+			(p.str("participant") == "" || str(nested(e, "person"), "id") == p.str("participant")) &&
+			(!hasFrom || !day.Before(from)) && (!hasTo || !day.After(to)) &&
+			(p.str("status") == "" || idOf(e["paymentStatus"]) == p.str("status"))
+	})
+}
+
+// registerComments serves comment threads on tasks and discussions, and
+// project discussions themselves. Comment rows remember their task or
+// discussion in taskId / messageId.
+func (s *Server) registerComments() {
+	s.handle("GET /api/2.0/project/task/{id}/comment", func(r *http.Request, p params) (any, error) {
+		return s.comments("taskId", tTasks, r.PathValue("id"))
+	})
+	s.handle("POST /api/2.0/project/task/{id}/comment", func(r *http.Request, p params) (any, error) {
+		return s.addComment("taskId", tTasks, r.PathValue("id"), p)
+	})
+	s.handle("PUT /api/2.0/project/comment/{id}", func(r *http.Request, p params) (any, error) {
+		c, err := s.find(tComments, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		if p.str("content") == "" {
+			return nil, badRequest("content is required")
+		}
+		c["text"], c["updated"] = p.str("content"), now()
+		return c, nil
+	})
+	// A comment with replies stays in the thread, marked inactive.
+	s.handle("DELETE /api/2.0/project/comment/{id}", func(r *http.Request, p params) (any, error) {
+		id := r.PathValue("id")
+		c, err := s.find(tComments, id)
+		if err != nil {
+			return nil, err
+		}
+		if len(s.t(tComments).list(func(o map[string]any) bool { return str(o, "parentId") == id })) > 0 {
+			c["inactive"] = true
+			return c, nil
+		}
+		s.t(tComments).del(id)
+		if m := s.t(tDiscussions).get(str(c, "messageId")); m != nil {
+			m["commentsCount"] = max(0, countOf(m, "commentsCount")-1)
+		}
+		return c, nil
+	})
+
+	s.handle("GET /api/2.0/project/message/{id}/comment", func(r *http.Request, p params) (any, error) {
+		return s.comments("messageId", tDiscussions, r.PathValue("id"))
+	})
+	s.handle("POST /api/2.0/project/message/{id}/comment", func(r *http.Request, p params) (any, error) {
+		c, err := s.addComment("messageId", tDiscussions, r.PathValue("id"), p)
+		if err == nil {
+			m := s.t(tDiscussions).get(r.PathValue("id"))
+			m["commentsCount"] = countOf(m, "commentsCount") + 1
+		}
+		return c, err
+	})
+	s.handle("GET /api/2.0/project/message/{id}", func(r *http.Request, p params) (any, error) {
+		return s.find(tDiscussions, r.PathValue("id"))
+	})
+	s.handle("PUT /api/2.0/project/message/{id}", func(r *http.Request, p params) (any, error) {
+		m, err := s.find(tDiscussions, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		if p.str("title") == "" {
+			return nil, badRequest("title is required")
+		}
+		m["title"], m["text"], m["updated"] = p.str("title"), p.str("content"), now()
+		return m, nil
+	})
+	s.handle("DELETE /api/2.0/project/message/{id}", func(r *http.Request, p params) (any, error) {
+		id := r.PathValue("id")
+		m, err := s.remove(tDiscussions, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range s.t(tComments).list(func(c map[string]any) bool { return str(c, "messageId") == id }) {
+			s.t(tComments).del(str(c, "id"))
+		}
+		return m, nil
+	})
+	s.handle("GET /api/2.0/project/{id}/message", func(r *http.Request, p params) (any, error) {
+		pid := r.PathValue("id")
+		if _, err := s.find(tProjects, pid); err != nil {
+			return nil, err
+		}
+		return s.t(tDiscussions).list(func(m map[string]any) bool { return str(nested(m, "projectOwner"), "id") == pid }), nil
+	})
+	s.handle("POST /api/2.0/project/{id}/message", func(r *http.Request, p params) (any, error) {
+		pr, err := s.find(tProjects, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		if p.str("title") == "" {
+			return nil, badRequest("title is required")
+		}
+		m := s.stamp(map[string]any{"title": p.str("title"), "text": p.str("content"), "status": 0, "commentsCount": 0, "canEdit": true,
+			"projectOwner": map[string]any{"id": pr["id"], "title": pr["title"], "status": pr["status"]}})
+		s.t(tDiscussions).put(m)
+		return m, nil
+	})
+}
+
+// comments lists the comments of the task or discussion id, oldest first.
+func (s *Server) comments(key, parent, id string) (any, error) {
+	if _, err := s.find(parent, id); err != nil {
+		return nil, err
+	}
+	return s.t(tComments).list(func(c map[string]any) bool { return str(c, key) == id }), nil
+}
+
+func (s *Server) addComment(key, parent, id string, p params) (map[string]any, error) {
+	if _, err := s.find(parent, id); err != nil {
+		return nil, err
+	}
+	if p.str("content") == "" {
+		return nil, badRequest("content is required")
+	}
+	c := s.stamp(map[string]any{key: num(id), "text": p.str("content"), "parentId": p.str("parentId"), "inactive": false, "canEdit": true})
+	s.t(tComments).put(c)
+	return c, nil
+}
@@ -0,0 +1,414 @@
+// Package sandbox is a local stand-in for an OnlyOffice Workspace portal.
+// It serves the subset of /api/2.0 this library uses (people, projects and
+// tasks, CRM, mail, files, calendar) from in-memory state seeded with
+// recorded real payloads (cassettes, see package cassette), so the office
+// TUI and oo scripts can be demoed and smoke-tested without a portal:
+//
+//	srv := sandbox.New()
+//	cas, _ := cassette.Load("seed.json") // oo-sandbox snapshot -o seed.json
+//	srv.Seed(cas)
+//	http.ListenAndServe(":8090", srv)
+//
+// Writes (create, update, delete, merge, upload) change the in-memory state
+// only; nothing is persisted. Any user name and password sign in.
+//
+// The sandbox is for demos and smoke tests of the binaries. It is not a
+// fixture for this library's own tests, which must hit a real portal or
+// replay a cassette (see .cursor/rules/no-synthetic-mocks.mdc).
+package sandbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server is an in-memory OnlyOffice portal. It implements http.Handler and
+// is safe for concurrent use.
+type Server struct {
+	mu     sync.Mutex
+	tables map[string]*table
+	blobs  map[string][]byte   // file contents by file id
+	links  map[string][]string // project contacts and task files, by owner key
+	tags   []string            // CRM contact tags, in creation order
+	self   string              // people id of the signed-in user
+	routes []route
+
+	nextInfo int64 // ids of nested rows (contact info, addresses, invoice lines)
+}
+
+// Table names. Each holds the rows of one entity type.
+const (
+	tPeople         = "people"
+	tProjects       = "project"
+	tTasks          = "project/task"
+	tSubtasks       = "project/subtask"
+	tMilestones     = "project/milestone"
+	tTime           = "project/time"
+	tComments       = "project/comment"
+	tDiscussions    = "project/message"
+	tContacts       = "crm/contact"
+	tOpportunities  = "crm/opportunity"
+	tStages         = "crm/opportunity/stage"
+	tCases          = "crm/case"
+	tCRMTasks       = "crm/task"
+	tTaskCategories = "crm/task/category"
+	tInvoices       = "crm/invoice"
+	tInvoiceItems   = "crm/invoiceitem"
+	tHistory        = "crm/history"
+	tHistoryCats    = "crm/history/category"
+	tMailAccounts   = "mail/accounts"
+	tMailFolders    = "mail/folders"
+	tMessages       = "mail/messages"
+	tFolders        = "files/folder"
+	tFiles          = "files/file"
+	tCalendars      = "calendar"
+)
+
+// Built-in folders: "My documents" (used when no seed provides one) and the
+// root of project folders.
+const (
+	myFolderID       = "1"
+	projectsFolderID = "3"
+)
+
+// New returns an empty sandbox with a signed-in administrator and the
+// portal's built-in containers: Files roots, the mail system folders, one
+// mailbox and one calendar. Seed adds recorded data.
+func New() *Server {
+	s := &Server{tables: map[string]*table{}, blobs: map[string][]byte{}, links: map[string][]string{}}
+	for _, name := range []string{tPeople, tProjects, tTasks, tSubtasks, tMilestones, tTime, tComments, tDiscussions, tContacts, tOpportunities, tStages,
+		tCases, tCRMTasks, tTaskCategories, tInvoices, tInvoiceItems, tHistory, tHistoryCats,
+		tMailAccounts, tMailFolders, tMessages, tFolders, tFiles, tCalendars} {
+		key := "id"
+		if name == tCalendars {
+			key = "objectId"
+		}
+		s.tables[name] = newTable(key, name == tPeople || name == tComments)
+	}
+	s.self = s.t(tPeople).put(map[string]any{
+		"userName":    "admin",
+		"firstName":   "Sandbox",
+		"lastName":    "Admin",
+		"displayName": "Sandbox Admin",
+		"email":       "admin@example.com",
+		"isAdmin":     true,
+		"status":      1,
+	})
+	s.t(tFolders).put(map[string]any{"id": 1, "title": "My Documents", "parentId": 0, "rootFolderType": rootMy})
+	s.t(tFolders).put(map[string]any{"id": 2, "title": "CRM", "parentId": 0, "rootFolderType": 7})
+	s.t(tFolders).put(map[string]any{"id": 3, "title": "In Projects", "parentId": 0, "rootFolderType": rootProjects})
+	s.t(tMailAccounts).put(map[string]any{"id": 1, "mailboxId": 1, "email": "admin@example.com", "name": "Sandbox Admin",
+		"enabled": true, "isDefault": true, "isGroup": false, "isAlias": false})
+	s.t(tCalendars).put(map[string]any{"objectId": 1, "title": "Sandbox", "isEditable": true,
+		"textColor": "#000000", "backgroundColor": "#87cbff", "events": []any{}})
+	for id, title := range []string{1: "inbox", 2: "sent", 3: "drafts", 4: "trash", 5: "spam"} {
+		if title != "" {
+			s.t(tMailFolders).put(map[string]any{"id": id, "title": title, "time_modified": now()})
+		}
+	}
+	s.register()
+	return s
+}
+
+func (s *Server) t(name string) *table { return s.tables[name] }
+
+// ServeHTTP routes a portal request. The ".json" suffix OnlyOffice accepts
+// on every endpoint is optional here too.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.URL.Path = strings.TrimSuffix(r.URL.Path, ".json")
+	r.URL.RawPath = ""
+	if strings.HasPrefix(r.URL.Path, "/api/2.0/") && !strings.HasPrefix(r.URL.Path, "/api/2.0/authentication") &&
+		r.Header.Get("Authorization") == "" && !hasAuthCookie(r) {
+		writeError(w, &apiError{http.StatusUnauthorized, "System.Security.Authentication.AuthenticationException", "Unauthorized"})
+		return
+	}
+	for _, rt := range s.routes {
+		if rt.match(r) {
+			rt.serve(w, r)
+			return
+		}
+	}
+	writeError(w, &apiError{http.StatusNotFound, "System.NotSupportedException",
+		fmt.Sprintf("sandbox: %s %s is not implemented", r.Method, r.URL.Path)})
+}
+
+func hasAuthCookie(r *http.Request) bool {
+	c, err := r.Cookie("asc_auth_key")
+	return err == nil && c.Value != ""
+}
+
+// apiError is rendered as the portal's error envelope.
+type apiError struct {
+	status  int
+	typ     string
+	message string
+}
+
+func (e *apiError) Error() string { return e.message }
+
+func notFound(what, id string) error {
+	return &apiError{http.StatusNotFound, "ASC.Api.Exceptions.ItemNotFoundException", fmt.Sprintf("%s %s not found", what, id)}
+}
+
+func badRequest(format string, args ...any) error {
+	return &apiError{http.StatusBadRequest, "System.ArgumentException", fmt.Sprintf(format, args...)}
+}
+
+func writeError(w http.ResponseWriter, e *apiError) {
+	writeJSON(w, e.status, map[string]any{
+		"status":     1,
+		"statusCode": e.status,
+		"error":      map[string]any{"message": e.message, "type": e.typ},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// page is a list response: Rows is the requested window, Total the size of
+// the whole result (the envelope "total").
+type page struct {
+	Rows  []map[string]any
+	Total int
+}
+
+// handler serves one route while holding the server lock. It returns the
+// value for the envelope "response" field (a page for lists).
+type handler func(r *http.Request, p params) (any, error)
+
+// route is one "METHOD /path/{wildcard}" pattern. Routes are tried in
+// registration order, so literal paths are registered before the wildcard
+// paths they overlap (net/http.ServeMux rejects such pairs, and the portal's
+// routes overlap a lot: /project/task/{id} vs /project/{id}/task).
+type route struct {
+	method string
+	segs   []string
+	serve  http.HandlerFunc
+}
+
+func (rt route) match(r *http.Request) bool {
+	if r.Method != rt.method {
+		return false
+	}
+	segs := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segs) != len(rt.segs) {
+		return false
+	}
+	for i, seg := range rt.segs {
+		if !strings.HasPrefix(seg, "{") && seg != segs[i] {
+			return false
+		}
+	}
+	for i, seg := range rt.segs {
+		if strings.HasPrefix(seg, "{") {
+			r.SetPathValue(strings.Trim(seg, "{}"), segs[i])
+		}
+	}
+	return true
+}
+
+// handle registers pattern ("GET /api/2.0/people/{id}").
+func (s *Server) handle(pattern string, h handler) {
+	method, path, _ := strings.Cut(pattern, " ")
+	s.routes = append(s.routes, route{method: method, segs: strings.Split(strings.Trim(path, "/"), "/"), serve: func(w http.ResponseWriter, r *http.Request) {
+		p, err := readParams(r)
+		if err != nil {
+			writeError(w, &apiError{http.StatusBadRequest, "System.ArgumentException", err.Error()})
+			return
+		}
+		s.mu.Lock()
+		out, err := h(r, p)
+		s.mu.Unlock()
+		if err != nil {
+			e, ok := err.(*apiError)
+			if !ok {
+				e = &apiError{http.StatusInternalServerError, "System.Exception", err.Error()}
+			}
+			writeError(w, e)
+			return
+		}
+		env := map[string]any{"status": 0, "statusCode": http.StatusOK, "response": out, "count": 1}
+		switch v := out.(type) {
+		case page:
+			env["response"], env["count"], env["total"] = v.Rows, len(v.Rows), v.Total
+		case []map[string]any:
+			env["count"] = len(v)
+		}
+		writeJSON(w, http.StatusOK, env)
+	}})
+}
+
+// params are the request's query, form and JSON body fields merged (body
+// wins). Form values are strings; JSON values keep their types.
+type params map[string]any
+
+func readParams(r *http.Request) (params, error) {
+	p := params{}
+	for k, v := range r.URL.Query() {
+		p[k] = v[len(v)-1]
+	}
+	if r.Body == nil {
+		return p, nil
+	}
+	ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch ct {
+	case "application/json":
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		if len(strings.TrimSpace(string(b))) == 0 {
+			return p, nil
+		}
+		dec := json.NewDecoder(strings.NewReader(string(b)))
+		dec.UseNumber()
+		var body map[string]any
+		if err := dec.Decode(&body); err != nil {
+			return nil, fmt.Errorf("invalid JSON body: %w", err)
+		}
+		for k, v := range body {
+			p[k] = v
+		}
+	case "application/x-www-form-urlencoded":
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		form, err := url.ParseQuery(string(b))
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range form {
+			if strings.HasSuffix(k, "[]") || len(v) > 1 {
+				p[strings.TrimSuffix(k, "[]")] = v
+				continue
+			}
+			p[k] = v[0]
+		}
+	}
+	return p, nil
+}
+
+func (p params) str(key string) string { return idOf(p[key]) }
+
+func (p params) int(key string, def int) int {
+	if n, err := strconv.Atoi(p.str(key)); err == nil {
+		return n
+	}
+	return def
+}
+
+func (p params) bool(key string) bool {
+	switch v := p[key].(type) {
+	case bool:
+		return v
+	default:
+		b, _ := strconv.ParseBool(p.str(key))
+		return b
+	}
+}
+
+// strings returns a list field (JSON array, repeated form key or a single
+// value).
+func (p params) strings(key string) []string {
+	switch v := p[key].(type) {
+	case nil:
+		return nil
+	case []string:
+		return v
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, x := range v {
+			out = append(out, idOf(x))
+		}
+		return out
+	default:
+		if s := idOf(v); s != "" {
+			return []string{s}
+		}
+		return nil
+	}
+}
+
+// numericFields are form fields the portal returns as numbers; everything
+// else submitted as a form value is stored as a string.
+var numericFields = map[string]bool{
+	"priority": true, "status": true, "stageId": true, "categoryId": true, "companyId": true,
+	"contactId": true, "entityId": true, "bidValue": true, "bidType": true, "perPeriodValue": true,
+	"successProbability": true, "milestoneId": true, "price": true, "quantity": true,
+	"folder": true, "projectId": true,
+}
+
+// fill copies request fields onto row, converting form strings for
+// numeric and boolean fields. Keys in skip are left alone.
+func fill(row map[string]any, p params, skip ...string) {
+	for k, v := range p {
+		if k == "id" || contains(skip, k) {
+			continue
+		}
+		if s, ok := v.(string); ok {
+			if t, ok := parseTime(s); ok && dateFields[k] {
+				v = t.Format(portalTime)
+			} else if n, err := strconv.ParseFloat(s, 64); err == nil && numericFields[k] {
+				v = n
+			} else if b, err := strconv.ParseBool(s); err == nil && strings.HasPrefix(k, "is") {
+				v = b
+			}
+		}
+		row[k] = v
+	}
+}
+
+func contains(list []string, v string) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// portalTime is the portal's timestamp format (RFC 3339 with 7 fractional
+// digits).
+const portalTime = "2006-01-02T15:04:05.0000000Z07:00"
+
+func now() string { return time.Now().Format(portalTime) }
+
+// dateFields are stored in portalTime whatever shape the client sent
+// (clients send zone-less or date-only values; the portal answers RFC 3339).
+var dateFields = map[string]bool{
+	"deadline": true, "startDate": true, "endDate": true, "dueDate": true, "issueDate": true,
+	"expectedCloseDate": true, "actualCloseDate": true, "start": true, "end": true,
+}
+
+func parseTime(v string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, v, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// window applies count/startIndex paging (the CRM, people and project
+// filter endpoints) to rows.
+func window(rows []map[string]any, p params) page {
+	total := len(rows)
+	start := min(max(p.int("startIndex", 0), 0), total)
+	end := total
+	if n := p.int("count", 0); n > 0 {
+		end = min(start+n, total)
+	}
+	return page{Rows: rows[start:end], Total: total}
+}
@@ -0,0 +1,490 @@
+package sandbox_test
+
+// These tests exercise the sandbox itself, driving it with the real client
+// the way the office TUI and oo do, or with plain requests (sandboxAPI)
+// where only its store semantics are under test. They say nothing about a
+// real portal.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
+	"github.com/eslider/go-onlyoffice/cassette"
+	"github.com/eslider/go-onlyoffice/sandbox"
+)
+
+func sandboxClient(t *testing.T, s *sandbox.Server) *onlyoffice.Client {
+	t.Helper()
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	return onlyoffice.NewClient(onlyoffice.Credentials{Url: srv.URL, User: "admin@example.com", Password: "any"},
+		onlyoffice.WithRetryPolicy(onlyoffice.RetryPolicy{}))
+}
+
+func TestProjectsAndTasks(t *testing.T) {
+	ctx := context.Background()
+	c := sandboxClient(t, sandbox.New())
+
+	prj, err := c.CreateProjectContext(ctx, onlyoffice.NewProjectRequest{Title: "Demo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prj.ID == nil || *prj.Title != "Demo" {
+		t.Fatalf("project = %+v", prj)
+	}
+	task, err := c.AddTask(ctx, strconv.Itoa(*prj.ID), "Write docs", "", 1, "2030-01-31")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := idString(task["id"])
+	if _, err := c.CreateSubtask(ctx, int(int64FromID(task["id"])), "Proofread", ""); err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.GetTaskByID(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subs, _ := got["subtasks"].([]any); len(subs) != 1 {
+		t.Errorf("subtasks = %v, want 1", got["subtasks"])
+	}
+	tasks, err := c.ListTasks(ctx, strconv.Itoa(*prj.ID), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 1 {
+		t.Errorf("ListTasks = %d tasks, want 1", len(tasks))
+	}
+
+	if _, err := c.GetTaskByID(ctx, "999999"); !errors.Is(err, onlyoffice.ErrNotFound) {
+		t.Errorf("missing task: err = %v, want ErrNotFound", err)
+	}
+}
+
+// TestTimeStore checks the time-tracking store: entries take the project
+// of their task, the filters combine, totals add up and removal is by id
+// list.
+func TestTimeStore(t *testing.T) {
+	a := newSandboxAPI(t)
+	prj := a.obj("POST", "/api/2.0/project", map[string]any{"title": "Billing"})
+	pid := idString(prj["id"])
+	task := a.obj("POST", "/api/2.0/project/"+pid+"/task", map[string]any{"title": "Consulting"})
+	logPath := "/api/2.0/project/task/" + idString(task["id"]) + "/time"
+
+	if st, _ := a.do("POST", logPath, map[string]any{"date": "2026-10-12", "hours": 0}); st != http.StatusBadRequest {
+		t.Errorf("zero hours: status %d, want 400", st)
+	}
+	first := a.obj("POST", logPath, map[string]any{"date": "2026-10-12", "hours": 2, "note": "kickoff"})
+	if idString(first["relatedProject"]) != pid || first["person"] == nil {
+		t.Fatalf("logged = %v", first)
+	}
+	second := a.obj("POST", logPath, map[string]any{"date": "2026-10-19", "hours": 1.5})
+
+	week := a.list("/api/2.0/project/time/filter?projectid=" + pid + "&createdStart=2026-10-12&createdStop=2026-10-18")
+	if len(week) != 1 || idString(week[0].(map[string]any)["id"]) != idString(first["id"]) {
+		t.Errorf("week = %v", week)
+	}
+	if _, total := a.do("GET", "/api/2.0/project/time/filter/total?projectid="+pid, nil); total != 3.5 {
+		t.Errorf("total = %v, want 3.5", total)
+	}
+	a.do("PUT", "/api/2.0/project/time/times/status", map[string]any{"timeids": []any{first["id"]}, "status": 2})
+	if billed := a.list("/api/2.0/project/time/filter?status=2"); len(billed) != 1 {
+		t.Errorf("billed = %v", billed)
+	}
+
+	ids := map[string]any{"timeids": []any{first["id"], second["id"]}}
+	if st, _ := a.do("DELETE", "/api/2.0/project/time/times/remove", ids); st != http.StatusOK {
+		t.Fatalf("remove: status %d", st)
+	}
+	if left := a.list(logPath); len(left) != 0 {
+		t.Errorf("after remove: %v", left)
+	}
+	if st, _ := a.do("DELETE", "/api/2.0/project/time/times/remove", ids); st != http.StatusNotFound {
+		t.Errorf("second remove: status %d, want 404", st)
+	}
+}
+
+// TestCommentStore checks comment threads and discussions: a comment with
+// replies survives its deletion as inactive, discussions count their
+// comments, and deleting a discussion takes its thread along.
+func TestCommentStore(t *testing.T) {
+	a := newSandboxAPI(t)
+	prj := a.obj("POST", "/api/2.0/project", map[string]any{"title": "Talk"})
+	pid := idString(prj["id"])
+	task := a.obj("POST", "/api/2.0/project/"+pid+"/task", map[string]any{"title": "Review"})
+	thread := "/api/2.0/project/task/" + idString(task["id"]) + "/comment"
+
+	if st, _ := a.do("POST", thread, map[string]any{"content": ""}); st != http.StatusBadRequest {
+		t.Errorf("empty comment: status %d, want 400", st)
+	}
+	first := a.obj("POST", thread, map[string]any{"content": "<p>Looks good</p>"})
+	reply := a.obj("POST", thread, map[string]any{"content": "<p>Thanks</p>", "parentId": first["id"]})
+	a.obj("DELETE", "/api/2.0/project/comment/"+idString(first["id"]), nil)
+	if got := a.list(thread); len(got) != 2 || got[0].(map[string]any)["inactive"] != true {
+		t.Fatalf("parent with a reply after delete: %v", got)
+	}
+	a.obj("DELETE", "/api/2.0/project/comment/"+idString(reply["id"]), nil)
+	if got := a.list(thread); len(got) != 1 {
+		t.Errorf("after deleting the reply: %v", got)
+	}
+
+	d := a.obj("POST", "/api/2.0/project/"+pid+"/message", map[string]any{"title": "Kickoff", "content": "<p>Agenda</p>"})
+	msg := "/api/2.0/project/message/" + idString(d["id"])
+	c := a.obj("POST", msg+"/comment", map[string]any{"content": "<p>+1</p>"})
+	a.obj("POST", msg+"/comment", map[string]any{"content": "<p>+2</p>"})
+	a.obj("DELETE", "/api/2.0/project/comment/"+idString(c["id"]), nil)
+	if got := a.obj("GET", msg, nil); got["commentsCount"] != 1.0 {
+		t.Errorf("commentsCount = %v, want 1", got["commentsCount"])
+	}
+	if st, _ := a.do("PUT", msg, map[string]any{"title": ""}); st != http.StatusBadRequest {
+		t.Errorf("untitled discussion: status %d, want 400", st)
+	}
+	a.obj("DELETE", msg, nil)
+	if st, _ := a.do("GET", msg+"/comment", nil); st != http.StatusNotFound {
+		t.Errorf("comments of a deleted discussion: status %d, want 404", st)
+	}
+}
+
+// TestSubtaskStore checks subtasks: they live inline in their task, only
+// open and closed are valid states, assignees must exist, and deleting one
+// drops it from the task.
+func TestSubtaskStore(t *testing.T) {
+	a := newSandboxAPI(t)
+	prj := a.obj("POST", "/api/2.0/project", map[string]any{"title": "Checklist"})
+	task := a.obj("POST", "/api/2.0/project/"+idString(prj["id"])+"/task", map[string]any{"title": "Release"})
+	taskPath := "/api/2.0/project/task/" + idString(task["id"])
+	subtasks := func() []any {
+		l, _ := a.obj("GET", taskPath, nil)["subtasks"].([]any)
+		return l
+	}
+
+	if st, _ := a.do("POST", taskPath, map[string]any{"title": "Tag", "responsible": "nobody"}); st != http.StatusNotFound {
+		t.Errorf("unknown responsible: status %d, want 404", st)
+	}
+	sub := a.obj("POST", taskPath, map[string]any{"title": "Tag"})
+	a.obj("POST", taskPath, map[string]any{"title": "Announce"})
+	subPath := taskPath + "/" + idString(sub["id"])
+	if st, _ := a.do("PUT", subPath+"/status", map[string]any{"status": "later"}); st != http.StatusBadRequest {
+		t.Errorf("status later: status %d, want 400", st)
+	}
+	a.obj("PUT", subPath+"/status", map[string]any{"status": 2})
+	if l := subtasks(); len(l) != 2 || l[0].(map[string]any)["status"] != 2.0 {
+		t.Fatalf("subtasks = %v", l)
+	}
+
+	a.obj("DELETE", subPath, nil)
+	if l := subtasks(); len(l) != 1 || l[0].(map[string]any)["title"] != "Announce" {
+		t.Errorf("after delete: %v", l)
+	}
+	if st, _ := a.do("DELETE", subPath, nil); st != http.StatusNotFound {
+		t.Errorf("second delete: status %d, want 404", st)
+	}
+}
+
+// TestLinkStore checks Gantt links: they are listed on both tasks, a task
+// cannot depend on itself, relinking replaces, and deleting a task strips
+// its links from the others.
+func TestLinkStore(t *testing.T) {
+	a := newSandboxAPI(t)
+	prj := a.obj("POST", "/api/2.0/project", map[string]any{"title": "Plan"})
+	var ids []string
+	for _, title := range []string{"Design", "Build", "Docs"} {
+		task := a.obj("POST", "/api/2.0/project/"+idString(prj["id"])+"/task", map[string]any{"title": title})
+		ids = append(ids, idString(task["id"]))
+	}
+	taskPath := func(id string) string { return "/api/2.0/project/task/" + id }
+	links := func(id string) []any {
+		l, _ := a.obj("GET", taskPath(id), nil)["links"].([]any)
+		return l
+	}
+
+	if st, _ := a.do("POST", taskPath(ids[0])+"/link", map[string]any{"dependenceTaskId": ids[0]}); st != http.StatusBadRequest {
+		t.Errorf("self link: status %d, want 400", st)
+	}
+	a.obj("POST", taskPath(ids[0])+"/link", map[string]any{"dependenceTaskId": ids[1], "linkType": 1})
+	a.obj("POST", taskPath(ids[0])+"/link", map[string]any{"dependenceTaskId": ids[1], "linkType": 2})
+	a.obj("POST", taskPath(ids[0])+"/link", map[string]any{"dependenceTaskId": ids[2], "linkType": 1})
+	if l := links(ids[1]); len(l) != 1 || l[0].(map[string]any)["linkType"] != 2.0 {
+		t.Errorf("relinked dependent: %v", l)
+	}
+	if l := links(ids[0]); len(l) != 2 {
+		t.Errorf("parent lists %d links, want 2", len(l))
+	}
+
+	a.obj("DELETE", taskPath(ids[0])+"/link", map[string]any{"dependenceTaskId": ids[1]})
+	if l := links(ids[1]); len(l) != 0 {
+		t.Errorf("unlinked dependent keeps %v", l)
+	}
+	a.obj("DELETE", taskPath(ids[2]), nil)
+	if l := links(ids[0]); len(l) != 0 {
+		t.Errorf("parent keeps links to a deleted task: %v", l)
+	}
+}
+
+// TestMilestoneStore checks milestones: task counters follow the tasks
+// that point at them, closing needs every task closed, and tasks only move
+// to milestones of their own project.
+func TestMilestoneStore(t *testing.T) {
+	a := newSandboxAPI(t)
+	prj := a.obj("POST", "/api/2.0/project", map[string]any{"title": "Roadmap"})
+	other := a.obj("POST", "/api/2.0/project", map[string]any{"title": "Elsewhere"})
+	ms := a.obj("POST", "/api/2.0/project/"+idString(prj["id"])+"/milestone", map[string]any{"title": "Beta", "deadline": "2030-03-01"})
+	foreign := a.obj("POST", "/api/2.0/project/"+idString(other["id"])+"/milestone", map[string]any{"title": "Other", "deadline": "2030-03-01"})
+	task := a.obj("POST", "/api/2.0/project/"+idString(prj["id"])+"/task", map[string]any{"title": "Freeze"})
+	msPath := "/api/2.0/project/milestone/" + idString(ms["id"])
+	taskPath := "/api/2.0/project/task/" + idString(task["id"])
+
+	if st, _ := a.do("PUT", taskPath+"/milestone", map[string]any{"milestoneid": foreign["id"]}); st != http.StatusBadRequest {
+		t.Errorf("move to another project's milestone: status %d, want 400", st)
+	}
+	a.obj("PUT", taskPath+"/milestone", map[string]any{"milestoneid": ms["id"]})
+	if got := a.obj("GET", msPath, nil); got["activeTaskCount"] != 1.0 || got["closedTaskCount"] != 0.0 {
+		t.Errorf("counters with an open task: %v / %v", got["activeTaskCount"], got["closedTaskCount"])
+	}
+	if st, _ := a.do("PUT", msPath+"/status", map[string]any{"status": "closed"}); st != http.StatusBadRequest {
+		t.Errorf("close with an open task: status %d, want 400", st)
+	}
+	a.obj("PUT", taskPath+"/status", map[string]any{"status": 2})
+	if got := a.obj("PUT", msPath+"/status", map[string]any{"status": "closed"}); got["status"] != 1.0 || got["closedTaskCount"] != 1.0 {
+		t.Errorf("closed milestone = %v", got)
+	}
+
+	a.obj("PUT", taskPath+"/milestone", map[string]any{"milestoneid": 0})
+	if l := a.list(msPath + "/task"); len(l) != 0 {
+		t.Errorf("after moving the task out: %v", l)
+	}
+	if st, _ := a.do("PUT", msPath, map[string]any{"title": "Beta 1"}); st != http.StatusBadRequest {
+		t.Errorf("update without a deadline: status %d, want 400", st)
+	}
+}
+
+func TestCRMContacts(t *testing.T) {
+	ctx := context.Background()
+	c := sandboxClient(t, sandbox.New())
+
+	co, err := c.CreateCompany(ctx, "Acme GmbH")
+	if err != nil {
+		t.Fatal(err)
+	}
+	coID := idString(co["id"])
+	if _, err := c.AddContactInfo(ctx, coID, "Email", "info@acme.example", "Work", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddContactTag(ctx, coID, "customer"); err != nil {
+		t.Fatal(err)
+	}
+	tagged, total, err := c.ListContactsByTag(ctx, "customer", 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || len(tagged) != 1 {
+		t.Errorf("ListContactsByTag = %d rows (total %d), want 1", len(tagged), total)
+	}
+	companies, _, err := c.ListCompanies(ctx, 10, 0, "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(companies) != 1 || companies[0].CompanyName != "Acme GmbH" {
+		t.Errorf("ListCompanies = %+v", companies)
+	}
+}
+
+func TestFilesRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	c := sandboxClient(t, sandbox.New())
+
+	dir, err := c.CreateDavFolder(ctx, "@my", "reports")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := c.UploadDavFile(ctx, dir.ID, "q1.txt", strings.NewReader("quarter one"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, err := c.DownloadDavFile(ctx, f.ID, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "quarter one" {
+		t.Errorf("download = %q", buf.String())
+	}
+	l, err := c.ListDavFolder(ctx, dir.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Files) != 1 || l.Files[0].Size != int64(len("quarter one")) {
+		t.Errorf("listing = %+v", l.Files)
+	}
+	if err := c.DeleteDavItems(ctx, []string{dir.ID}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetFile(ctx, f.ID); !errors.Is(err, onlyoffice.ErrNotFound) {
+		t.Errorf("file after folder delete: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMailAndCalendar(t *testing.T) {
+	ctx := context.Background()
+	c := sandboxClient(t, sandbox.New())
+
+	draft, err := c.SaveMailDraft(ctx, onlyoffice.SaveMailDraftParams{To: "bob@example.com", Subject: "Hi", Body: "<p>Hello Bob</p>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.SendMail(ctx, onlyoffice.SendMailParams{ID: int64FromID(draft["id"]), To: "bob@example.com", Subject: "Hi", Body: "<p>Hello Bob</p>"}); err != nil {
+		t.Fatal(err)
+	}
+	sent, err := c.ListMailMessages(ctx, onlyoffice.MailMessagesFilter{Folder: onlyoffice.MailFolderSent})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != 1 || sent[0]["introduction"] != "Hello Bob" {
+		t.Errorf("sent = %v", sent)
+	}
+
+	ev, err := c.AddEvent(ctx, "1", "Standup", "2030-01-15T09:00:00", "2030-01-15T09:15:00", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cals, err := c.ListCalendars(ctx, "2030-01-01", "2030-01-31")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events, _ := cals[0]["events"].([]any); len(events) != 1 {
+		t.Errorf("events in range = %v, want the new event", cals[0]["events"])
+	}
+	if _, err := c.DeleteEvent(ctx, idString(ev["objectId"])); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSeed(t *testing.T) {
+	ctx := context.Background()
+
+	// Record a session against one sandbox, then seed a fresh one from it.
+	src := sandbox.New()
+	path := filepath.Join(t.TempDir(), "seed.json")
+	rec := cassette.New(path)
+	srv := httptest.NewServer(src)
+	defer srv.Close()
+	c := onlyoffice.NewClient(onlyoffice.Credentials{Url: srv.URL, User: "admin@example.com", Password: "any"},
+		onlyoffice.WithMiddleware(rec.Middleware()))
+	if _, err := c.CreateCompany(ctx, "Seeded Ltd"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListAllContacts(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	cas, err := cassette.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := sandbox.New()
+	if n := s.Seed(cas); n == 0 {
+		t.Fatal("Seed stored no rows")
+	}
+	contacts, err := sandboxClient(t, s).ListAllContacts(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contacts) != 1 || contacts[0]["displayName"] != "Seeded Ltd" {
+		t.Errorf("seeded contacts = %v", contacts)
+	}
+}
+
+// sandboxAPI calls the sandbox without the client, for store semantics
+// (counters, cascades, validation) the client has no say in.
+type sandboxAPI struct {
+	t   *testing.T
+	srv *httptest.Server
+}
+
+func newSandboxAPI(t *testing.T) *sandboxAPI {
+	t.Helper()
+	srv := httptest.NewServer(sandbox.New())
+	t.Cleanup(srv.Close)
+	return &sandboxAPI{t: t, srv: srv}
+}
+
+// do sends body as JSON and returns the status and the envelope's
+// "response" field.
+func (a *sandboxAPI) do(method, path string, body map[string]any) (int, any) {
+	a.t.Helper()
+	var rd io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			a.t.Fatal(err)
+		}
+		rd = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, a.srv.URL+path, rd)
+	if err != nil {
+		a.t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "sandbox")
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.srv.Client().Do(req)
+	if err != nil {
+		a.t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var env struct {
+		Response any `json:"response"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		a.t.Fatalf("%s %s: %v", method, path, err)
+	}
+	return resp.StatusCode, env.Response
+}
+
+// obj is do for a call that must answer an object.
+func (a *sandboxAPI) obj(method, path string, body map[string]any) map[string]any {
+	a.t.Helper()
+	st, out := a.do(method, path, body)
+	m, ok := out.(map[string]any)
+	if st != http.StatusOK || !ok {
+		a.t.Fatalf("%s %s: status %d, response %v", method, path, st, out)
+	}
+	return m
+}
+
+// list is a GET that must answer an array.
+func (a *sandboxAPI) list(path string) []any {
+	a.t.Helper()
+	st, out := a.do("GET", path, nil)
+	l, ok := out.([]any)
+	if st != http.StatusOK || (!ok && out != nil) {
+		a.t.Fatalf("GET %s: status %d, response %v", path, st, out)
+	}
+	return l
+}
+
+// idString renders a decoded JSON id (float64 or string).
+func idString(v any) string {
+	switch x := v.(type) {
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case string:
+		return x
+	}
+	return ""
+}
+
+func int64FromID(v any) int64 {
+	n, _ := strconv.ParseInt(idString(v), 10, 64)
+	return n
+}
@@ -0,0 +1,178 @@
+package sandbox
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
+	"github.com/eslider/go-onlyoffice/cassette"
+)
+
+// seeders map a recorded GET route (onlyoffice.RouteTemplate form) to the
+// table its response rows belong in.
+var seeders = map[string]string{
+	"/api/2.0/people":                          tPeople,
+	"/api/2.0/people/filter":                   tPeople,
+	"/api/2.0/people/{id}":                     tPeople,
+	"/api/2.0/project":                         tProjects,
+	"/api/2.0/project/filter":                  tProjects,
+	"/api/2.0/project/{id}":                    tProjects,
+	"/api/2.0/project/task/filter":             tTasks,
+	"/api/2.0/project/task/@self":              tTasks,
+	"/api/2.0/project/task/{id}":               tTasks,
+	"/api/2.0/project/{id}/task":               tTasks,
+	"/api/2.0/project/{id}/milestone":          tMilestones,
+	"/api/2.0/crm/contact/filter":              tContacts,
+	"/api/2.0/crm/contact/{id}":                tContacts,
+	"/api/2.0/crm/contact/company/{id}/person": tContacts,
+	"/api/2.0/crm/opportunity/filter":          tOpportunities,
+	"/api/2.0/crm/opportunity/{id}":            tOpportunities,
+	"/api/2.0/crm/opportunity/stage":           tStages,
+	"/api/2.0/crm/case/filter":                 tCases,
+	"/api/2.0/crm/task/filter":                 tCRMTasks,
+	"/api/2.0/crm/task/category":               tTaskCategories,
+	"/api/2.0/crm/invoice/filter":              tInvoices,
+	"/api/2.0/crm/invoice/{id}":                tInvoices,
+	"/api/2.0/crm/invoiceitem/filter":          tInvoiceItems,
+	"/api/2.0/crm/history/filter":              tHistory,
+	"/api/2.0/crm/history/category":            tHistoryCats,
+	"/api/2.0/mail/accounts":                   tMailAccounts,
+	"/api/2.0/mail/folders":                    tMailFolders,
+	"/api/2.0/mail/messages":                   tMessages,
+	"/api/2.0/mail/messages/{id}":              tMessages,
+	"/api/2.0/files/file/{id}":                 tFiles,
+}
+
+// linkSeeders are listings that relate rows rather than define them: the
+// rows go in the table and their ids are linked under the owner key plus
+// the route's {id}.
+var linkSeeders = map[string]struct{ table, owner string }{
+	"/api/2.0/project/task/{id}/files":    {tFiles, "task/"},
+	"/api/2.0/crm/opportunity/{id}/files": {tFiles, "crm/opportunity/"},
+	"/api/2.0/crm/contact/{id}/files":     {tFiles, "crm/contact/"},
+	"/api/2.0/crm/contact/project/{id}":   {tContacts, "project/"},
+}
+
+// Seed loads the successful GET responses recorded in c into the sandbox
+// and returns the number of rows stored. Rows seen several times are
+// merged, so a detail response adds to the list entry recorded before it.
+// Responses the sandbox has no table for are ignored.
+func (s *Server) Seed(c *cassette.Cassette) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, in := range c.Interactions {
+		if in.Method != "GET" || in.Status/100 != 2 || in.Base64 {
+			continue
+		}
+		resp := envelopeResponse(in.Body)
+		if resp == nil {
+			continue
+		}
+		route := onlyoffice.RouteTemplate(in.Path)
+		if name, ok := seeders[route]; ok {
+			for _, row := range rowsOf(resp) {
+				s.t(name).merge(row)
+				n++
+			}
+			continue
+		}
+		switch {
+		case route == "/api/2.0/people/@self":
+			if rows := rowsOf(resp); len(rows) == 1 {
+				s.self = s.t(tPeople).merge(rows[0])
+				n++
+			}
+		case strings.HasPrefix(route, "/api/2.0/calendar/calendars/"):
+			for _, cal := range rowsOf(resp) {
+				s.t(tCalendars).merge(cal)
+				n++
+			}
+		case route == "/api/2.0/project/{id}/files" || strings.HasPrefix(route, "/api/2.0/files/"):
+			n += s.seedListing(resp)
+		default:
+			if l, ok := linkSeeders[route]; ok {
+				owner := l.owner + strings.Split(strings.TrimPrefix(in.Path, "/"), "/")[idSegment(route)]
+				for _, row := range rowsOf(resp) {
+					s.link(owner, s.t(l.table).merge(row))
+					n++
+				}
+			}
+		}
+	}
+	return n
+}
+
+// seedListing stores the folders and files of a Files listing (one folder
+// object, or the @root array of them).
+func (s *Server) seedListing(resp any) int {
+	n := 0
+	for _, l := range rowsOf(resp) {
+		if cur := nested(l, "current"); cur != nil {
+			s.t(tFolders).merge(cur)
+			n++
+		}
+		for _, key := range []string{"folders", "files"} {
+			name := tFolders
+			if key == "files" {
+				name = tFiles
+			}
+			for _, row := range members(l, key) {
+				if m, ok := row.(map[string]any); ok {
+					if key == "files" && m["folderId"] == nil {
+						m["folderId"] = nested(l, "current")["id"]
+					}
+					s.t(name).merge(m)
+					n++
+				}
+			}
+		}
+	}
+	return n
+}
+
+// envelopeResponse decodes the "response" field of a recorded body, keeping
+// numbers exact.
+func envelopeResponse(body string) any {
+	dec := json.NewDecoder(bytes.NewReader([]byte(body)))
+	dec.UseNumber()
+	var env struct {
+		Response any `json:"response"`
+	}
+	if dec.Decode(&env) != nil {
+		return nil
+	}
+	return env.Response
+}
+
+// rowsOf returns the objects of a response: the array elements, or the
+// object itself.
+func rowsOf(resp any) []map[string]any {
+	switch v := resp.(type) {
+	case map[string]any:
+		if idOf(v["id"]) == "" && idOf(v["objectId"]) == "" && nested(v, "current") == nil {
+			return nil
+		}
+		return []map[string]any{v}
+	case []any:
+		var out []map[string]any
+		for _, x := range v {
+			if m, ok := x.(map[string]any); ok {
+				out = append(out, m)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// idSegment is the index of the first {id} in a route's path segments.
+func idSegment(route string) int {
+	for i, seg := range strings.Split(strings.TrimPrefix(route, "/"), "/") {
+		if seg == "{id}" {
+			return i
+		}
+	}
+	return 0
+}
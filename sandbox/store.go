@@ -0,0 +1,140 @@
+package sandbox
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// table is one in-memory entity set, kept in insertion order so listings
+// are stable. Rows are plain JSON objects: exactly what the portal returned
+// when seeded, or what the sandbox built when created through the API.
+type table struct {
+	rows  map[string]map[string]any
+	order []string
+	next  int64
+	guid  bool   // ids are GUIDs (people) rather than sequential integers
+	key   string // id field: "id", or "objectId" for calendars
+}
+
+func newTable(key string, guid bool) *table {
+	return &table{rows: map[string]map[string]any{}, next: 1, guid: guid, key: key}
+}
+
+// put inserts or replaces row, assigning an id when it has none, and
+// returns the id.
+func (t *table) put(row map[string]any) string {
+	id := idOf(row[t.key])
+	if id == "" {
+		if t.guid {
+			id = newGUID()
+			row[t.key] = id
+		} else {
+			id = strconv.FormatInt(t.next, 10)
+			row[t.key] = t.next
+		}
+	}
+	if n, err := strconv.ParseInt(id, 10, 64); err == nil && n >= t.next {
+		t.next = n + 1
+	}
+	if _, ok := t.rows[id]; !ok {
+		t.order = append(t.order, id)
+	}
+	t.rows[id] = row
+	return id
+}
+
+// merge upserts row, keeping fields of an existing row that row lacks (a
+// list entry seeded after the full record must not drop its details).
+func (t *table) merge(row map[string]any) string {
+	if old := t.rows[idOf(row[t.key])]; old != nil {
+		for k, v := range row {
+			old[k] = v
+		}
+		return idOf(row[t.key])
+	}
+	return t.put(row)
+}
+
+func (t *table) get(id string) map[string]any { return t.rows[id] }
+
+func (t *table) del(id string) map[string]any {
+	row := t.rows[id]
+	if row == nil {
+		return nil
+	}
+	delete(t.rows, id)
+	for i, v := range t.order {
+		if v == id {
+			t.order = append(t.order[:i], t.order[i+1:]...)
+			break
+		}
+	}
+	return row
+}
+
+// list returns the rows keep accepts (all when keep is nil), in order.
+func (t *table) list(keep func(map[string]any) bool) []map[string]any {
+	out := make([]map[string]any, 0, len(t.order))
+	for _, id := range t.order {
+		if row := t.rows[id]; keep == nil || keep(row) {
+			out = append(out, row)
+		}
+	}
+	return out
+}
+
+// idOf renders a JSON id (number or string) as a string; "" when absent.
+func idOf(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case json.Number:
+		return x.String()
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+// str returns a string field, rendering numbers; "" when absent.
+func str(row map[string]any, key string) string {
+	if row == nil {
+		return ""
+	}
+	return idOf(row[key])
+}
+
+// nested returns row[key] as an object, or nil.
+func nested(row map[string]any, key string) map[string]any {
+	m, _ := row[key].(map[string]any)
+	return m
+}
+
+// matches reports whether any of the row's name-like fields contains the
+// search term (case-insensitive), mirroring the portal's filterValue.
+func matches(row map[string]any, term string) bool {
+	if term == "" {
+		return true
+	}
+	term = strings.ToLower(term)
+	for _, k := range []string{"displayName", "title", "subject", "firstName", "lastName", "companyName", "email", "from"} {
+		if strings.Contains(strings.ToLower(str(row, k)), term) {
+			return true
+		}
+	}
+	return false
+}
+
+func newGUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,275 @@
+package onlyoffice
+
+// Critical path scheduling over project tasks and their links. Pure Go: no
+// portal calls. Dates have day granularity; a task's duration is its
+// StartDate..Deadline span, both days included.
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ErrDependencyCycle is returned by ScheduleTasks when task links form a
+// loop.
+var ErrDependencyCycle = errors.New("task links form a cycle")
+
+// ScheduledTask is one task's place in a Schedule. Start and finish dates
+// are inclusive days.
+type ScheduledTask struct {
+	TaskID   int    `json:"taskId"`
+	Title    string `json:"title"`
+	Duration int    `json:"duration"` // days
+
+	EarlyStart  time.Time `json:"earlyStart"`
+	EarlyFinish time.Time `json:"earlyFinish"`
+	LateStart   time.Time `json:"lateStart"`
+	LateFinish  time.Time `json:"lateFinish"`
+
+	// Slack is how many days the task can slip without moving the
+	// project finish.
+	Slack    int  `json:"slack"`
+	Critical bool `json:"critical"`
+	// Delay is how many days EarlyFinish lies past the planned Deadline.
+	Delay int `json:"delay,omitempty"`
+}
+
+// Schedule is the result of ScheduleTasks.
+type Schedule struct {
+	Start  time.Time `json:"start"`
+	Finish time.Time `json:"finish"` // inclusive
+	// Tasks are sorted by early start, then id.
+	Tasks []ScheduledTask `json:"tasks"`
+	// CriticalPath lists the ids of the zero-slack tasks by early start.
+	CriticalPath []int `json:"criticalPath"`
+}
+
+type scheduleNode struct {
+	task     *Task
+	dur      int
+	planned  int  // planned start, days from the schedule origin
+	fixed    bool // planned start is known
+	deadline int
+	hasDue   bool
+	es, lf   int
+	succ     []scheduleEdge
+	pred     []scheduleEdge
+}
+
+type scheduleEdge struct {
+	other int // task id
+	typ   TaskLinkType
+}
+
+// ScheduleTasks computes earliest and latest dates, slack and the critical
+// path of tasks from their StartDate, Deadline and Links. A task's planned
+// start is treated as "start no earlier than"; links to tasks outside the
+// list are ignored. Tasks without dates take one day.
+func ScheduleTasks(tasks []*Task) (*Schedule, error) {
+	nodes := map[int]*scheduleNode{}
+	var origin time.Time
+	for _, t := range tasks {
+		if t == nil || t.ID == nil {
+			continue
+		}
+		for _, d := range []*time.Time{t.StartDate, t.Deadline} {
+			if d != nil && !d.IsZero() && (origin.IsZero() || scheduleDay(*d).Before(origin)) {
+				origin = scheduleDay(*d)
+			}
+		}
+	}
+	if origin.IsZero() {
+		origin = scheduleDay(time.Now())
+	}
+	dayOf := func(t time.Time) int { return int(scheduleDay(t).Sub(origin).Hours()+12) / 24 }
+
+	ids := make([]int, 0, len(tasks))
+	for _, t := range tasks {
+		if t == nil || t.ID == nil || nodes[*t.ID] != nil {
+			continue
+		}
+		n := &scheduleNode{task: t, dur: 1}
+		hasStart := t.StartDate != nil && !t.StartDate.IsZero()
+		n.hasDue = t.Deadline != nil && !t.Deadline.IsZero()
+		if n.hasDue {
+			n.deadline = dayOf(*t.Deadline)
+		}
+		switch {
+		case hasStart:
+			n.planned, n.fixed = dayOf(*t.StartDate), true
+			if n.hasDue && n.deadline >= n.planned {
+				n.dur = n.deadline - n.planned + 1
+			}
+		case n.hasDue:
+			n.planned, n.fixed = n.deadline, true
+		}
+		nodes[*t.ID] = n
+		ids = append(ids, *t.ID)
+	}
+
+	seen := map[[2]int]bool{}
+	for _, id := range ids {
+		for _, l := range nodes[id].task.Links {
+			from, to := int(l.ParentTaskID), int(l.DependentTaskID)
+			if nodes[from] == nil || nodes[to] == nil || from == to || seen[[2]int{from, to}] {
+				continue
+			}
+			seen[[2]int{from, to}] = true
+			nodes[from].succ = append(nodes[from].succ, scheduleEdge{to, l.Type})
+			nodes[to].pred = append(nodes[to].pred, scheduleEdge{from, l.Type})
+		}
+	}
+
+	order, err := scheduleOrder(ids, nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Forward pass: earliest start (es) in days from origin; finish is
+	// exclusive (es + dur). Links only push a task later: a start-end or
+	// end-end link that would start it before its planned start, or before
+	// the origin for an undated task, leaves es there, so no task starts
+	// before Schedule.Start.
+	finish := 0
+	for _, id := range order {
+		n := nodes[id]
+		n.es = 0
+		if n.fixed {
+			n.es = n.planned
+		}
+		for _, e := range n.pred {
+			p := nodes[e.other]
+			var es int
+			switch e.typ {
+			case TaskLinkEndStart:
+				es = p.es + p.dur
+			case TaskLinkStartStart:
+				es = p.es
+			case TaskLinkEndEnd:
+				es = p.es + p.dur - n.dur
+			case TaskLinkStartEnd:
+				es = p.es - n.dur
+			}
+			n.es = max(n.es, es)
+		}
+		finish = max(finish, n.es+n.dur)
+	}
+
+	// Backward pass: latest finish (lf), exclusive.
+	for i := len(order) - 1; i >= 0; i-- {
+		n := nodes[order[i]]
+		n.lf = finish
+		for _, e := range n.succ {
+			s := nodes[e.other]
+			ls := s.lf - s.dur
+			var lf int
+			switch e.typ {
+			case TaskLinkEndStart:
+				lf = ls
+			case TaskLinkStartStart:
+				lf = ls + n.dur
+			case TaskLinkEndEnd:
+				lf = s.lf
+			case TaskLinkStartEnd:
+				lf = s.lf + n.dur
+			}
+			n.lf = min(n.lf, lf)
+		}
+	}
+
+	date := func(d int) time.Time { return origin.AddDate(0, 0, d) }
+	out := &Schedule{Start: origin, Finish: date(finish - 1)}
+	for _, id := range ids {
+		n := nodes[id]
+		st := ScheduledTask{
+			TaskID:      id,
+			Duration:    n.dur,
+			EarlyStart:  date(n.es),
+			EarlyFinish: date(n.es + n.dur - 1),
+			LateStart:   date(n.lf - n.dur),
+			LateFinish:  date(n.lf - 1),
+			Slack:       n.lf - n.dur - n.es,
+		}
+		if n.task.Title != nil {
+			st.Title = *n.task.Title
+		}
+		st.Critical = st.Slack == 0
+		if n.hasDue && n.es+n.dur-1 > n.deadline {
+			st.Delay = n.es + n.dur - 1 - n.deadline
+		}
+		out.Tasks = append(out.Tasks, st)
+	}
+	slices.SortFunc(out.Tasks, func(a, b ScheduledTask) int {
+		if c := a.EarlyStart.Compare(b.EarlyStart); c != 0 {
+			return c
+		}
+		return a.TaskID - b.TaskID
+	})
+	for _, st := range out.Tasks {
+		if st.Critical {
+			out.CriticalPath = append(out.CriticalPath, st.TaskID)
+		}
+	}
+	return out, nil
+}
+
+// scheduleOrder sorts task ids topologically along their links (Kahn),
+// keeping the input order among independent tasks.
+func scheduleOrder(ids []int, nodes map[int]*scheduleNode) ([]int, error) {
+	indeg := make(map[int]int, len(ids))
+	for _, id := range ids {
+		indeg[id] = len(nodes[id].pred)
+	}
+	var queue, order []int
+	for _, id := range ids {
+		if indeg[id] == 0 {
+			queue = append(queue, id)
+		}
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		order = append(order, id)
+		for _, e := range nodes[id].succ {
+			if indeg[e.other]--; indeg[e.other] == 0 {
+				queue = append(queue, e.other)
+			}
+		}
+	}
+	if len(order) < len(ids) {
+		return nil, fmt.Errorf("%w: tasks %v", ErrDependencyCycle, scheduleLoop(ids, nodes, indeg))
+	}
+	return order, nil
+}
+
+// scheduleLoop narrows the tasks Kahn's sort left over (indeg > 0) to those
+// on a loop, dropping the ones merely downstream of it.
+func scheduleLoop(ids []int, nodes map[int]*scheduleNode, indeg map[int]int) []int {
+	left := map[int]bool{}
+	for _, id := range ids {
+		left[id] = indeg[id] > 0
+	}
+	for pruned := true; pruned; {
+		pruned = false
+		for _, id := range ids {
+			if !left[id] || slices.ContainsFunc(nodes[id].succ, func(e scheduleEdge) bool { return left[e.other] }) {
+				continue
+			}
+			left[id], pruned = false, true
+		}
+	}
+	var loop []int
+	for _, id := range ids {
+		if left[id] {
+			loop = append(loop, id)
+		}
+	}
+	return loop
+}
+
+// scheduleDay truncates t to its calendar day in UTC.
+func scheduleDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,142 @@
+package onlyoffice
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func schedTask(id int, start, deadline string, links ...TaskLink) *Task {
+	title := fmt.Sprintf("T%d", id)
+	t := &Task{ID: &id, Title: &title, Links: links}
+	if start != "" {
+		d, _ := time.Parse("2006-01-02", start)
+		t.StartDate = &d
+	}
+	if deadline != "" {
+		d, _ := time.Parse("2006-01-02", deadline)
+		t.Deadline = &d
+	}
+	return t
+}
+
+func link(parent, dependent int, t TaskLinkType) TaskLink {
+	return TaskLink{ParentTaskID: FlexInt(parent), DependentTaskID: FlexInt(dependent), Type: t}
+}
+
+func TestScheduleTasksCriticalPath(t *testing.T) {
+	ab, ac := link(1, 2, TaskLinkEndStart), link(1, 3, TaskLinkEndStart)
+	s, err := ScheduleTasks([]*Task{
+		schedTask(1, "2026-03-02", "2026-03-04", ab, ac),
+		schedTask(2, "2026-03-02", "2026-03-03", ab),
+		schedTask(3, "2026-03-02", "2026-03-02", ac),
+		schedTask(4, "", ""),
+		schedTask(5, "2026-03-09", "2026-03-08", link(5, 99, TaskLinkEndStart)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := func(d time.Time) string { return d.Format("2006-01-02") }
+	if day(s.Start) != "2026-03-02" || day(s.Finish) != "2026-03-09" {
+		t.Errorf("schedule = %s..%s", day(s.Start), day(s.Finish))
+	}
+	want := map[int]struct {
+		start, finish string
+		dur, slack    int
+		delay         int
+	}{
+		1: {"2026-03-02", "2026-03-04", 3, 3, 0},
+		2: {"2026-03-05", "2026-03-06", 2, 3, 3},
+		3: {"2026-03-05", "2026-03-05", 1, 4, 3},
+		4: {"2026-03-02", "2026-03-02", 1, 7, 0},
+		5: {"2026-03-09", "2026-03-09", 1, 0, 1}, // deadline before start: one day, late
+	}
+	for _, st := range s.Tasks {
+		w := want[st.TaskID]
+		if day(st.EarlyStart) != w.start || day(st.EarlyFinish) != w.finish || st.Duration != w.dur || st.Slack != w.slack || st.Delay != w.delay {
+			t.Errorf("task %d = %s..%s dur %d slack %d delay %d; want %+v", st.TaskID,
+				day(st.EarlyStart), day(st.EarlyFinish), st.Duration, st.Slack, st.Delay, w)
+		}
+		if st.Critical != (st.Slack == 0) {
+			t.Errorf("task %d critical = %v with slack %d", st.TaskID, st.Critical, st.Slack)
+		}
+	}
+	if len(s.CriticalPath) != 1 || s.CriticalPath[0] != 5 {
+		t.Errorf("critical path = %v", s.CriticalPath)
+	}
+
+	// Without the late-starting task 5, the 1 → 2 chain sets the finish.
+	s, err = ScheduleTasks([]*Task{
+		schedTask(1, "2026-03-02", "2026-03-04", ab, ac),
+		schedTask(2, "2026-03-02", "2026-03-03", ab),
+		schedTask(3, "2026-03-02", "2026-03-02", ac),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if day(s.Finish) != "2026-03-06" || len(s.CriticalPath) != 2 || s.CriticalPath[0] != 1 || s.CriticalPath[1] != 2 {
+		t.Errorf("finish %s, critical path %v; want 2026-03-06, [1 2]", day(s.Finish), s.CriticalPath)
+	}
+}
+
+func TestScheduleTasksLinkTypes(t *testing.T) {
+	for typ, wantStart := range map[TaskLinkType]string{
+		TaskLinkEndStart:   "2026-03-05",
+		TaskLinkStartStart: "2026-03-02",
+		TaskLinkEndEnd:     "2026-03-03",
+		TaskLinkStartEnd:   "2026-03-02",
+	} {
+		l := link(1, 2, typ)
+		s, err := ScheduleTasks([]*Task{
+			schedTask(1, "2026-03-02", "2026-03-04", l),
+			schedTask(2, "2026-03-02", "2026-03-03", l),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, st := range s.Tasks {
+			if st.TaskID == 2 && st.EarlyStart.Format("2006-01-02") != wantStart {
+				t.Errorf("%s: dependent starts %s; want %s", typ, st.EarlyStart.Format("2006-01-02"), wantStart)
+			}
+		}
+	}
+}
+
+func TestScheduleTasksNothingStartsBeforeStart(t *testing.T) {
+	se, ee := link(1, 2, TaskLinkStartEnd), link(3, 4, TaskLinkEndEnd)
+	s, err := ScheduleTasks([]*Task{
+		schedTask(1, "2026-03-02", "2026-03-02", se),
+		schedTask(2, "", "", se), // would have to finish by 03-02: starts at the origin
+		schedTask(3, "2026-03-02", "2026-03-03", ee),
+		schedTask(4, "2026-03-03", "2026-03-12", ee), // long task, short parent
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, st := range s.Tasks {
+		if st.EarlyStart.Before(s.Start) || st.LateStart.Before(s.Start) || st.Slack < 0 {
+			t.Errorf("task %d: early %s late %s slack %d, schedule start %s", st.TaskID,
+				st.EarlyStart.Format("2006-01-02"), st.LateStart.Format("2006-01-02"), st.Slack, s.Start.Format("2006-01-02"))
+		}
+	}
+	if got := s.Tasks[len(s.Tasks)-1]; got.TaskID != 4 || got.EarlyStart.Format("2006-01-02") != "2026-03-03" {
+		t.Errorf("end-end link moved task 4 before its planned start: %+v", got)
+	}
+}
+
+func TestScheduleTasksCycle(t *testing.T) {
+	_, err := ScheduleTasks([]*Task{
+		schedTask(1, "", "", link(1, 2, TaskLinkEndStart)),
+		schedTask(2, "", "", link(2, 3, TaskLinkEndStart)),
+		schedTask(3, "", "", link(3, 1, TaskLinkEndStart), link(3, 4, TaskLinkEndStart)),
+		schedTask(4, "", ""),
+	})
+	if !errors.Is(err, ErrDependencyCycle) {
+		t.Fatalf("err = %v; want ErrDependencyCycle", err)
+	}
+	if !strings.HasSuffix(err.Error(), "tasks [1 2 3]") {
+		t.Errorf("err = %v; want only the looping tasks", err)
+	}
+}
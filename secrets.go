@@ -0,0 +1,129 @@
+package onlyoffice
+
+// Password sources: where Credentials.PasswordSource says to fetch the
+// password from when it is not given in plain text. Resolution is lazy —
+// it runs only when the client actually performs the password flow, so a
+// cached or pre-issued token never wakes up gpg or the keyring.
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ResolveSecret returns the secret named by source, one of:
+//
+//   - env:NAME          the environment variable NAME
+//   - file:PATH         the first line of a file (a leading ~/ is expanded)
+//   - pass:ENTRY        the first line of `pass show ENTRY` (password-store)
+//   - keyring:SERVICE   the Secret Service item with attributes
+//     service=SERVICE and username=creds.User (`secret-tool lookup`, libsecret)
+//   - command:CMDLINE   the output of CMDLINE run by sh, git credential-helper
+//     style: it gets protocol, host and username as key=value lines on stdin
+//     and may answer with a password= line; otherwise its first output line
+//     is the secret
+//
+// creds supplies the portal URL and user for keyring and command lookups.
+func ResolveSecret(ctx context.Context, source string, creds Credentials) (string, error) {
+	kind, arg, ok := strings.Cut(strings.TrimSpace(source), ":")
+	arg = strings.TrimSpace(arg)
+	if !ok || arg == "" {
+		return "", fmt.Errorf("secret source %q: want env:, file:, pass:, keyring: or command:", source)
+	}
+	var (
+		out string
+		err error
+	)
+	switch kind {
+	case "env":
+		out = os.Getenv(arg)
+		if out == "" {
+			err = fmt.Errorf("$%s is empty", arg)
+		}
+	case "file":
+		var b []byte
+		if b, err = os.ReadFile(expandHome(arg)); err == nil {
+			out = firstLine(string(b))
+		}
+	case "pass":
+		out, err = runSecretCommand(ctx, nil, "pass", "show", arg)
+		out = firstLine(out) // further lines are pass metadata
+	case "keyring":
+		if creds.User == "" {
+			return "", fmt.Errorf("secret source %s: a user is required for the keyring lookup", source)
+		}
+		out, err = runSecretCommand(ctx, nil, "secret-tool", "lookup", "service", arg, "username", creds.User)
+	case "command":
+		out, err = runSecretCommand(ctx, credentialHelperInput(creds), "sh", "-c", arg)
+		out = credentialHelperPassword(out)
+	default:
+		return "", fmt.Errorf("secret source %q: unknown kind %q", source, kind)
+	}
+	if err == nil && out == "" {
+		err = fmt.Errorf("empty secret")
+	}
+	if err != nil {
+		return "", fmt.Errorf("secret source %s: %w", kind+":"+arg, err)
+	}
+	return out, nil
+}
+
+// runSecretCommand runs name with args and returns its trimmed output.
+// stderr is passed through so pinentry and helper prompts stay visible.
+func runSecretCommand(ctx context.Context, stdin []byte, name string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
+
+// credentialHelperInput is the request a git credential helper reads.
+func credentialHelperInput(creds Credentials) []byte {
+	var b strings.Builder
+	if u, err := url.Parse(creds.Url); err == nil && u.Host != "" {
+		fmt.Fprintf(&b, "protocol=%s\nhost=%s\n", u.Scheme, u.Host)
+	}
+	if creds.User != "" {
+		fmt.Fprintf(&b, "username=%s\n", creds.User)
+	}
+	b.WriteString("\n")
+	return []byte(b.String())
+}
+
+// credentialHelperPassword picks the password= line of a credential helper
+// answer, or the first line of plain output.
+func credentialHelperPassword(out string) string {
+	sc := bufio.NewScanner(strings.NewReader(out))
+	for sc.Scan() {
+		if v, ok := strings.CutPrefix(sc.Text(), "password="); ok {
+			return v
+		}
+	}
+	return firstLine(out)
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return strings.TrimSpace(line)
+}
+
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
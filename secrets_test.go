@@ -0,0 +1,58 @@
+package onlyoffice
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveSecret(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	creds := Credentials{Url: "https://office.example.com", User: "me@example.com"}
+
+	file := filepath.Join(dir, "pw")
+	if err := os.WriteFile(file, []byte("from-file\nignored\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// A stand-in for password-store: prints the entry name, then metadata.
+	pass := filepath.Join(dir, "pass")
+	if err := os.WriteFile(pass, []byte("#!/bin/sh\necho \"pw-for-$2\"\necho 'login: me'\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("OO_TEST_SECRET", "from-env")
+
+	for source, want := range map[string]string{
+		"env:OO_TEST_SECRET":   "from-env",
+		"file:" + file:         "from-file",
+		"pass:work/onlyoffice": "pw-for-work/onlyoffice",
+		"command:echo plain":   "plain",
+		// git credential-helper style: echo the request back with a password.
+		"command:cat; echo password=helper": "helper",
+		"command:grep host=":                "host=office.example.com",
+	} {
+		got, err := ResolveSecret(ctx, source, creds)
+		if err != nil || got != want {
+			t.Errorf("%s = %q, %v; want %q", source, got, err, want)
+		}
+	}
+
+	for source, want := range map[string]string{
+		"plaintext":          "want env:",
+		"vault:kv/oo":        "unknown kind",
+		"env:OO_TEST_UNSET":  "is empty",
+		"command:true":       "empty secret",
+		"keyring:onlyoffice": "user is required",
+	} {
+		c := creds
+		if strings.HasPrefix(source, "keyring:") {
+			c.User = ""
+		}
+		if _, err := ResolveSecret(ctx, source, c); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: err = %v, want %q", source, err, want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Package sqlitecache is an on-disk onlyoffice.Cache backed by SQLite, so
+// cached lookups (users, deal stages, task categories, …) survive between
+// `oo` invocations:
+//
+//	store, err := sqlitecache.Open("") // onlyoffice.DefaultCachePath
+//	defer store.Close()
+//	c := onlyoffice.NewClient(creds, onlyoffice.WithCache(store, nil))
+//
+// It lives outside the root package so library users who do not want a
+// disk cache do not link the SQLite driver.
+package sqlitecache
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
+	_ "modernc.org/sqlite"
+)
+
+// Store is an onlyoffice.Cache in one SQLite file. Safe for concurrent use,
+// also by several processes.
+type Store struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+var _ onlyoffice.Cache = (*Store)(nil)
+
+// Open opens (creating if needed) the cache database at path, or at
+// onlyoffice.DefaultCachePath when path is empty. Expired entries are
+// purged on open.
+func Open(path string) (*Store, error) {
+	if path == "" {
+		p, err := onlyoffice.DefaultCachePath()
+		if err != nil {
+			return nil, err
+		}
+		path = p
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, err
+	}
+	s := &Store{db: db, now: time.Now}
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS cache (
+		key     TEXT PRIMARY KEY,
+		value   BLOB NOT NULL,
+		expires INTEGER NOT NULL
+	)`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("cache %s: %w", path, err)
+	}
+	if _, err := db.Exec(`DELETE FROM cache WHERE expires <= ?`, s.now().UnixNano()); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("cache %s: %w", path, err)
+	}
+	_ = os.Chmod(path, 0o600)
+	return s, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error { return s.db.Close() }
+
+// Get implements onlyoffice.Cache.
+func (s *Store) Get(key string) ([]byte, bool, error) {
+	var value []byte
+	err := s.db.QueryRow(`SELECT value FROM cache WHERE key = ? AND expires > ?`, key, s.now().UnixNano()).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set implements onlyoffice.Cache.
+func (s *Store) Set(key string, value []byte, ttl time.Duration) error {
+	_, err := s.db.Exec(`INSERT INTO cache (key, value, expires) VALUES (?, ?, ?)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value, expires = excluded.expires`,
+		key, value, s.now().Add(ttl).UnixNano())
+	return err
+}
+
+// DeletePrefix implements onlyoffice.Cache.
+func (s *Store) DeletePrefix(prefix string) error {
+	_, err := s.db.Exec(`DELETE FROM cache WHERE key LIKE ? ESCAPE '\'`, likePrefix(prefix))
+	return err
+}
+
+// likePrefix escapes prefix for a LIKE 'prefix%' match.
+func likePrefix(prefix string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return r.Replace(prefix) + "%"
+}
@@ -0,0 +1,57 @@
+package sqlitecache
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.db")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	if err := s.Set("portal|users|list", []byte(`[1]`), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("portal|users|list", []byte(`[1,2]`), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	_ = s.Set("portal|users_x|list", []byte(`[3]`), time.Minute)
+	if v, ok, err := s.Get("portal|users|list"); err != nil || !ok || string(v) != "[1,2]" {
+		t.Fatalf("Get = %q, %v, %v", v, ok, err)
+	}
+
+	// LIKE wildcards in the prefix are literal.
+	if err := s.DeletePrefix("portal|users|"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := s.Get("portal|users|list"); ok {
+		t.Error("entry survived DeletePrefix")
+	}
+	if _, ok, _ := s.Get("portal|users_x|list"); !ok {
+		t.Error("DeletePrefix matched _ as a wildcard")
+	}
+
+	// Entries outlive the process, not their TTL.
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	s, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	s.now = func() time.Time { return now }
+	if _, ok, _ := s.Get("portal|users_x|list"); !ok {
+		t.Error("entry lost on reopen")
+	}
+	s.now = func() time.Time { return now.Add(time.Minute) }
+	if _, ok, _ := s.Get("portal|users_x|list"); ok {
+		t.Error("expired entry served")
+	}
+}
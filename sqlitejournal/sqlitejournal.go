@@ -0,0 +1,151 @@
+// Package sqlitejournal is an onlyoffice.Journal backed by SQLite, for
+// journals that grow too large to scan as JSON Lines:
+//
+//	j, err := sqlitejournal.Open("") // journal.db next to onlyoffice.DefaultJournalPath
+//	defer j.Close()
+//	c := onlyoffice.NewClient(creds, onlyoffice.WithJournal(j))
+//
+// Like sqlitecache it lives outside the root package so the SQLite driver
+// is only linked by those who use it.
+package sqlitejournal
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
+	_ "modernc.org/sqlite"
+)
+
+// Journal is an onlyoffice.Journal in one SQLite file. Entries are stored
+// as JSON next to indexed columns for filtering. Safe for concurrent use,
+// also by several processes.
+type Journal struct {
+	db *sql.DB
+}
+
+var _ onlyoffice.Journal = (*Journal)(nil)
+
+// DefaultPath is journal.db in the directory of
+// onlyoffice.DefaultJournalPath.
+func DefaultPath() (string, error) {
+	p, err := onlyoffice.DefaultJournalPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(p), "journal.db"), nil
+}
+
+// Open opens (creating if needed) the journal database at path, or at
+// DefaultPath when path is empty.
+func Open(path string) (*Journal, error) {
+	if path == "" {
+		p, err := DefaultPath()
+		if err != nil {
+			return nil, err
+		}
+		path = p
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS journal (
+		id     TEXT PRIMARY KEY,
+		time   INTEGER NOT NULL,
+		portal TEXT NOT NULL,
+		op     TEXT NOT NULL,
+		path   TEXT NOT NULL,
+		entry  TEXT NOT NULL
+	)`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("journal %s: %w", path, err)
+	}
+	_ = os.Chmod(path, 0o600)
+	return &Journal{db: db}, nil
+}
+
+// Close closes the database.
+func (j *Journal) Close() error { return j.db.Close() }
+
+// Append implements onlyoffice.Journal.
+func (j *Journal) Append(e *onlyoffice.JournalEntry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = j.db.Exec(`INSERT INTO journal (id, time, portal, op, path, entry) VALUES (?, ?, ?, ?, ?, ?)`,
+		e.ID, e.Time.UnixNano(), e.Portal, e.Op, e.Path, string(b))
+	return err
+}
+
+// List implements onlyoffice.Journal.
+func (j *Journal) List(f onlyoffice.JournalFilter) ([]onlyoffice.JournalEntry, error) {
+	var where []string
+	var args []any
+	if !f.Since.IsZero() {
+		where, args = append(where, "time >= ?"), append(args, f.Since.UnixNano())
+	}
+	if f.Op != "" {
+		where, args = append(where, "op = ?"), append(args, f.Op)
+	}
+	if f.Path != "" {
+		where, args = append(where, "instr(path, ?) > 0"), append(args, f.Path)
+	}
+	if f.Portal != "" {
+		where, args = append(where, "portal = ?"), append(args, f.Portal)
+	}
+	q := "SELECT entry FROM journal"
+	if len(where) > 0 {
+		q += " WHERE " + strings.Join(where, " AND ")
+	}
+	q += " ORDER BY id DESC"
+	if f.Limit > 0 {
+		q += fmt.Sprintf(" LIMIT %d", f.Limit)
+	}
+	rows, err := j.db.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []onlyoffice.JournalEntry
+	for rows.Next() {
+		var raw string
+		if err := rows.Scan(&raw); err != nil {
+			return nil, err
+		}
+		var e onlyoffice.JournalEntry
+		if err := json.Unmarshal([]byte(raw), &e); err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	slices.Reverse(out)
+	return out, rows.Err()
+}
+
+// Get implements onlyoffice.Journal.
+func (j *Journal) Get(id string) (*onlyoffice.JournalEntry, error) {
+	var raw string
+	err := j.db.QueryRow(`SELECT entry FROM journal WHERE id = ?`, id).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("journal entry %s: %w", id, onlyoffice.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var e onlyoffice.JournalEntry
+	if err := json.Unmarshal([]byte(raw), &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
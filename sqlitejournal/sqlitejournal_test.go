@@ -0,0 +1,51 @@
+package sqlitejournal
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	onlyoffice "github.com/eslider/go-onlyoffice"
+)
+
+func TestJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.db")
+	j, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	for i, op := range []string{onlyoffice.JournalCreate, onlyoffice.JournalDelete, onlyoffice.JournalUpdate} {
+		at := base.Add(time.Duration(i) * time.Hour)
+		e := &onlyoffice.JournalEntry{ID: at.Format("20060102-150405.000000"), Time: at, Portal: "https://p.example",
+			Op: op, Method: "PUT", Path: "/api/2.0/crm/contact/1.json", Body: map[string]any{"n": i}}
+		if err := j.Append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	j.Close()
+
+	// Entries survive reopening.
+	j, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+	if got, _ := j.List(onlyoffice.JournalFilter{Op: onlyoffice.JournalDelete}); len(got) != 1 || got[0].Body.(map[string]any)["n"] != float64(1) {
+		t.Errorf("Op filter = %+v", got)
+	}
+	if got, _ := j.List(onlyoffice.JournalFilter{Since: base.Add(30 * time.Minute), Path: "crm/contact"}); len(got) != 2 {
+		t.Errorf("Since+Path filter: %d entries", len(got))
+	}
+	got, err := j.List(onlyoffice.JournalFilter{Limit: 2})
+	if err != nil || len(got) != 2 || got[0].Op != onlyoffice.JournalDelete || got[1].Op != onlyoffice.JournalUpdate {
+		t.Errorf("Limit keeps the newest, oldest first: %+v, %v", got, err)
+	}
+	if e, err := j.Get(got[0].ID); err != nil || e.Op != onlyoffice.JournalDelete {
+		t.Errorf("Get = %+v, %v", e, err)
+	}
+	if _, err := j.Get("nope"); !errors.Is(err, onlyoffice.ErrNotFound) {
+		t.Errorf("Get(unknown) = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,162 @@
+package onlyoffice
+
+// Subtasks: the checklist items of a project task. The portal addresses
+// them under their parent, /project/task/{taskid}/{subtaskid}; there is no
+// list endpoint, the parent task carries them in Task.Subtasks.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Subtask is one checklist item of a task. Status is
+// ProjectTaskStatusOpen or ProjectTaskStatusClosed.
+type Subtask struct {
+	ID          FlexInt           `json:"id"`
+	TaskID      FlexInt           `json:"taskid"`
+	Title       string            `json:"title"`
+	Description string            `json:"description,omitempty"`
+	Status      ProjectTaskStatus `json:"status"`
+	Responsible *User             `json:"responsible,omitempty"`
+	Created     *Time             `json:"created,omitempty"`
+	CreatedBy   *User             `json:"createdBy,omitempty"`
+	Updated     *Time             `json:"updated,omitempty"`
+	UpdatedBy   *User             `json:"updatedBy,omitempty"`
+	CanEdit     bool              `json:"canEdit,omitempty"`
+}
+
+// Done reports whether the subtask is closed.
+func (s Subtask) Done() bool { return s.Status == ProjectTaskStatusClosed }
+
+// ResponsibleID returns the assignee's user id, or "" when unassigned.
+func (s Subtask) ResponsibleID() string {
+	if s.Responsible == nil || s.Responsible.ID == nil {
+		return ""
+	}
+	return *s.Responsible.ID
+}
+
+// ResponsibleName returns the assignee's display name, or "".
+func (s Subtask) ResponsibleName() string {
+	if s.Responsible == nil || s.Responsible.DisplayName == nil {
+		return ""
+	}
+	return *s.Responsible.DisplayName
+}
+
+// ListSubtasks returns the subtasks of taskID.
+// GET /api/2.0/project/task/{taskid}
+func (c *Client) ListSubtasks(ctx context.Context, taskID int) ([]Subtask, error) {
+	raw, err := c.getJSON(ctx, fmt.Sprintf("/api/2.0/project/task/%d.json", taskID))
+	if err != nil {
+		return nil, err
+	}
+	var task struct {
+		Subtasks []Subtask `json:"subtasks"`
+	}
+	if err := decodeResponse(raw, &task); err != nil {
+		return nil, err
+	}
+	return task.Subtasks, nil
+}
+
+// CreateSubtask adds a subtask to taskID; responsibleID may be empty.
+// POST /api/2.0/project/task/{taskid}
+func (c *Client) CreateSubtask(ctx context.Context, taskID int, title, responsibleID string) (*Subtask, error) {
+	raw, err := c.createSubtask(ctx, taskID, title, responsibleID)
+	if err != nil {
+		return nil, err
+	}
+	out := new(Subtask)
+	return out, decodeResponse(raw, out)
+}
+
+// createSubtask posts a new subtask and returns the portal's reply
+// undecoded, for CreateSubtask and the deprecated AddSubtask to read in
+// their own shape.
+func (c *Client) createSubtask(ctx context.Context, taskID int, title, responsibleID string) (json.RawMessage, error) {
+	if strings.TrimSpace(title) == "" {
+		return nil, fmt.Errorf("CreateSubtask: title is required")
+	}
+	body := map[string]any{"title": title}
+	if responsibleID != "" {
+		body["responsible"] = responsibleID
+	}
+	return c.postJSON(ctx, fmt.Sprintf("/api/2.0/project/task/%d.json", taskID), body)
+}
+
+// UpdateSubtask changes the title and assignee of a subtask. The portal
+// wants both on every update, so an empty title or responsibleID keeps the
+// current value (read from the parent task first).
+// PUT /api/2.0/project/task/{taskid}/{subtaskid}
+func (c *Client) UpdateSubtask(ctx context.Context, taskID, subtaskID int, title, responsibleID string) (*Subtask, error) {
+	if title == "" || responsibleID == "" {
+		cur, err := c.subtask(ctx, taskID, subtaskID)
+		if err != nil {
+			return nil, err
+		}
+		if title == "" {
+			title = cur.Title
+		}
+		if responsibleID == "" {
+			responsibleID = cur.ResponsibleID()
+		}
+	}
+	body := map[string]any{"title": title}
+	if responsibleID != "" {
+		body["responsible"] = responsibleID
+	}
+	raw, err := c.putJSON(ctx, fmt.Sprintf("/api/2.0/project/task/%d/%d.json", taskID, subtaskID), body)
+	if err != nil {
+		return nil, err
+	}
+	out := new(Subtask)
+	return out, decodeResponse(raw, out)
+}
+
+// ReassignSubtask hands a subtask to another user, keeping its title.
+func (c *Client) ReassignSubtask(ctx context.Context, taskID, subtaskID int, responsibleID string) (*Subtask, error) {
+	if responsibleID == "" {
+		return nil, fmt.Errorf("ReassignSubtask: responsible user id is required")
+	}
+	return c.UpdateSubtask(ctx, taskID, subtaskID, "", responsibleID)
+}
+
+// SetSubtaskStatus closes (ProjectTaskStatusClosed) or reopens
+// (ProjectTaskStatusOpen) a subtask.
+// PUT /api/2.0/project/task/{taskid}/{subtaskid}/status
+func (c *Client) SetSubtaskStatus(ctx context.Context, taskID, subtaskID int, status ProjectTaskStatus) (*Subtask, error) {
+	if status != ProjectTaskStatusOpen && status != ProjectTaskStatusClosed {
+		return nil, fmt.Errorf("SetSubtaskStatus: status must be open or closed, got %d", status)
+	}
+	raw, err := c.putJSON(ctx, fmt.Sprintf("/api/2.0/project/task/%d/%d/status.json", taskID, subtaskID),
+		map[string]any{"status": status})
+	if err != nil {
+		return nil, err
+	}
+	out := new(Subtask)
+	return out, decodeResponse(raw, out)
+}
+
+// DeleteSubtask removes a subtask.
+// DELETE /api/2.0/project/task/{taskid}/{subtaskid}
+func (c *Client) DeleteSubtask(ctx context.Context, taskID, subtaskID int) error {
+	_, err := c.deleteJSON(ctx, fmt.Sprintf("/api/2.0/project/task/%d/%d.json", taskID, subtaskID), nil)
+	return err
+}
+
+// subtask finds one subtask of taskID.
+func (c *Client) subtask(ctx context.Context, taskID, subtaskID int) (*Subtask, error) {
+	subs, err := c.ListSubtasks(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range subs {
+		if int(subs[i].ID) == subtaskID {
+			return &subs[i], nil
+		}
+	}
+	return nil, fmt.Errorf("task %d has no subtask %d: %w", taskID, subtaskID, ErrNotFound)
+}
@@ -0,0 +1,65 @@
+//go:build integration
+
+package onlyoffice
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// TestIntegrationSubtasks walks a throwaway task's checklist: create,
+// close, rename and reassign (each keeping the other field), delete.
+func TestIntegrationSubtasks(t *testing.T) {
+	c := liveClient(t)
+	ctx := context.Background()
+	taskID := createTestTask(t, c, createTestProject(t, c, "subtasks"), "integration checklist task")
+	self, err := c.SelfUserID(ctx)
+	if err != nil {
+		t.Fatalf("SelfUserID: %v", err)
+	}
+
+	tag, err := c.CreateSubtask(ctx, taskID, "Tag", self)
+	if err != nil {
+		t.Fatalf("CreateSubtask: %v", err)
+	}
+	if tag.ResponsibleID() != self || tag.Done() || int(tag.TaskID) != taskID {
+		t.Fatalf("subtask = %+v", tag)
+	}
+	legacy, err := c.AddSubtask(ctx, strconv.Itoa(taskID), "Announce")
+	if err != nil {
+		t.Fatalf("AddSubtask: %v", err)
+	}
+	announce := int(flexInt(legacy["id"]))
+	if announce == 0 || legacy["title"] != "Announce" {
+		t.Fatalf("AddSubtask = %v", legacy)
+	}
+
+	if s, err := c.SetSubtaskStatus(ctx, taskID, int(tag.ID), ProjectTaskStatusClosed); err != nil || !s.Done() {
+		t.Fatalf("SetSubtaskStatus = %+v, %v", s, err)
+	}
+	if s, err := c.UpdateSubtask(ctx, taskID, int(tag.ID), "Tag v1", ""); err != nil || s.Title != "Tag v1" || s.ResponsibleID() != self {
+		t.Fatalf("UpdateSubtask(title) = %+v, %v", s, err)
+	}
+	if s, err := c.ReassignSubtask(ctx, taskID, announce, self); err != nil || s.Title != "Announce" || s.ResponsibleID() != self {
+		t.Fatalf("ReassignSubtask = %+v, %v", s, err)
+	}
+
+	subs, err := c.ListSubtasks(ctx, taskID)
+	if err != nil || len(subs) != 2 {
+		t.Fatalf("ListSubtasks = %+v, %v", subs, err)
+	}
+	if err := c.DeleteSubtask(ctx, taskID, announce); err != nil {
+		t.Fatalf("DeleteSubtask: %v", err)
+	}
+	if subs, _ := c.ListSubtasks(ctx, taskID); len(subs) != 1 || subs[0].Title != "Tag v1" {
+		t.Errorf("after delete: %+v", subs)
+	}
+	if _, err := c.UpdateSubtask(ctx, taskID, announce, "", self); !errors.Is(err, ErrNotFound) {
+		t.Errorf("deleted subtask: err = %v, want ErrNotFound", err)
+	}
+	if _, err := c.SetSubtaskStatus(ctx, taskID, int(tag.ID), ProjectTaskStatusDisable); err == nil {
+		t.Error("SetSubtaskStatus must only accept open or closed")
+	}
+}
@@ -0,0 +1,86 @@
+package onlyoffice
+
+// Task links: the dependencies between project tasks drawn in the portal's
+// Gantt chart. A link runs from a parent (predecessor) task to a dependent
+// (successor) task.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// TaskLinkType is how a dependent task is tied to its parent.
+type TaskLinkType int
+
+// Portal codes of TaskLinkType.
+const (
+	TaskLinkStartEnd   TaskLinkType = 0 // dependent ends after the parent starts
+	TaskLinkEndStart   TaskLinkType = 1 // dependent starts after the parent ends
+	TaskLinkStartStart TaskLinkType = 2 // dependent starts after the parent starts
+	TaskLinkEndEnd     TaskLinkType = 3 // dependent ends after the parent ends
+)
+
+// String returns "end-start", "start-start", "end-end" or "start-end".
+func (t TaskLinkType) String() string {
+	switch t {
+	case TaskLinkStartEnd:
+		return "start-end"
+	case TaskLinkEndStart:
+		return "end-start"
+	case TaskLinkStartStart:
+		return "start-start"
+	case TaskLinkEndEnd:
+		return "end-end"
+	}
+	return fmt.Sprintf("TaskLinkType(%d)", int(t))
+}
+
+// ParseTaskLinkType accepts the String forms, the short forms "es", "ss",
+// "ee", "se" and "fs"/"ff"/"sf" (finish = end).
+func ParseTaskLinkType(s string) (TaskLinkType, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "end-start", "es", "fs", "finish-start":
+		return TaskLinkEndStart, nil
+	case "start-start", "ss":
+		return TaskLinkStartStart, nil
+	case "end-end", "ee", "ff", "finish-finish":
+		return TaskLinkEndEnd, nil
+	case "start-end", "se", "sf", "start-finish":
+		return TaskLinkStartEnd, nil
+	}
+	return 0, fmt.Errorf("unknown task link type %q (want end-start, start-start, end-end or start-end)", s)
+}
+
+// TaskLink is one dependency as listed in Task.Links.
+type TaskLink struct {
+	ParentTaskID    FlexInt      `json:"parentTaskId"`
+	DependentTaskID FlexInt      `json:"dependenceTaskId"`
+	Type            TaskLinkType `json:"linkType"`
+}
+
+// LinkTasks makes dependentID depend on parentID and returns the dependent
+// task with its updated links.
+// POST /api/2.0/project/task/{parenttaskid}/link
+func (c *Client) LinkTasks(ctx context.Context, parentID, dependentID int, t TaskLinkType) (*Task, error) {
+	if parentID == dependentID {
+		return nil, fmt.Errorf("LinkTasks: task %d cannot depend on itself", parentID)
+	}
+	raw, err := c.postJSON(ctx, fmt.Sprintf("/api/2.0/project/task/%d/link.json", parentID),
+		map[string]any{"dependenceTaskId": dependentID, "linkType": t})
+	if err != nil {
+		return nil, err
+	}
+	out := new(Task)
+	return out, decodeResponse(raw, out)
+}
+
+// UnlinkTasks removes the dependency of dependentID on parentID. As with
+// LinkTasks, the parent (predecessor) goes in the path and the dependent in
+// the body.
+// DELETE /api/2.0/project/task/{parenttaskid}/link
+func (c *Client) UnlinkTasks(ctx context.Context, parentID, dependentID int) error {
+	_, err := c.deleteJSON(ctx, fmt.Sprintf("/api/2.0/project/task/%d/link.json", parentID),
+		map[string]any{"dependenceTaskId": dependentID, "parentTaskId": parentID})
+	return err
+}
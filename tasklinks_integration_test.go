@@ -0,0 +1,76 @@
+//go:build integration
+
+package onlyoffice
+
+import (
+	"context"
+	"strconv"
+	"testing"
+)
+
+// TestIntegrationTaskLinks links two throwaway tasks, reads the link back
+// through the task list the scheduler uses, and unlinks them.
+func TestIntegrationTaskLinks(t *testing.T) {
+	c := liveClient(t)
+	ctx := context.Background()
+	projectID := createTestProject(t, c, "links")
+	design := createTestTask(t, c, projectID, "integration design")
+	build := createTestTask(t, c, projectID, "integration build")
+
+	dep, err := c.LinkTasks(ctx, design, build, TaskLinkEndStart)
+	if err != nil {
+		t.Fatalf("LinkTasks: %v", err)
+	}
+	if dep.ID == nil || *dep.ID != build || len(dep.Links) != 1 || int(dep.Links[0].ParentTaskID) != design {
+		t.Fatalf("linked task = %+v", dep)
+	}
+	if _, err := c.LinkTasks(ctx, build, build, TaskLinkEndStart); err == nil {
+		t.Error("self link must fail")
+	}
+
+	req := NewProjectGetTasksRequest(projectID)
+	req.Simple = false
+	tasks, err := c.GetTasksContext(ctx, req)
+	if err != nil {
+		t.Fatalf("GetTasks: %v", err)
+	}
+	var links []TaskLink
+	for _, task := range tasks {
+		if *task.ID == build {
+			links = task.Links
+		}
+	}
+	if len(links) != 1 || links[0].Type != TaskLinkEndStart || int(links[0].DependentTaskID) != build {
+		t.Errorf("listed links of %d = %+v", build, links)
+	}
+	if _, err := ScheduleTasks(tasks); err != nil {
+		t.Errorf("ScheduleTasks: %v", err)
+	}
+
+	if err := c.UnlinkTasks(ctx, design, build); err != nil {
+		t.Fatalf("UnlinkTasks: %v", err)
+	}
+	// The link is listed on both ends; read each task back on its own to
+	// see it is gone from the portal, not just from a cached list.
+	for _, id := range []int{design, build} {
+		task, err := c.GetTaskByID(ctx, strconv.Itoa(id))
+		if err != nil {
+			t.Fatalf("GetTaskByID %d: %v", id, err)
+		}
+		if links, _ := task["links"].([]any); len(links) != 0 {
+			t.Errorf("task %d keeps links %v after unlink", id, links)
+		}
+	}
+	tasks, err = c.GetTasksContext(ctx, req)
+	if err != nil {
+		t.Fatalf("GetTasks: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("GetTasks after unlink: %d tasks, want 2", len(tasks))
+	}
+	for _, task := range tasks {
+		if len(task.Links) != 0 {
+			t.Errorf("task %d keeps links %+v after unlink", *task.ID, task.Links)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package onlyoffice
+
+import "testing"
+
+func TestParseTaskLinkType(t *testing.T) {
+	for in, want := range map[string]TaskLinkType{
+		"end-start": TaskLinkEndStart, "FS": TaskLinkEndStart, "ss": TaskLinkStartStart,
+		"ff": TaskLinkEndEnd, "start-end": TaskLinkStartEnd,
+	} {
+		if got, err := ParseTaskLinkType(in); err != nil || got != want {
+			t.Errorf("ParseTaskLinkType(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParseTaskLinkType("later"); err == nil {
+		t.Error("unknown link type must fail")
+	}
+}
@@ -1,7 +1,7 @@
 package onlyoffice
 
 // Project task API — both typed (Task + CreateProjectTask / UpdateProjectTask
-// / GetTasks) and untyped form-endpoint helpers (AddTask, AddSubtask,
+// / GetTasks) and untyped form-endpoint helpers (AddTask,
 // UpdateTaskStatus, DeleteTask, ListTasks, …). The typed path mirrors the
 // JSON responses; the form-encoded path mirrors the Python cv/bin/office
 // reference and the OnlyOffice web UI.
@@ -26,7 +26,7 @@ type Task struct {
 	Priority     *int          `json:"priority,omitempty"`
 	ProjectOwner *ProjectOwner `json:"projectOwner,omitempty"`
 
-	Subtasks []any `json:"subtasks,omitempty"`
+	Subtasks []Subtask `json:"subtasks,omitempty"`
 
 	Status *ProjectTaskStatus `json:"status,omitempty"`
 
@@ -49,6 +49,10 @@ type Task struct {
 
 	MilestoneID *int64     `json:"milestoneId,omitempty"`
 	Milestone   *Milestone `json:"milestone,omitempty"`
+
+	// Links are the Gantt dependencies this task takes part in, as parent
+	// or as dependent.
+	Links []TaskLink `json:"links,omitempty"`
 }
 
 // TaskPriority values: High = 1, Normal = 0, Low = -1.
@@ -145,8 +149,13 @@ func NewProjectGetTasksRequest(projectId int) ProjectGetTasksRequest {
 
 // CreateProjectTask creates a project task via the typed JSON API.
 func (c *Client) CreateProjectTask(req NewProjectTaskRequest) (*Task, error) {
+	return c.CreateProjectTaskContext(context.Background(), req)
+}
+
+// CreateProjectTaskContext is the context-aware variant of CreateProjectTask.
+func (c *Client) CreateProjectTaskContext(ctx context.Context, req NewProjectTaskRequest) (*Task, error) {
 	task := &Task{}
-	return task, c.Query(Request{
+	return task, c.QueryContext(ctx, Request{
 		Uri:    fmt.Sprintf("/api/2.0/project/%d/task.json", req.ProjectId),
 		Method: "POST",
 		Body:   req,
@@ -157,8 +166,13 @@ func (c *Client) CreateProjectTask(req NewProjectTaskRequest) (*Task, error) {
 
 // UpdateProjectTask updates task fields via the typed JSON API.
 func (c *Client) UpdateProjectTask(req ProjectTaskUpdateRequest) (*Task, error) {
+	return c.UpdateProjectTaskContext(context.Background(), req)
+}
+
+// UpdateProjectTaskContext is the context-aware variant of UpdateProjectTask.
+func (c *Client) UpdateProjectTaskContext(ctx context.Context, req ProjectTaskUpdateRequest) (*Task, error) {
 	task := &Task{}
-	return task, c.Query(
+	return task, c.QueryContext(ctx,
 		Request{
 			Uri:    fmt.Sprintf("/api/2.0/project/task/%d.json", req.ID),
 			Method: "PUT",
@@ -169,8 +183,13 @@ func (c *Client) UpdateProjectTask(req ProjectTaskUpdateRequest) (*Task, error)
 }
 
 // GetTasks returns a list of tasks for a project matching the given filter.
-func (c *Client) GetTasks(req ProjectGetTasksRequest) (tasks []*Task, err error) {
-	return tasks, c.Query(
+func (c *Client) GetTasks(req ProjectGetTasksRequest) ([]*Task, error) {
+	return c.GetTasksContext(context.Background(), req)
+}
+
+// GetTasksContext is the context-aware variant of GetTasks.
+func (c *Client) GetTasksContext(ctx context.Context, req ProjectGetTasksRequest) (tasks []*Task, err error) {
+	return tasks, c.QueryContext(ctx,
 		Request{
 			Uri:    "/api/2.0/project/task/filter.json",
 			Params: req,
@@ -256,11 +275,22 @@ func (c *Client) AddTask(ctx context.Context, projectID, title, description stri
 	return c.postFormObject(ctx, fmt.Sprintf("/api/2.0/project/%s/task.json", url.PathEscape(projectID)), fields)
 }
 
-// AddSubtask creates a subtask under parentTaskID.
+// AddSubtask creates an unassigned subtask under parentTaskID and returns
+// the portal's subtask object as is.
+//
+// Deprecated: use CreateSubtask, which takes a responsible user and returns
+// a typed Subtask.
 func (c *Client) AddSubtask(ctx context.Context, parentTaskID, title string) (map[string]any, error) {
-	fields := url.Values{}
-	fields.Set("title", title)
-	return c.postFormObject(ctx, fmt.Sprintf("/api/2.0/project/task/%s.json", url.PathEscape(parentTaskID)), fields)
+	id, err := strconv.Atoi(strings.TrimSpace(parentTaskID))
+	if err != nil {
+		return nil, fmt.Errorf("AddSubtask: task id %q: %w", parentTaskID, err)
+	}
+	raw, err := c.createSubtask(ctx, id, title, "")
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]any
+	return out, decodeResponse(raw, &out)
 }
 
 // UpdateTaskStatus changes task status. status accepts "open"/"closed"
@@ -0,0 +1,205 @@
+package onlyoffice
+
+// OpenTelemetry instrumentation. Off unless WithTracerProvider or
+// WithMeterProvider is given: without them (*Client).do takes the plain
+// path and no span, attribute or timer is created. With them, every API
+// request becomes one client span (covering all retries, ended when the
+// response body is closed) and one sample of the request-duration
+// histogram; long-running helpers add a parent span via StartSpan.
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// instrumentationName identifies this library to tracer and meter providers.
+const instrumentationName = "github.com/eslider/go-onlyoffice"
+
+// Span attribute keys beyond the OpenTelemetry HTTP semantic conventions.
+const (
+	AttrRetryCount = attribute.Key("onlyoffice.retry.count")
+	AttrOperation  = attribute.Key("onlyoffice.operation")
+)
+
+// telemetry holds the client's tracer and instruments.
+type telemetry struct {
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+}
+
+// WithTracerProvider records one span per API request (method, templated
+// route, status, retry count, bytes sent/received) using tp.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *Client) {
+		if tp == nil {
+			return
+		}
+		c.ensureTelemetry().tracer = tp.Tracer(instrumentationName)
+	}
+}
+
+// WithMeterProvider records request latency in the
+// "onlyoffice.client.request.duration" histogram (seconds, by method,
+// route and status) using mp.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *Client) {
+		if mp == nil {
+			return
+		}
+		h, err := mp.Meter(instrumentationName).Float64Histogram(
+			"onlyoffice.client.request.duration",
+			metric.WithUnit("s"),
+			metric.WithDescription("Duration of OnlyOffice API requests, including retries and body transfer."),
+		)
+		if err != nil {
+			return
+		}
+		c.ensureTelemetry().duration = h
+	}
+}
+
+func (c *Client) ensureTelemetry() *telemetry {
+	if c.tel == nil {
+		c.tel = &telemetry{
+			tracer:   tracenoop.NewTracerProvider().Tracer(instrumentationName),
+			duration: noopHistogram(),
+		}
+	}
+	return c.tel
+}
+
+func noopHistogram() metric.Float64Histogram {
+	h, _ := noop.NewMeterProvider().Meter(instrumentationName).Float64Histogram("noop")
+	return h
+}
+
+// StartSpan opens a span for a higher-level operation (CleanupCRM,
+// ApplyApproved, …) so the request spans it issues nest under it. The
+// returned end func records err (if any) and ends the span. Without a
+// tracer provider it returns ctx unchanged and a no-op end.
+func (c *Client) StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, func(err error)) {
+	if c == nil || c.tel == nil {
+		return ctx, func(error) {}
+	}
+	attrs = append(attrs, AttrOperation.String(name))
+	ctx, span := c.tel.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// startSpanOf is StartSpan for helpers that take a narrow client interface;
+// clients without telemetry get a no-op.
+func startSpanOf(ctx context.Context, client any, name string) (context.Context, func(error)) {
+	if c, ok := client.(*Client); ok {
+		return c.StartSpan(ctx, name)
+	}
+	return ctx, func(error) {}
+}
+
+// doTraced wraps one logical request (all attempts) in a span and a
+// histogram sample. Both end when the response body is closed, so large
+// downloads are measured in full.
+func (c *Client) doTraced(req *http.Request) (*http.Response, error) {
+	route := RouteTemplate(req.URL.Path)
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", req.Method),
+		attribute.String("url.template", route),
+		attribute.String("server.address", req.URL.Hostname()),
+	}
+	ctx, span := c.tel.tracer.Start(req.Context(), req.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	req = req.WithContext(ctx)
+	start := time.Now()
+
+	resp, sent, err := c.doAttempts(req)
+
+	span.SetAttributes(AttrRetryCount.Int(max(sent-1, 0)))
+	if req.ContentLength > 0 {
+		span.SetAttributes(attribute.Int64("http.request.body.size", req.ContentLength))
+	}
+	finish := func(status int, received int64, err error) {
+		mattrs := attrs
+		if status > 0 {
+			mattrs = append(mattrs, attribute.Int("http.response.status_code", status))
+			span.SetAttributes(attribute.Int("http.response.status_code", status))
+		}
+		span.SetAttributes(attribute.Int64("http.response.body.size", received))
+		switch {
+		case err != nil:
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		case status >= 400:
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		span.End()
+		c.tel.duration.Record(context.WithoutCancel(ctx), time.Since(start).Seconds(), metric.WithAttributes(mattrs...))
+	}
+	if err != nil {
+		finish(0, 0, err)
+		return resp, err
+	}
+	resp.Body = &tracedBody{ReadCloser: resp.Body, finish: func(n int64, rerr error) { finish(resp.StatusCode, n, rerr) }}
+	return resp, nil
+}
+
+// tracedBody counts the bytes read and finishes the request span on Close
+// (or on the first read error other than EOF).
+type tracedBody struct {
+	io.ReadCloser
+	n      int64
+	once   sync.Once
+	finish func(n int64, err error)
+}
+
+func (b *tracedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	if err != nil && err != io.EOF {
+		b.once.Do(func() { b.finish(b.n, err) })
+	}
+	return n, err
+}
+
+func (b *tracedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.finish(b.n, nil) })
+	return err
+}
+
+var (
+	routeGUID    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	routeNumeric = regexp.MustCompile(`^-?[0-9]+$`)
+)
+
+// RouteTemplate turns a request path into a low-cardinality route for span
+// names and metric attributes: numeric and GUID segments become {id} and the
+// ".json" suffix is dropped, so /api/2.0/crm/contact/42.json becomes
+// /api/2.0/crm/contact/{id}.
+func RouteTemplate(path string) string {
+	segs := strings.Split(path, "/")
+	for i, s := range segs {
+		s = strings.TrimSuffix(s, ".json")
+		if routeNumeric.MatchString(s) || routeGUID.MatchString(s) {
+			s = "{id}"
+		}
+		segs[i] = s
+	}
+	return strings.Join(segs, "/")
+}
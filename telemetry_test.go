@@ -0,0 +1,151 @@
+package onlyoffice
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRouteTemplate(t *testing.T) {
+	cases := map[string]string{
+		"/api/2.0/crm/contact/42.json":                              "/api/2.0/crm/contact/{id}",
+		"/api/2.0/crm/contact/42/data/7.json":                       "/api/2.0/crm/contact/{id}/data/{id}",
+		"/api/2.0/people/0b3c5a1e-7f2d-4c3b-9a8e-1d2c3b4a5f60.json": "/api/2.0/people/{id}",
+		"/api/2.0/people/@self.json":                                "/api/2.0/people/@self",
+		"/api/2.0/crm/contact/filter.json":                          "/api/2.0/crm/contact/filter",
+		"/api/2.0/files/folder/-1":                                  "/api/2.0/files/folder/{id}",
+		"/Products/Files/HttpHandlers/filehandler.ashx":             "/Products/Files/HttpHandlers/filehandler.ashx",
+	}
+	for in, want := range cases {
+		if got := RouteTemplate(in); got != want {
+			t.Errorf("RouteTemplate(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func tracedClient(t *testing.T, rt roundTripFunc, p RetryPolicy) (*Client, *tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	t.Helper()
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	c := NewClient(Credentials{Url: "http://portal.invalid", Token: "tok"}, WithTracerProvider(tp), WithMeterProvider(mp), WithRetryPolicy(p))
+	c.client = &http.Client{Transport: rt}
+	return c, exp, reader
+}
+
+func spanAttr(s tracetest.SpanStub, key string) attribute.Value {
+	for _, kv := range s.Attributes {
+		if string(kv.Key) == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTracedRequestRecordsOneSpanAcrossRetries(t *testing.T) {
+	const body = "twelve bytes"
+	calls := 0
+	c, exp, reader := tracedClient(t, func(r *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return stubResponse(http.StatusServiceUnavailable, nil), nil
+		}
+		resp := stubResponse(http.StatusOK, nil)
+		resp.Body = io.NopCloser(strings.NewReader(body))
+		return resp, nil
+	}, RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond})
+
+	// The span ends when the body is closed; what the body holds is not
+	// the tracer's business.
+	req, _ := http.NewRequest(http.MethodGet, "http://portal.invalid/api/2.0/crm/contact/42.json", nil)
+	resp, err := c.do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	spans := exp.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("spans=%d, want 1", len(spans))
+	}
+	s := spans[0]
+	if s.Name != "GET /api/2.0/crm/contact/{id}" {
+		t.Fatalf("name=%q", s.Name)
+	}
+	if got := spanAttr(s, "onlyoffice.retry.count").AsInt64(); got != 1 {
+		t.Fatalf("retry.count=%d", got)
+	}
+	if got := spanAttr(s, "http.response.status_code").AsInt64(); got != 200 {
+		t.Fatalf("status=%d", got)
+	}
+	if got := spanAttr(s, "http.response.body.size").AsInt64(); got != int64(len(body)) {
+		t.Fatalf("body.size=%d", got)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "onlyoffice.client.request.duration" {
+				continue
+			}
+			h := m.Data.(metricdata.Histogram[float64])
+			if len(h.DataPoints) != 1 || h.DataPoints[0].Count != 1 {
+				t.Fatalf("datapoints=%+v", h.DataPoints)
+			}
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("duration histogram not recorded")
+	}
+}
+
+func TestTracedRequestMarksErrors(t *testing.T) {
+	c, exp, _ := tracedClient(t, func(r *http.Request) (*http.Response, error) {
+		return stubResponse(http.StatusNotFound, nil), nil
+	}, RetryPolicy{})
+
+	ctx, end := c.StartSpan(context.Background(), "Lookup")
+	_, err := c.GetContact(ctx, "1")
+	end(err)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err=%v", err)
+	}
+	spans := exp.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("spans=%d", len(spans))
+	}
+	req, op := spans[0], spans[1]
+	if req.Status.Code != codes.Error || op.Status.Code != codes.Error {
+		t.Fatalf("status req=%v op=%v", req.Status, op.Status)
+	}
+	if req.Parent.SpanID() != op.SpanContext.SpanID() {
+		t.Fatal("request span not nested under operation span")
+	}
+}
+
+func TestStartSpanWithoutTelemetryIsNoop(t *testing.T) {
+	c := NewClient(Credentials{})
+	ctx := context.Background()
+	got, end := c.StartSpan(ctx, "x")
+	end(nil)
+	if got != ctx || c.tel != nil {
+		t.Fatal("StartSpan without providers changed state")
+	}
+}
@@ -0,0 +1,349 @@
+package onlyoffice
+
+// Project time tracking: time entries logged against tasks
+// (/api/2.0/project/time), their billing status, and TimeReport for
+// summing hours per person, project, task or week.
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PaymentStatus is the billing state of a time entry.
+type PaymentStatus int
+
+const (
+	PaymentNotChargeable PaymentStatus = 0 // not billable
+	PaymentNotBilled     PaymentStatus = 1 // billable, not yet billed
+	PaymentBilled        PaymentStatus = 2 // billed (paid)
+)
+
+// String returns "not-chargeable", "not-billed" or "billed".
+func (s PaymentStatus) String() string {
+	switch s {
+	case PaymentNotChargeable:
+		return "not-chargeable"
+	case PaymentNotBilled:
+		return "not-billed"
+	case PaymentBilled:
+		return "billed"
+	}
+	return strconv.Itoa(int(s))
+}
+
+// ParsePaymentStatus accepts the String forms, the aliases "unbillable",
+// "billable" and "paid", and the numeric codes.
+func ParsePaymentStatus(s string) (PaymentStatus, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "not-chargeable", "notchargeable", "unbillable", "non-billable", "0":
+		return PaymentNotChargeable, nil
+	case "not-billed", "notbilled", "billable", "1":
+		return PaymentNotBilled, nil
+	case "billed", "paid", "2":
+		return PaymentBilled, nil
+	}
+	return 0, fmt.Errorf("unknown payment status %q (want not-chargeable, billable or paid)", s)
+}
+
+// TimeEntry is one block of time logged on a project task.
+type TimeEntry struct {
+	ID                   FlexInt       `json:"id"`
+	Date                 *Time         `json:"date,omitempty"`
+	Hours                FlexFloat     `json:"hours"`
+	Note                 string        `json:"note,omitempty"`
+	ProjectID            FlexInt       `json:"relatedProject"`
+	TaskID               FlexInt       `json:"relatedTask"`
+	TaskTitle            string        `json:"relatedTaskTitle,omitempty"`
+	Person               *User         `json:"person,omitempty"`
+	CreatedBy            *User         `json:"createdBy,omitempty"`
+	PaymentStatus        PaymentStatus `json:"paymentStatus"`
+	StatusChanged        *Time         `json:"statusChanged,omitempty"`
+	CanEdit              bool          `json:"canEdit"`
+	CanEditPaymentStatus bool          `json:"canEditPaymentStatus"`
+}
+
+// PersonName returns the display name of the person the time is logged
+// for, or their id.
+func (e TimeEntry) PersonName() string {
+	if e.Person == nil {
+		return ""
+	}
+	if e.Person.DisplayName != nil && *e.Person.DisplayName != "" {
+		return *e.Person.DisplayName
+	}
+	if e.Person.ID != nil {
+		return *e.Person.ID
+	}
+	return ""
+}
+
+// NewTimeEntry is the payload for LogTime and UpdateTime.
+type NewTimeEntry struct {
+	Date  time.Time // day the work was done; zero = today
+	Hours float64
+	Note  string
+	// PersonID is who did the work; "" = the authenticated user.
+	PersonID string
+	// ProjectID is the task's project; 0 looks it up from the task
+	// (LogTime only).
+	ProjectID int
+}
+
+func (c *Client) timeEntryBody(ctx context.Context, e NewTimeEntry) (map[string]any, error) {
+	if e.Hours <= 0 {
+		return nil, errors.New("time entry: hours must be positive")
+	}
+	if e.Date.IsZero() {
+		e.Date = time.Now()
+	}
+	if e.PersonID == "" {
+		uid, err := c.SelfUserID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("time entry: resolve self: %w", err)
+		}
+		e.PersonID = uid
+	}
+	y, m, d := e.Date.Date()
+	return map[string]any{
+		"date":     Time(time.Date(y, m, d, 0, 0, 0, 0, time.UTC)),
+		"hours":    e.Hours,
+		"note":     e.Note,
+		"personId": e.PersonID,
+	}, nil
+}
+
+// LogTime records time spent on a task.
+// POST /api/2.0/project/task/{taskid}/time
+func (c *Client) LogTime(ctx context.Context, taskID int, e NewTimeEntry) (*TimeEntry, error) {
+	body, err := c.timeEntryBody(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+	if e.ProjectID == 0 {
+		task, err := c.GetTaskByID(ctx, strconv.Itoa(taskID))
+		if err != nil {
+			return nil, fmt.Errorf("LogTime: task %d: %w", taskID, err)
+		}
+		owner, _ := task["projectOwner"].(map[string]any)
+		e.ProjectID = int(flexInt(owner["id"]))
+	}
+	body["projectId"] = e.ProjectID
+	raw, err := c.postJSON(ctx, fmt.Sprintf("/api/2.0/project/task/%d/time.json", taskID), body)
+	if err != nil {
+		return nil, err
+	}
+	out := new(TimeEntry)
+	return out, decodeResponse(raw, out)
+}
+
+// UpdateTime replaces date, hours, note and person of a time entry.
+// PUT /api/2.0/project/time/{timeid}
+func (c *Client) UpdateTime(ctx context.Context, id int, e NewTimeEntry) (*TimeEntry, error) {
+	body, err := c.timeEntryBody(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := c.putJSON(ctx, fmt.Sprintf("/api/2.0/project/time/%d.json", id), body)
+	if err != nil {
+		return nil, err
+	}
+	out := new(TimeEntry)
+	return out, decodeResponse(raw, out)
+}
+
+// SetTimeStatus changes the billing status of time entries.
+// PUT /api/2.0/project/time/times/status
+func (c *Client) SetTimeStatus(ctx context.Context, status PaymentStatus, ids ...int) ([]TimeEntry, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	raw, err := c.putJSON(ctx, "/api/2.0/project/time/times/status.json", map[string]any{"timeids": ids, "status": status})
+	if err != nil {
+		return nil, err
+	}
+	var out []TimeEntry
+	return out, decodeResponse(raw, &out)
+}
+
+// DeleteTime removes time entries.
+// DELETE /api/2.0/project/time/times/remove
+func (c *Client) DeleteTime(ctx context.Context, ids ...int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := c.deleteJSON(ctx, "/api/2.0/project/time/times/remove.json", map[string]any{"timeids": ids})
+	return err
+}
+
+// ListTaskTime returns the time logged on one task.
+// GET /api/2.0/project/task/{taskid}/time
+func (c *Client) ListTaskTime(ctx context.Context, taskID int) ([]TimeEntry, error) {
+	return getTyped[[]TimeEntry](ctx, c, fmt.Sprintf("/api/2.0/project/task/%d/time.json", taskID))
+}
+
+// TimeFilter narrows ListTime / IterTime. The zero value lists every
+// entry the caller can see.
+type TimeFilter struct {
+	ProjectID int
+	PersonID  string    // participant user id
+	From, To  time.Time // entry dates, inclusive; zero = open
+	Status    *PaymentStatus
+}
+
+func (f TimeFilter) query() url.Values {
+	q := url.Values{}
+	if f.ProjectID != 0 {
+		q.Set("projectid", strconv.Itoa(f.ProjectID))
+	}
+	if f.PersonID != "" {
+		q.Set("participant", f.PersonID)
+	}
+	if !f.From.IsZero() {
+		q.Set("createdStart", Time(f.From).String())
+	}
+	if !f.To.IsZero() {
+		q.Set("createdStop", Time(f.To).String())
+	}
+	if f.Status != nil {
+		q.Set("status", strconv.Itoa(int(*f.Status)))
+	}
+	return q
+}
+
+// IterTime streams time entries matching f page by page.
+// GET /api/2.0/project/time/filter
+func (c *Client) IterTime(ctx context.Context, f TimeFilter) *Pager[TimeEntry] {
+	return NewPager(ctx, DefaultPageSize, func(ctx context.Context, count, start int) ([]TimeEntry, int, error) {
+		return filterPageOf[TimeEntry](ctx, c, "/api/2.0/project/time/filter.json", f.query(), count, start)
+	})
+}
+
+// ListTime returns every time entry matching f.
+func (c *Client) ListTime(ctx context.Context, f TimeFilter) ([]TimeEntry, error) {
+	var out []TimeEntry
+	for e, err := range c.IterTime(ctx, f).All() {
+		if err != nil {
+			return out, err
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// TimeGroup is a TimeReport dimension.
+type TimeGroup string
+
+const (
+	TimeByPerson  TimeGroup = "person"
+	TimeByProject TimeGroup = "project"
+	TimeByTask    TimeGroup = "task"
+	TimeByWeek    TimeGroup = "week"   // ISO week, e.g. "2026-W42"
+	TimeByMonth   TimeGroup = "month"  // e.g. "2026-10"
+	TimeByStatus  TimeGroup = "status" // PaymentStatus.String
+)
+
+// ParseTimeGroups splits a comma-separated list such as "person,week".
+func ParseTimeGroups(spec string) ([]TimeGroup, error) {
+	var out []TimeGroup
+	for _, s := range strings.Split(spec, ",") {
+		g := TimeGroup(strings.TrimSpace(s))
+		switch g {
+		case "":
+			continue
+		case TimeByPerson, TimeByProject, TimeByTask, TimeByWeek, TimeByMonth, TimeByStatus:
+			out = append(out, g)
+		default:
+			return nil, fmt.Errorf("unknown time grouping %q (want person, project, task, week, month or status)", g)
+		}
+	}
+	return out, nil
+}
+
+// TimeReportRow is the sum of one group of entries.
+type TimeReportRow struct {
+	Keys    map[TimeGroup]string `json:"keys"`
+	Hours   float64              `json:"hours"`
+	Entries int                  `json:"entries"`
+}
+
+// TimeReport sums hours grouped by the given dimensions; rows are sorted
+// by their keys in the order given. Projects and tasks are keyed by id and
+// sort numerically.
+// With no dimensions it returns a single total row.
+func TimeReport(entries []TimeEntry, by ...TimeGroup) []TimeReportRow {
+	rows := map[string]*TimeReportRow{}
+	var order []string
+	for _, e := range entries {
+		keys := make(map[TimeGroup]string, len(by))
+		parts := make([]string, len(by))
+		for i, g := range by {
+			keys[g] = e.groupKey(g)
+			parts[i] = keys[g]
+		}
+		k := strings.Join(parts, "\x00")
+		row, ok := rows[k]
+		if !ok {
+			row = &TimeReportRow{Keys: keys}
+			rows[k] = row
+			order = append(order, k)
+		}
+		row.Hours += float64(e.Hours)
+		row.Entries++
+	}
+	slices.SortFunc(order, func(a, b string) int {
+		for _, g := range by {
+			if c := compareTimeKeys(g, rows[a].Keys[g], rows[b].Keys[g]); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	out := make([]TimeReportRow, len(order))
+	for i, k := range order {
+		out[i] = *rows[k]
+	}
+	return out
+}
+
+// compareTimeKeys orders two keys of dimension g: project and task ids by
+// number, everything else as text.
+func compareTimeKeys(g TimeGroup, a, b string) int {
+	if g == TimeByProject || g == TimeByTask {
+		x, errA := strconv.Atoi(a)
+		y, errB := strconv.Atoi(b)
+		if errA == nil && errB == nil {
+			return cmp.Compare(x, y)
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+func (e TimeEntry) groupKey(g TimeGroup) string {
+	var day time.Time
+	if e.Date != nil {
+		day = time.Time(*e.Date)
+	}
+	switch g {
+	case TimeByPerson:
+		return e.PersonName()
+	case TimeByProject:
+		return e.ProjectID.String()
+	case TimeByTask:
+		return e.TaskID.String()
+	case TimeByWeek:
+		y, w := day.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", y, w)
+	case TimeByMonth:
+		return day.Format("2006-01")
+	case TimeByStatus:
+		return e.PaymentStatus.String()
+	}
+	return ""
+}
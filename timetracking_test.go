@@ -0,0 +1,76 @@
+package onlyoffice
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParsePaymentStatus(t *testing.T) {
+	for in, want := range map[string]PaymentStatus{
+		"billable": PaymentNotBilled, "Paid": PaymentBilled, "not-chargeable": PaymentNotChargeable, "2": PaymentBilled,
+	} {
+		got, err := ParsePaymentStatus(in)
+		if err != nil || got != want {
+			t.Errorf("ParsePaymentStatus(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParsePaymentStatus("maybe"); err == nil {
+		t.Error("unknown status must fail")
+	}
+}
+
+func TestTimeReport(t *testing.T) {
+	var entries []TimeEntry
+	for _, s := range []string{
+		`{"id":1,"date":"2026-10-12T00:00:00","hours":2.5,"relatedProject":7,"relatedTask":70,"person":{"id":"a","displayName":"Ann"},"paymentStatus":1}`,
+		`{"id":2,"date":"2026-10-13T00:00:00","hours":1,"relatedProject":7,"relatedTask":71,"person":{"id":"a","displayName":"Ann"},"paymentStatus":2}`,
+		`{"id":3,"date":"2026-10-19T00:00:00","hours":4,"relatedProject":7,"relatedTask":70,"person":{"id":"b","displayName":"Bob"},"paymentStatus":1}`,
+		`{"id":4,"date":"2026-10-14T00:00:00","hours":0.5,"relatedProject":10,"relatedTask":9,"person":{"id":"a","displayName":"Ann"},"paymentStatus":0}`,
+	} {
+		var e TimeEntry
+		if err := json.Unmarshal([]byte(s), &e); err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, e)
+	}
+
+	total := TimeReport(entries)
+	if len(total) != 1 || total[0].Hours != 8 || total[0].Entries != 4 {
+		t.Errorf("total = %+v", total)
+	}
+
+	rows := TimeReport(entries, TimeByPerson, TimeByWeek)
+	var got []string
+	for _, r := range rows {
+		got = append(got, r.Keys[TimeByPerson]+"/"+r.Keys[TimeByWeek]+"="+strconv.FormatFloat(r.Hours, 'f', -1, 64))
+	}
+	want := "Ann/2026-W42=4, Bob/2026-W43=4"
+	if strings.Join(got, ", ") != want {
+		t.Errorf("person,week = %s; want %s", strings.Join(got, ", "), want)
+	}
+
+	byProject := TimeReport(entries, TimeByProject, TimeByStatus)
+	if len(byProject) != 3 || byProject[0].Keys[TimeByProject] != "7" || byProject[0].Keys[TimeByStatus] != "billed" {
+		t.Errorf("project,status = %+v", byProject)
+	}
+	// Ids sort as numbers: project 10 after 7, task 9 before 70.
+	if last := byProject[len(byProject)-1]; last.Keys[TimeByProject] != "10" {
+		t.Errorf("project 10 sorted before 7: %+v", byProject)
+	}
+	var tasks []string
+	for _, r := range TimeReport(entries, TimeByTask) {
+		tasks = append(tasks, r.Keys[TimeByTask])
+	}
+	if strings.Join(tasks, ",") != "9,70,71" {
+		t.Errorf("task order = %v", tasks)
+	}
+
+	if _, err := ParseTimeGroups("person, week"); err != nil {
+		t.Error(err)
+	}
+	if _, err := ParseTimeGroups("person,day"); err == nil {
+		t.Error("unknown grouping must fail")
+	}
+}
@@ -0,0 +1,220 @@
+package onlyoffice
+
+// Persistent token storage. Every CLI invocation builds a fresh Client, so
+// without a store each `oo` command re-authenticates — slow, and it fills
+// the portal's login audit log. A TokenStore lets the client reuse a token
+// until its Expires timestamp.
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenStore persists authentication tokens between processes. Keys come
+// from TokenStoreKey. Load returns (nil, nil) when no token is stored.
+type TokenStore interface {
+	Load(key string) (*Token, error)
+	Save(key string, t *Token) error
+	Delete(key string) error
+}
+
+// WithTokenStore makes the client load its token from s before
+// authenticating, save fresh tokens to s, and drop the stored token on
+// InvalidateToken.
+func WithTokenStore(s TokenStore) Option {
+	return func(c *Client) { c.tokenStore = s }
+}
+
+// TokenStoreKey identifies the portal account a token belongs to:
+// the base URL without trailing slash plus the lowercase user name.
+func TokenStoreKey(creds Credentials) string {
+	return strings.TrimRight(creds.Url, "/") + "|" + strings.ToLower(strings.TrimSpace(creds.User))
+}
+
+// DefaultTokenStorePath returns $XDG_CACHE_HOME/oo/tokens.json (or the
+// platform equivalent from os.UserCacheDir).
+func DefaultTokenStorePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "oo", "tokens.json"), nil
+}
+
+// FileTokenStore keeps tokens for any number of portal accounts in one JSON
+// file, written with 0600 permissions inside a 0700 directory.
+type FileTokenStore struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// NewFileTokenStore returns a store at path, or at DefaultTokenStorePath
+// when path is empty.
+func NewFileTokenStore(path string) (*FileTokenStore, error) {
+	if path == "" {
+		p, err := DefaultTokenStorePath()
+		if err != nil {
+			return nil, err
+		}
+		path = p
+	}
+	return &FileTokenStore{Path: path}, nil
+}
+
+// storedToken is the on-disk form. Token.Expires uses the OnlyOffice wire
+// format whose marshal/unmarshal layouts differ, so the file stores a plain
+// RFC 3339 timestamp instead.
+type storedToken struct {
+	Token   string    `json:"token"`
+	Expires time.Time `json:"expires"`
+	Saved   time.Time `json:"saved"`
+}
+
+// Load implements TokenStore.
+func (s *FileTokenStore) Load(key string) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	st, ok := all[key]
+	if !ok || st.Token == "" {
+		return nil, nil
+	}
+	return &Token{Value: st.Token, Expires: Time(st.Expires)}, nil
+}
+
+// Save implements TokenStore.
+func (s *FileTokenStore) Save(key string, t *Token) error {
+	if t == nil {
+		return s.Delete(key)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all, err := s.read()
+	if err != nil {
+		return err
+	}
+	all[key] = storedToken{Token: t.Value, Expires: time.Time(t.Expires), Saved: time.Now()}
+	return s.write(all)
+}
+
+// Delete implements TokenStore. Deleting an absent key is not an error.
+func (s *FileTokenStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := all[key]; !ok {
+		return nil
+	}
+	delete(all, key)
+	return s.write(all)
+}
+
+func (s *FileTokenStore) read() (map[string]storedToken, error) {
+	all := map[string]storedToken{}
+	b, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return all, nil
+	}
+	if err := json.Unmarshal(b, &all); err != nil {
+		return nil, fmt.Errorf("token store %s: %w", s.Path, err)
+	}
+	return all, nil
+}
+
+// write replaces the file atomically so a crash never leaves a truncated
+// store behind.
+func (s *FileTokenStore) write(all map[string]storedToken) error {
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0o700); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), ".tokens-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// loadStoredToken adopts a still-valid token from the store. Store errors
+// are not fatal — the client simply authenticates.
+func (c *Client) loadStoredToken() bool {
+	if c.tokenStore == nil {
+		return false
+	}
+	t, err := c.tokenStore.Load(TokenStoreKey(*c.credentials))
+	if err != nil || t == nil || t.Value == "" || !time.Time(t.Expires).After(time.Now()) {
+		return false
+	}
+	c.setToken(t)
+	return true
+}
+
+// persistToken caches t in memory and, when configured, in the store.
+func (c *Client) persistToken(t *Token) {
+	c.setToken(t)
+	if c.tokenStore != nil {
+		_ = c.tokenStore.Save(TokenStoreKey(*c.credentials), t)
+	}
+}
+
+// refreshStaleToken prepares req for a replay after a 401: it drops the
+// token req was sent with (unless another goroutine already replaced it),
+// obtains a fresh one and rewrites the Authorization header. It reports
+// false when there is nothing to gain from a replay.
+func (c *Client) refreshStaleToken(req *http.Request) bool {
+	sent := req.Header.Get("Authorization")
+	if c.tokenStore == nil || sent == "" || (req.Body != nil && req.GetBody == nil) {
+		return false
+	}
+	c.mu.Lock()
+	stale := c.token != nil && c.token.Value == sent
+	if stale {
+		c.dropTokenLocked()
+	}
+	c.mu.Unlock()
+	if stale {
+		_ = c.tokenStore.Delete(TokenStoreKey(*c.credentials))
+	}
+	tok, err := c.authHeader(req.Context())
+	if err != nil || tok == sent {
+		return false
+	}
+	req.Header.Set("Authorization", tok)
+	return true
+}
@@ -0,0 +1,76 @@
+package onlyoffice
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileTokenStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "oo", "tokens.json")
+	s, err := NewFileTokenStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok, err := s.Load("k"); err != nil || tok != nil {
+		t.Fatalf("empty store Load=%v,%v", tok, err)
+	}
+	exp := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := s.Save("k", &Token{Value: "abc", Expires: Time(exp)}); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0o600 {
+		t.Fatalf("perm=%o", perm)
+	}
+	tok, err := s.Load("k")
+	if err != nil || tok == nil {
+		t.Fatalf("Load=%v,%v", tok, err)
+	}
+	if tok.Value != "abc" || !time.Time(tok.Expires).Equal(exp) {
+		t.Fatalf("token=%+v", tok)
+	}
+	if err := s.Delete("k"); err != nil {
+		t.Fatal(err)
+	}
+	if tok, _ := s.Load("k"); tok != nil {
+		t.Fatalf("deleted token still loaded: %+v", tok)
+	}
+}
+
+func TestTokenStoreKeyNormalizes(t *testing.T) {
+	a := TokenStoreKey(Credentials{Url: "https://office.example/", User: " Bob@Example.com"})
+	b := TokenStoreKey(Credentials{Url: "https://office.example", User: "bob@example.com"})
+	if a != b {
+		t.Fatalf("%q != %q", a, b)
+	}
+}
+
+func TestClientAdoptsStoredTokenUntilExpiry(t *testing.T) {
+	s := &FileTokenStore{Path: filepath.Join(t.TempDir(), "tokens.json")}
+	creds := Credentials{Url: "http://portal.invalid", User: "u"}
+	_ = s.Save(TokenStoreKey(creds), &Token{Value: "stored", Expires: Time(time.Now().Add(time.Hour))})
+
+	c := NewClient(creds, WithTokenStore(s))
+	if err := c.Authenticate(); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.tokenValue(); got != "stored" {
+		t.Fatalf("token=%q", got)
+	}
+
+	c.InvalidateToken()
+	if tok, _ := s.Load(TokenStoreKey(creds)); tok != nil {
+		t.Fatal("InvalidateToken must drop the stored token")
+	}
+
+	_ = s.Save(TokenStoreKey(creds), &Token{Value: "expired", Expires: Time(time.Now().Add(-time.Minute))})
+	c = NewClient(creds, WithTokenStore(s))
+	if c.loadStoredToken() {
+		t.Fatal("expired stored token adopted")
+	}
+}
@@ -0,0 +1,512 @@
+package onlyoffice
+
+// Undo of journaled CRM mutations. A journal entry carries the payload and
+// the pre-change snapshot, which is enough to put most changes back:
+// deleted contacts and deals are recreated (under new ids — the portal
+// never reuses one), removed tags, members and contact info are re-added
+// and updated fields are written back. What the portal dropped for good,
+// such as history moved by a merge, is reported instead of guessed at.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUndoUnsupported is returned by Undo for entries it cannot reverse,
+// e.g. creates or non-CRM endpoints.
+var ErrUndoUnsupported = errors.New("onlyoffice: undo not supported")
+
+// ErrAlreadyUndone is returned by Undo and UndoFrom for an entry the
+// journal already holds a JournalUndo record of: running it again would
+// recreate the same contacts and deals a second time.
+var ErrAlreadyUndone = errors.New("onlyoffice: already undone")
+
+// UndoResult reports what Undo restored and what it could not.
+type UndoResult struct {
+	Entry    string   `json:"entry"`
+	Restored []string `json:"restored,omitempty"`
+	// Lost lists what the snapshot cannot bring back.
+	Lost   []string `json:"lost,omitempty"`
+	Errors []string `json:"errors,omitempty"`
+	// NewIDs maps the ids of recreated contacts and deals to their new ids.
+	NewIDs map[string]string `json:"newIds,omitempty"`
+}
+
+func (r *UndoResult) restored(format string, args ...any) {
+	r.Restored = append(r.Restored, fmt.Sprintf(format, args...))
+}
+
+func (r *UndoResult) lost(format string, args ...any) {
+	r.Lost = append(r.Lost, fmt.Sprintf(format, args...))
+}
+
+func (r *UndoResult) addErr(err error) {
+	if err != nil {
+		r.Errors = append(r.Errors, err.Error())
+	}
+}
+
+var (
+	undoContactTag = regexp.MustCompile(`^/api/2\.0/crm/contact/(\d+)/tag(?:\.json)?$`)
+	undoContactRow = regexp.MustCompile(`^/api/2\.0/crm/contact/(\d+)/data/(\d+)(?:\.json)?$`)
+	undoOppMember  = regexp.MustCompile(`^/api/2\.0/crm/opportunity/(\d+)/contact/(\d+)(?:\.json)?$`)
+)
+
+// Undo reverses a journaled CRM mutation: a contact delete or merge, a
+// deal delete, a contact or deal update, or the removal of a tag, a deal
+// member or a contact info row. Failures of single steps are collected in
+// the result; an error with a nil result means nothing was attempted.
+//
+// With a journal configured (WithJournal) Undo refuses entries that were
+// already undone and appends a JournalUndo record referencing e once
+// anything was attempted. UndoFrom does the same for a journal the client
+// does not write to.
+func (c *Client) Undo(ctx context.Context, e *JournalEntry) (*UndoResult, error) {
+	return c.undoIn(ctx, c.journal, e)
+}
+
+// UndoFrom reverses entry id of j like Undo, checking and recording the
+// undo in j.
+func (c *Client) UndoFrom(ctx context.Context, j Journal, id string) (*UndoResult, error) {
+	e, err := j.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	return c.undoIn(ctx, j, e)
+}
+
+// undoIn runs undo, guarded by and recorded in j when it is not nil. A
+// dry run records nothing: no request was sent.
+func (c *Client) undoIn(ctx context.Context, j Journal, e *JournalEntry) (*UndoResult, error) {
+	if j != nil {
+		prev, err := undoneBy(j, e)
+		if err != nil {
+			return nil, fmt.Errorf("undo %s: %w", e.ID, err)
+		}
+		if prev != "" {
+			return nil, fmt.Errorf("undo %s: undone by journal entry %s: %w", e.ID, prev, ErrAlreadyUndone)
+		}
+	}
+	res, err := c.undo(ctx, e)
+	if j == nil || res == nil || c.dryRun != nil {
+		return res, err
+	}
+	now := time.Now()
+	rec := &JournalEntry{
+		ID:     newJournalID(now),
+		Time:   now,
+		Portal: c.baseURL(),
+		User:   c.credentials.User,
+		Op:     JournalUndo,
+		Method: e.Method,
+		Path:   e.Path,
+		Body:   res,
+		Status: http.StatusOK,
+		UndoOf: e.ID,
+	}
+	if err != nil {
+		rec.Status, rec.Error = 0, err.Error()
+	}
+	if jerr := j.Append(rec); jerr != nil {
+		if err == nil {
+			return res, fmt.Errorf("undo %s: done, but not recorded in the journal: %w", e.ID, jerr)
+		}
+		if c.logger != nil {
+			c.logger.Warn("onlyoffice: journal append failed", "undo", e.ID, "error", jerr)
+		}
+	}
+	return res, err
+}
+
+// undoneBy returns the id of the JournalUndo record of e in j, or "".
+func undoneBy(j Journal, e *JournalEntry) (string, error) {
+	undos, err := j.List(JournalFilter{Op: JournalUndo, Portal: e.Portal})
+	if err != nil {
+		return "", err
+	}
+	for _, u := range undos {
+		if u.UndoOf == e.ID {
+			return u.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// undo is Undo without the journal bookkeeping.
+func (c *Client) undo(ctx context.Context, e *JournalEntry) (*UndoResult, error) {
+	if !e.OK() {
+		return nil, fmt.Errorf("undo %s: the request did not succeed (%s), nothing to undo", e.ID, e.Error)
+	}
+	if e.Portal != "" && e.Portal != c.baseURL() {
+		return nil, fmt.Errorf("undo %s: the entry is for %s, not %s", e.ID, e.Portal, c.baseURL())
+	}
+	res := &UndoResult{Entry: e.ID}
+	fields, _ := e.Body.(map[string]any)
+	var err error
+	switch {
+	case e.Op == JournalMerge:
+		err = c.undoMerge(ctx, e, fields, res)
+	case e.Op == JournalDelete && journalContactPath.MatchString(e.Path):
+		id := journalContactPath.FindStringSubmatch(e.Path)[1]
+		if _, err = c.restoreContact(ctx, e, id, res); err == nil {
+			res.lost("history, files, CRM tasks, cases and project links of contact #%s (not in the snapshot)", id)
+		}
+	case e.Op == JournalUpdate && journalContactPath.MatchString(e.Path):
+		err = c.restoreContactFields(ctx, e, journalContactPath.FindStringSubmatch(e.Path)[1], res)
+	case e.Op == JournalDelete && journalOppPath.MatchString(e.Path):
+		err = c.restoreOpportunity(ctx, e, journalOppPath.FindStringSubmatch(e.Path)[1], res)
+	case e.Op == JournalUpdate && journalOppPath.MatchString(e.Path):
+		err = c.restoreOpportunityFields(ctx, e, journalOppPath.FindStringSubmatch(e.Path)[1], res)
+	case e.Op == JournalDelete && undoContactTag.MatchString(e.Path):
+		id, tag := undoContactTag.FindStringSubmatch(e.Path)[1], stringField(fields, "tagName")
+		if tag == "" {
+			return nil, fmt.Errorf("undo %s: no tagName in the journaled body", e.ID)
+		}
+		if err = c.AddContactTag(ctx, id, tag); err == nil {
+			res.restored("tag %q on contact #%s", tag, id)
+		}
+	case e.Op == JournalDelete && undoContactRow.MatchString(e.Path):
+		m := undoContactRow.FindStringSubmatch(e.Path)
+		err = c.restoreContactRow(ctx, e, m[1], m[2], res)
+	case e.Op == JournalDelete && undoOppMember.MatchString(e.Path):
+		m := undoOppMember.FindStringSubmatch(e.Path)
+		if _, err = c.AddOpportunityMember(ctx, m[1], m[2]); err == nil {
+			res.restored("contact #%s on deal #%s", m[2], m[1])
+		}
+	default:
+		return nil, fmt.Errorf("undo %s (%s %s): %w", e.ID, e.Method, e.Path, ErrUndoUnsupported)
+	}
+	if err != nil {
+		return res, fmt.Errorf("undo %s: %w", e.ID, err)
+	}
+	return res, nil
+}
+
+// snapshot decodes the snapshot taken of path into out.
+func (e *JournalEntry) snapshot(path string, out any) bool {
+	raw, ok := e.Snapshot[path]
+	return ok && json.Unmarshal(raw, out) == nil
+}
+
+func contactSnapshotPath(id string) string { return JournalContactPaths(id)[0] }
+
+// restoreContact recreates a deleted contact from its snapshot with its
+// contact info, addresses, tags, deal memberships and — for a company —
+// the links of its persons. It returns the new id.
+func (c *Client) restoreContact(ctx context.Context, e *JournalEntry, oldID string, res *UndoResult) (string, error) {
+	var p Person
+	if !e.snapshot(contactSnapshotPath(oldID), &p) {
+		return "", fmt.Errorf("no snapshot of contact #%s in the journal entry", oldID)
+	}
+	var out map[string]any
+	var err error
+	if p.IsCompany {
+		var co Company
+		e.snapshot(contactSnapshotPath(oldID), &co)
+		name := co.CompanyName
+		if name == "" {
+			name = co.DisplayName
+		}
+		out, err = c.CreateCompany(ctx, name)
+		if err == nil && co.About != "" {
+			_, err = c.UpdateCompany(ctx, fmt.Sprint(flexInt(out["id"])), name, co.About)
+		}
+	} else {
+		companyID := 0
+		if p.Company != nil {
+			companyID = int(p.Company.ID)
+		}
+		out, err = c.CreatePerson(ctx, p.FirstName, p.LastName, companyID, p.Title, p.About)
+		if err != nil && companyID != 0 {
+			res.lost("link of contact #%s to company #%d: %v", oldID, companyID, err)
+			out, err = c.CreatePerson(ctx, p.FirstName, p.LastName, 0, p.Title, p.About)
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	newID := strconv.FormatInt(flexInt(out["id"]), 10)
+	if res.NewIDs == nil {
+		res.NewIDs = map[string]string{}
+	}
+	res.NewIDs[oldID] = newID
+	res.restored("contact #%s (%s) as #%s", oldID, p.DisplayName, newID)
+
+	rows := 0
+	for _, row := range p.CommonData {
+		if row.Kind() == "address" {
+			continue // restored from Addresses below
+		}
+		_, err := c.AddContactInfo(ctx, newID, contactInfoTypeName(row), row.Data, string(row.Category), row.IsPrimary)
+		if err != nil {
+			res.addErr(fmt.Errorf("contact info %s %q: %w", row.Kind(), row.Data, err))
+			continue
+		}
+		rows++
+	}
+	for _, a := range p.Addresses {
+		category := string(a.Category)
+		if a.CategoryName != "" {
+			category = a.CategoryName
+		}
+		if _, err := c.AddContactAddress(ctx, newID, a.Street, a.City, a.State, a.Zip, a.Country, category, a.IsPrimary); err != nil {
+			res.addErr(fmt.Errorf("address %s, %s: %w", a.Street, a.City, err))
+			continue
+		}
+		rows++
+	}
+	if rows > 0 {
+		res.restored("%d contact info row(s) and address(es) on #%s", rows, newID)
+	}
+	for _, tag := range p.Tags {
+		if err := c.AddContactTag(ctx, newID, tag); err != nil {
+			res.addErr(fmt.Errorf("tag %q: %w", tag, err))
+			continue
+		}
+		res.restored("tag %q on #%s", tag, newID)
+	}
+
+	var deals []Opportunity
+	e.snapshot("/api/2.0/crm/opportunity/bycontact/"+url.PathEscape(oldID)+".json", &deals)
+	for _, d := range deals {
+		if _, err := c.AddOpportunityMember(ctx, d.ID.String(), newID); err != nil {
+			res.addErr(fmt.Errorf("deal #%s (%s): %w", d.ID, d.Title, err))
+			continue
+		}
+		res.restored("#%s on deal #%s (%s)", newID, d.ID, d.Title)
+	}
+
+	var persons []Person
+	e.snapshot("/api/2.0/crm/contact/company/"+url.PathEscape(oldID)+"/person.json", &persons)
+	companyID, _ := strconv.Atoi(newID)
+	for _, person := range persons {
+		if _, err := c.UpdatePerson(ctx, person.ID.String(), person.FirstName, person.LastName, companyID, "", ""); err != nil {
+			res.addErr(fmt.Errorf("person #%s (%s): %w", person.ID, person.DisplayName, err))
+			continue
+		}
+		res.restored("person #%s (%s) under #%s", person.ID, person.DisplayName, newID)
+	}
+
+	res.lost("the id: contact #%s now is #%s", oldID, newID)
+	return newID, nil
+}
+
+// contactInfoTypeName maps a snapshot's infoType (code or name) to the
+// name AddContactInfo sends.
+func contactInfoTypeName(row ContactInfo) string {
+	switch k := row.Kind(); k {
+	case "linkedin":
+		return "LinkedIn"
+	case "gmail":
+		return "GMail"
+	case "livejournal":
+		return "LiveJournal"
+	case "myspace":
+		return "MySpace"
+	case "msn", "icq", "aim":
+		return strings.ToUpper(k)
+	case "":
+		return string(row.InfoType)
+	default:
+		return strings.ToUpper(k[:1]) + k[1:]
+	}
+}
+
+// restoreContactFields writes a contact's snapshot names, job title, about
+// text and company link back.
+func (c *Client) restoreContactFields(ctx context.Context, e *JournalEntry, id string, res *UndoResult) error {
+	var p Person
+	if !e.snapshot(contactSnapshotPath(id), &p) {
+		return fmt.Errorf("no snapshot of contact #%s in the journal entry", id)
+	}
+	if p.IsCompany {
+		var co Company
+		e.snapshot(contactSnapshotPath(id), &co)
+		if _, err := c.UpdateCompany(ctx, id, co.CompanyName, co.About); err != nil {
+			return err
+		}
+	} else {
+		companyID := 0
+		if p.Company != nil {
+			companyID = int(p.Company.ID)
+		}
+		if _, err := c.UpdatePerson(ctx, id, p.FirstName, p.LastName, companyID, p.Title, p.About); err != nil {
+			return err
+		}
+	}
+	res.restored("fields of contact #%s (%s)", id, p.DisplayName)
+	// The update endpoints cannot clear a field, only set it.
+	if p.About == "" || (!p.IsCompany && (p.Title == "" || p.Company == nil)) {
+		res.lost("fields empty in the snapshot (about, job title, company) keep their current values on #%s", id)
+	}
+	return nil
+}
+
+// restoreContactRow re-adds a removed contact info row from the contact
+// snapshot.
+func (c *Client) restoreContactRow(ctx context.Context, e *JournalEntry, contactID, rowID string, res *UndoResult) error {
+	var p Person
+	if !e.snapshot(contactSnapshotPath(contactID), &p) {
+		return fmt.Errorf("no snapshot of contact #%s in the journal entry", contactID)
+	}
+	for _, row := range p.CommonData {
+		if row.ID.String() != rowID {
+			continue
+		}
+		if _, err := c.AddContactInfo(ctx, contactID, contactInfoTypeName(row), row.Data, string(row.Category), row.IsPrimary); err != nil {
+			return err
+		}
+		res.restored("%s %q on contact #%s", row.Kind(), row.Data, contactID)
+		return nil
+	}
+	return fmt.Errorf("contact info row #%s is not in the snapshot of contact #%s", rowID, contactID)
+}
+
+// restoreOpportunity recreates a deleted deal with its members.
+func (c *Client) restoreOpportunity(ctx context.Context, e *JournalEntry, oldID string, res *UndoResult) error {
+	var o Opportunity
+	if !e.snapshot(e.Path, &o) {
+		return fmt.Errorf("no snapshot of deal #%s in the journal entry", oldID)
+	}
+	var stageID int
+	if o.Stage != nil {
+		stageID = int(o.Stage.ID)
+	}
+	var responsible, currency string
+	if o.Responsible != nil && o.Responsible.ID != nil {
+		responsible = *o.Responsible.ID
+	}
+	if o.BidCurrency != nil {
+		currency = o.BidCurrency.Abbreviation
+	}
+	out, err := c.CreateOpportunity(ctx, o.Title, stageID, responsible, currency, o.Description, float64(o.BidValue))
+	if err != nil {
+		return err
+	}
+	newID := strconv.FormatInt(flexInt(out["id"]), 10)
+	res.NewIDs = map[string]string{oldID: newID}
+	res.restored("deal #%s (%s) as #%s", oldID, o.Title, newID)
+
+	members := o.Members
+	var listed []CRMContact
+	if e.snapshot("/api/2.0/crm/opportunity/"+oldID+"/contact.json", &listed) && len(listed) > 0 {
+		members = listed
+	}
+	seen := map[int64]bool{}
+	for _, m := range members {
+		if m.ID == 0 || seen[int64(m.ID)] {
+			continue
+		}
+		seen[int64(m.ID)] = true
+		if _, err := c.AddOpportunityMember(ctx, newID, m.ID.String()); err != nil {
+			res.addErr(fmt.Errorf("member #%s (%s): %w", m.ID, m.DisplayName, err))
+			continue
+		}
+		res.restored("contact #%s (%s) on #%s", m.ID, m.DisplayName, newID)
+	}
+	res.lost("the id: deal #%s now is #%s", oldID, newID)
+	res.lost("history, files, tasks, close dates and access list of deal #%s", oldID)
+	return nil
+}
+
+// restoreOpportunityFields PUTs a deal's snapshot back.
+func (c *Client) restoreOpportunityFields(ctx context.Context, e *JournalEntry, id string, res *UndoResult) error {
+	var opp map[string]any
+	if !e.snapshot(e.Path, &opp) {
+		return fmt.Errorf("no snapshot of deal #%s in the journal entry", id)
+	}
+	if _, err := c.putJSONObject(ctx, e.Path, opportunityUpdateBody(opp, stringField(opp, "title"))); err != nil {
+		return err
+	}
+	res.restored("fields and members of deal #%s (%s)", id, stringField(opp, "title"))
+	return nil
+}
+
+// undoMerge recreates the merged-away contact and takes back what the
+// merge added to the surviving one: contact info, tags and deal
+// memberships it did not have before.
+func (c *Client) undoMerge(ctx context.Context, e *JournalEntry, fields map[string]any, res *UndoResult) error {
+	if fields["fromContactId"] == nil || fields["toContactId"] == nil {
+		return errors.New("no fromContactId/toContactId in the journaled body")
+	}
+	from, to := fmt.Sprint(fields["fromContactId"]), fmt.Sprint(fields["toContactId"])
+	var src, before Person
+	if !e.snapshot(contactSnapshotPath(from), &src) || !e.snapshot(contactSnapshotPath(to), &before) {
+		return fmt.Errorf("no snapshot of contacts #%s and #%s in the journal entry", from, to)
+	}
+	if _, err := c.restoreContact(ctx, e, from, res); err != nil {
+		return err
+	}
+
+	now, err := c.GetContact(ctx, to)
+	if err != nil {
+		res.addErr(fmt.Errorf("surviving contact #%s: %w", to, err))
+		return nil
+	}
+	var after Person
+	if raw, err := json.Marshal(now); err == nil {
+		_ = json.Unmarshal(raw, &after)
+	}
+	had := map[string]bool{}
+	for _, row := range before.CommonData {
+		had[row.Kind()+"\x00"+row.Data] = true
+	}
+	moved := map[string]bool{}
+	for _, row := range src.CommonData {
+		moved[row.Kind()+"\x00"+row.Data] = true
+	}
+	for _, row := range after.CommonData {
+		key := row.Kind() + "\x00" + row.Data
+		if had[key] || !moved[key] {
+			continue
+		}
+		had[key] = true // one copy per merged row
+		if _, err := c.DeleteContactInfo(ctx, to, row.ID.String()); err != nil {
+			res.addErr(fmt.Errorf("contact info %q on #%s: %w", row.Data, to, err))
+			continue
+		}
+		res.restored("removed merged %s %q from #%s", row.Kind(), row.Data, to)
+	}
+	for _, tag := range src.Tags {
+		if slices.Contains(before.Tags, tag) || !slices.Contains(after.Tags, tag) {
+			continue
+		}
+		if err := c.RemoveContactTag(ctx, to, tag); err != nil {
+			res.addErr(fmt.Errorf("tag %q on #%s: %w", tag, to, err))
+			continue
+		}
+		res.restored("removed merged tag %q from #%s", tag, to)
+	}
+
+	var srcDeals, toDeals []Opportunity
+	e.snapshot("/api/2.0/crm/opportunity/bycontact/"+url.PathEscape(from)+".json", &srcDeals)
+	if e.snapshot("/api/2.0/crm/opportunity/bycontact/"+url.PathEscape(to)+".json", &toDeals) {
+		member := map[int64]bool{}
+		for _, d := range toDeals {
+			member[int64(d.ID)] = true
+		}
+		for _, d := range srcDeals {
+			if member[int64(d.ID)] {
+				continue
+			}
+			if _, err := c.RemoveOpportunityMember(ctx, d.ID.String(), to); err != nil {
+				res.addErr(fmt.Errorf("#%s on deal #%s: %w", to, d.ID, err))
+				continue
+			}
+			res.restored("removed #%s from deal #%s (%s)", to, d.ID, d.Title)
+		}
+	} else if len(srcDeals) > 0 {
+		res.lost("deal memberships the merge gave #%s (no snapshot of its deals)", to)
+	}
+	res.lost("merge history: notes, files, tasks, cases and project links of #%s stay on #%s", from, to)
+	return nil
+}
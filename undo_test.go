@@ -0,0 +1,70 @@
+package onlyoffice
+
+// Undo refusals, checked before any request is sent. Undoing against a
+// portal is covered by TestIntegrationUndoDeletedCompany.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// noRequests fails the test on any request: the refusals below must happen
+// before anything is sent.
+func noRequests(t *testing.T, opts ...Option) *Client {
+	t.Helper()
+	return NewClient(Credentials{Url: "http://portal.invalid", Token: "tok"},
+		append(opts, WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			return nil, errors.New("unexpected request")
+		})))...)
+}
+
+func undoEntry(op, method, path string, body any) *JournalEntry {
+	return &JournalEntry{ID: "20261016-120000.000001", Portal: "http://portal.invalid", Op: op,
+		Method: method, Path: path, Body: body, Status: http.StatusOK}
+}
+
+func TestUndoRefusesUnsupportedEntries(t *testing.T) {
+	ctx := context.Background()
+	c := noRequests(t)
+	if _, err := c.Undo(ctx, undoEntry(JournalCreate, "POST", "/api/2.0/crm/contact/company.json", nil)); !errors.Is(err, ErrUndoUnsupported) {
+		t.Errorf("create: err = %v, want ErrUndoUnsupported", err)
+	}
+	failed := undoEntry(JournalDelete, "DELETE", "/api/2.0/crm/contact/4/tag.json", nil)
+	failed.Status, failed.Error = http.StatusForbidden, "403 Forbidden"
+	if _, err := c.Undo(ctx, failed); err == nil {
+		t.Error("undo of a failed request must refuse")
+	}
+	other := undoEntry(JournalDelete, "DELETE", "/api/2.0/crm/contact/4/tag.json", map[string]any{"tagName": "lead"})
+	other.Portal = "https://other.example"
+	if _, err := c.Undo(ctx, other); err == nil {
+		t.Error("undo against another portal must refuse")
+	}
+}
+
+func TestUndoRefusesEntryAlreadyUndone(t *testing.T) {
+	j, err := NewFileJournal(filepath.Join(t.TempDir(), "journal.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := undoEntry(JournalDelete, "DELETE", "/api/2.0/crm/contact/4/tag.json", map[string]any{"tagName": "lead"})
+	undone := &JournalEntry{ID: "20261016-130000.000001", Portal: e.Portal, Op: JournalUndo,
+		Method: e.Method, Path: e.Path, Status: http.StatusOK, UndoOf: e.ID}
+	for _, x := range []*JournalEntry{e, undone} {
+		if err := j.Append(x); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c := noRequests(t, WithJournal(j))
+	ctx := context.Background()
+	if _, err := c.UndoFrom(ctx, j, e.ID); !errors.Is(err, ErrAlreadyUndone) || !strings.Contains(err.Error(), undone.ID) {
+		t.Errorf("UndoFrom: err = %v, want ErrAlreadyUndone naming %s", err, undone.ID)
+	}
+	if _, err := c.Undo(ctx, e); !errors.Is(err, ErrAlreadyUndone) {
+		t.Errorf("Undo: err = %v, want ErrAlreadyUndone", err)
+	}
+}
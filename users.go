@@ -66,12 +66,21 @@ type Group struct {
 }
 
 // GetUsers lists all portal users.
-func (c *Client) GetUsers() (list []*User, err error) {
-	return list, c.Query(Request{Uri: "/api/2.0/people/filter.json"},
-		&struct {
-			MetaResponse `json:",inline"`
-			Response     *[]*User `json:"response"`
-		}{Response: &list})
+func (c *Client) GetUsers() ([]*User, error) {
+	return c.GetUsersContext(context.Background())
+}
+
+// GetUsersContext is the context-aware variant of GetUsers.
+func (c *Client) GetUsersContext(ctx context.Context) ([]*User, error) {
+	return cached(ctx, c, CacheUsers, "list", func(ctx context.Context) ([]*User, error) {
+		var list []*User
+		err := c.QueryContext(ctx, Request{Uri: "/api/2.0/people/filter.json"},
+			&struct {
+				MetaResponse `json:",inline"`
+				Response     *[]*User `json:"response"`
+			}{Response: &list})
+		return list, err
+	})
 }
 
 // GetUser returns one portal user profile by ID.
@@ -108,8 +117,11 @@ func (c *Client) ChangeUserPassword(ctx context.Context, userID, password string
 
 // SelfUserID returns the ID of the authenticated user (people/@self), cached.
 func (c *Client) SelfUserID(ctx context.Context) (string, error) {
-	if c.selfID != "" {
-		return c.selfID, nil
+	c.mu.Lock()
+	cached := c.selfID
+	c.mu.Unlock()
+	if cached != "" {
+		return cached, nil
 	}
 	raw, err := c.getJSON(ctx, "/api/2.0/people/@self.json")
 	if err != nil {
@@ -123,6 +135,8 @@ func (c *Client) SelfUserID(ctx context.Context) (string, error) {
 	if err := json.Unmarshal(raw, &env); err != nil {
 		return "", err
 	}
+	c.mu.Lock()
 	c.selfID = env.Response.ID
-	return c.selfID, nil
+	c.mu.Unlock()
+	return env.Response.ID, nil
 }